@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/server"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/list"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/revoke"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/rotate"
+)
+
+const apiKeyUsage = `usage:
+  api-key create -name <name> -scopes <role,...> [-expires-at <RFC3339>]  create a key, it is printed only once
+  api-key list                                                           list the keys, without the keys
+  api-key rotate [-overlap <duration>] [-expires-at <RFC3339>] <id>      replace a key, the old one works until the end of the overlap
+  api-key revoke <id>                                                    reject a key immediately`
+
+func runApiKeyCommand(ctx context.Context, dependency server.Dependency, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(apiKeyUsage)
+	}
+
+	encoder := json.NewEncoder(out)
+	flags := flag.NewFlagSet("api-key "+args[0], flag.ContinueOnError)
+
+	switch args[0] {
+	case "create":
+		name := flags.String("name", "", "name of the client of the key")
+		scopes := flags.String("scopes", "", "comma separated roles granted to the key")
+		expiresAt := flags.String("expires-at", "", "expiration of the key, RFC3339")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		expiration, err := parseExpiration(*expiresAt)
+		if err != nil {
+			return err
+		}
+
+		apiKey, err := dependency.CreateApiKey.Handle(ctx, create.CreateApiKeyInput{
+			Name:      *name,
+			Scopes:    splitScopes(*scopes),
+			ExpiresAt: expiration,
+		})
+		if err != nil {
+			return err
+		}
+
+		return encoder.Encode(apiKey)
+	case "list":
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		apiKeys, err := dependency.ListApiKey.Handle(ctx, list.ListApiKeyInput{})
+		if err != nil {
+			return err
+		}
+
+		for _, apiKey := range apiKeys {
+			if err := encoder.Encode(apiKey); err != nil {
+				return err
+			}
+		}
+	case "rotate":
+		overlap := flags.Duration("overlap", 0, "how long the rotated key is still accepted, API_KEY_ROTATION_OVERLAP when not informed")
+		expiresAt := flags.String("expires-at", "", "expiration of the new key, RFC3339")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		if flags.NArg() != 1 {
+			return errors.New(apiKeyUsage)
+		}
+
+		expiration, err := parseExpiration(*expiresAt)
+		if err != nil {
+			return err
+		}
+
+		request := rotate.RotateApiKeyInput{
+			Id:        flags.Arg(0),
+			ExpiresAt: expiration,
+		}
+
+		flags.Visit(func(f *flag.Flag) {
+			if f.Name == "overlap" {
+				minutes := int(overlap.Minutes())
+				request.OverlapMinutes = &minutes
+			}
+		})
+
+		apiKey, err := dependency.RotateApiKey.Handle(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		return encoder.Encode(apiKey)
+	case "revoke":
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		if flags.NArg() != 1 {
+			return errors.New(apiKeyUsage)
+		}
+
+		if err := dependency.RevokeApiKey.Handle(ctx, revoke.RevokeApiKeyInput{Id: flags.Arg(0)}); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "API key %s revoked\n", flags.Arg(0))
+	default:
+		return errors.New(apiKeyUsage)
+	}
+
+	return nil
+}
+
+func splitScopes(value string) []string {
+	scopes := make([]string, 0)
+
+	for _, scope := range strings.Split(value, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+func parseExpiration(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiration %q, please use RFC3339: %w", value, err)
+	}
+
+	return &expiresAt, nil
+}
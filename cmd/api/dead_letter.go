@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter"
+)
+
+const deadLetterUsage = `usage:
+  dlq list [-max N]                  list the messages in the dead letter queue
+  dlq redrive (-all | <message_id>...)  send messages back to the order production queue
+  dlq replay [-dry-run] <file.jsonl>    process the messages of a JSONL file`
+
+func runDeadLetterCommand(ctx context.Context, deadLetterQueue dead_letter.DeadLetterQueueService, args []string, out io.Writer) error {
+	if deadLetterQueue == nil {
+		return errors.New("dead letter queue is not configured, please set AWS_ORDER_PRODUCTION_DLQ_NAME")
+	}
+
+	if len(args) == 0 {
+		return errors.New(deadLetterUsage)
+	}
+
+	encoder := json.NewEncoder(out)
+	flags := flag.NewFlagSet("dlq "+args[0], flag.ContinueOnError)
+
+	switch args[0] {
+	case "list":
+		max := flags.Int("max", 100, "maximum number of messages to list")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		messages, err := deadLetterQueue.ListMessages(ctx, *max)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if err := encoder.Encode(message); err != nil {
+				return err
+			}
+		}
+	case "redrive":
+		all := flags.Bool("all", false, "redrive every message")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		messageIds := flags.Args()
+		if len(messageIds) == 0 && !*all {
+			return errors.New(deadLetterUsage)
+		}
+
+		if *all {
+			messageIds = nil
+		}
+
+		redriven, err := deadLetterQueue.Redrive(ctx, messageIds)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "%d message(s) redriven\n", len(redriven))
+	case "replay":
+		dryRun := flags.Bool("dry-run", false, "validate the messages without persisting them")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		if flags.NArg() != 1 {
+			return errors.New(deadLetterUsage)
+		}
+
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		results, err := deadLetterQueue.Replay(ctx, file, *dryRun)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.New(deadLetterUsage)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter"
+	cloud_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunDeadLetterCommand(t *testing.T) {
+	t.Run("Should replay the messages of the file as the operator", func(t *testing.T) {
+		// Arrange
+		ctx := operatorContext(context.Background(), "store_1")
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+		topic := cloud_mocks.NewMockTopicService(t)
+
+		repository.On("GetByID", mock.Anything, "c3fdab1b-3c06-4db2-9edc-4760a2429462").
+			Return(order_entity.Order{}, custom_error.ErrOrderNotFound).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+			Once()
+
+		repository.On("Create", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", mock.Anything, audit_entity.OrderCreatedAction, audit_entity.OrderResource, "c3fdab1b-3c06-4db2-9edc-4760a2429462", nil, mock.Anything).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now())
+
+		topic.On("PublishMessage", mock.Anything, mock.Anything).
+			Return(aws.String("message-id"), nil).
+			Once()
+
+		// the real policy authorizes the creation of the orders
+		processor := create.NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		deadLetterQueue := &dead_letter.AwsSqsDeadLetterQueueService{
+			MessageProcessor:        processor,
+			UpdateOrderTopicService: topic,
+			Recorder:                recorder,
+		}
+
+		file := filepath.Join(t.TempDir(), "messages.jsonl")
+		err := os.WriteFile(file, []byte(`{"order_id":"c3fdab1b-3c06-4db2-9edc-4760a2429462","items":[{"id":"cfdab175-1f86-4fb0-9bcb-15f2c58df30c","name":"Hamburger","quantity":1}]}`+"\n"), 0o600)
+		assert.NoError(t, err)
+
+		out := &bytes.Buffer{}
+
+		// Act
+		err = runDeadLetterCommand(ctx, deadLetterQueue, []string{"replay", file}, out)
+
+		// Assert
+		assert.NoError(t, err)
+
+		var result dead_letter.ReplayResult
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		assert.True(t, result.Success, result.Error)
+		assert.Equal(t, "c3fdab1b-3c06-4db2-9edc-4760a2429462", result.OrderId)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		topic.AssertExpectations(t)
+	})
+}
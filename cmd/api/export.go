@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/export"
+)
+
+const exportUsage = `usage:
+  export -from <date> -to <date> [-state Delivered,Cancelled] [-format csv|jsonl] [-out file]`
+
+func runExportCommand(ctx context.Context, exporter service.ExportOrderProductionService[export.ExportOrderProductionInput], args []string, out io.Writer) error {
+	var request export.ExportOrderProductionInput
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.StringVar(&request.From, "from", "", "first day of the export (2006-01-02 or RFC 3339)")
+	flags.StringVar(&request.To, "to", "", "last day of the export (2006-01-02 or RFC 3339)")
+	flags.StringVar(&request.States, "state", "", "comma separated list of states, every state when empty")
+	flags.StringVar(&request.Format, "format", export.FormatCSV, "format of the export, csv or jsonl")
+	output := flags.String("out", "", "file to write the export, stdout when empty")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if request.From == "" || request.To == "" {
+		return errors.New(exportUsage)
+	}
+
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	count, err := exporter.Handle(ctx, request, out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%d order(s) exported\n", count)
+
+	return nil
+}
@@ -3,31 +3,32 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"os/user"
 	"syscall"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/tracing"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/environment/loader"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/server"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/logger"
 )
 
-func init() {
-	var err error
-	time.Local, err = time.LoadLocation("America/Sao_Paulo")
-	if err != nil {
-		panic(err)
-	}
-}
-
 func main() {
 	ctx := context.Background()
 
@@ -36,9 +37,12 @@ func main() {
 	var config *environment.Config
 	var err error
 
-	if len(os.Args) > 1 && os.Args[1] == "local" {
+	args := os.Args[1:]
+
+	if len(args) > 0 && args[0] == "local" {
 		slog.InfoContext(ctx, "loading environment from .env file")
 		config, err = loader.GetEnvironmentFromFile(ctx, ".env")
+		args = args[1:]
 	} else {
 		config, err = loader.GetEnvironment(ctx)
 	}
@@ -48,8 +52,33 @@ func main() {
 		panic(err)
 	}
 
+	if err := config.AuthConfig.Validate(); err != nil {
+		slog.ErrorContext(ctx, "error validating the authentication", "error", err)
+		panic(err)
+	}
+
+	if err := config.CloudConfig.Validate(); err != nil {
+		slog.ErrorContext(ctx, "error validating the cloud configuration", "error", err)
+		panic(err)
+	}
+
 	logger.SetupLog(config)
 
+	shutdownTracing, err := tracing.Setup(ctx, config)
+	if err != nil {
+		slog.ErrorContext(ctx, "error setting up the tracing", "error", err)
+		panic(err)
+	}
+
+	location, err := time.LoadLocation(config.ApiConfig.Timezone)
+	if err != nil {
+		slog.ErrorContext(ctx, "error loading the timezone", "timezone", config.ApiConfig.Timezone, "error", err)
+		panic(err)
+	}
+
+	time.Local = location
+	order_entity.SetLocation(location)
+
 	cloudConfig, err := awsConfig.LoadDefaultConfig(ctx)
 	if err != nil {
 		panic(err)
@@ -71,6 +100,24 @@ func main() {
 
 	server := server.NewServer(config)
 
+	// the export and the API keys only use the database, so they do not need
+	// the queues
+	if len(args) > 0 && args[0] == "export" {
+		if err := runExportCommand(operatorContext(ctx, config.ApiConfig.StoreId), server.Dependency.ExportOrderProduction, args[1:], os.Stdout); err != nil {
+			slog.ErrorContext(ctx, "error running export command", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(args) > 0 && args[0] == "api-key" {
+		if err := runApiKeyCommand(operatorContext(ctx, config.ApiConfig.StoreId), server.Dependency, args[1:], os.Stdout); err != nil {
+			slog.ErrorContext(ctx, "error running API key command", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := server.UpdateOrderTopicService.UpdateTopicArn(ctx); err != nil {
 		slog.ErrorContext(ctx, "error updating update order topic url", "error", err)
 		panic(err)
@@ -81,12 +128,33 @@ func main() {
 		panic(err)
 	}
 
+	if server.DeadLetterQueueService != nil {
+		if err := server.DeadLetterQueueService.UpdateQueueUrl(ctx); err != nil {
+			slog.ErrorContext(ctx, "error updating dead letter queue url", "error", err)
+			panic(err)
+		}
+	}
+
+	if len(args) > 0 && args[0] == "dlq" {
+		if err := runDeadLetterCommand(operatorContext(ctx, config.ApiConfig.StoreId), server.DeadLetterQueueService, args[1:], os.Stdout); err != nil {
+			slog.ErrorContext(ctx, "error running dead letter queue command", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	go func(ctx context.Context) {
 		for {
 			server.QueueService.ConsumeMessages(ctx)
 		}
 	}(ctx)
 
+	listenerCtx, stopListener := context.WithCancel(ctx)
+	defer stopListener()
+
+	go server.OrderStreamListener.Listen(listenerCtx)
+	go server.OrderEventPruner.Run(listenerCtx)
+
 	httpServer := server.GetHttpServer()
 
 	go func() {
@@ -98,6 +166,23 @@ func main() {
 		slog.InfoContext(ctx, "http server stopped serving requests")
 	}()
 
+	grpcServer := server.GetGrpcServer()
+
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", config.GrpcConfig.Port))
+	if err != nil {
+		slog.ErrorContext(ctx, "error listening the grpc port", "error", err)
+		panic(err)
+	}
+
+	go func() {
+		slog.InfoContext(ctx, "🚀 gRPC server started", "address", grpcListener.Addr().String())
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			slog.ErrorContext(ctx, "grpc server error", "error", err)
+			panic(err)
+		}
+		slog.InfoContext(ctx, "grpc server stopped serving requests")
+	}()
+
 	sc := make(chan os.Signal, 1)
 	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	<-sc
@@ -105,8 +190,60 @@ func main() {
 	ctx, shutdown := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdown()
 
+	server.GrpcHealthServer.Shutdown()
+
 	if err := httpServer.Shutdown(ctx); err != nil {
 		slog.ErrorContext(ctx, "error while trying to shutdown the server", "error", err)
 	}
+
+	// the order streams were closed with the http server, the graceful stop
+	// only waits for the calls in progress
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		slog.ErrorContext(ctx, "error while trying to shutdown the grpc server", "error", ctx.Err())
+		grpcServer.Stop()
+	}
+
+	if err := server.WebhookDispatcher.Wait(ctx); err != nil {
+		slog.ErrorContext(ctx, "error while waiting the pending webhook deliveries", "error", err)
+	}
+
+	if server.AutoPrintService != nil {
+		if err := server.AutoPrintService.Wait(ctx); err != nil {
+			slog.ErrorContext(ctx, "error while waiting the pending ticket prints", "error", err)
+		}
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		slog.ErrorContext(ctx, "error while flushing the pending spans", "error", err)
+	}
+
 	slog.InfoContext(ctx, "graceful shutdown completed ✅")
 }
+
+// operatorContext records the changes of the commands in the audit log as made
+// by the user of the operating system running them, the logs and the events of
+// the command share a new correlation id. The operator has access to the
+// database and the queues, so the commands are authorized as a manager of the
+// store of the deployment
+func operatorContext(ctx context.Context, storeId string) context.Context {
+	ctx = correlation.WithIds(ctx, "", "")
+	ctx = authorization.WithRoles(ctx, authorization.ManagerRole)
+	ctx = authorization.WithStore(ctx, storeId)
+
+	actor := audit_entity.Actor{Type: audit_entity.OperatorActor}
+
+	if current, err := user.Current(); err == nil {
+		actor.Id = current.Username
+	}
+
+	ctx = audit.WithActor(ctx, actor)
+	return audit.WithSource(ctx, audit_entity.Source{Type: audit_entity.CliSource})
+}
@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+)
+
+type actorKey struct{}
+
+type sourceKey struct{}
+
+// WithActor stores the author of the changes in the context
+func WithActor(ctx context.Context, actor audit_entity.Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, the unknown actor
+// when there is none
+func ActorFromContext(ctx context.Context) audit_entity.Actor {
+	actor, ok := ctx.Value(actorKey{}).(audit_entity.Actor)
+	if !ok {
+		return audit_entity.Actor{Type: audit_entity.UnknownActor}
+	}
+
+	return actor
+}
+
+// WithSource stores where the changes came from in the context
+func WithSource(ctx context.Context, source audit_entity.Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+func SourceFromContext(ctx context.Context) audit_entity.Source {
+	source, _ := ctx.Value(sourceKey{}).(audit_entity.Source)
+	return source
+}
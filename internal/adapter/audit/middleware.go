@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/labstack/echo/v4"
+)
+
+// Middleware marks the changes of the request as coming from the HTTP API,
+// with the id of the request
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+
+			requestId := correlation.RequestIdFromContext(request.Context())
+			if requestId == "" {
+				requestId = request.Header.Get(echo.HeaderXRequestID)
+			}
+
+			ctx := WithSource(request.Context(), audit_entity.Source{
+				Type:      audit_entity.HttpSource,
+				RequestId: requestId,
+			})
+
+			c.SetRequest(request.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
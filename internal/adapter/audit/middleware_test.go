@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Should store the HTTP source with the request id", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.PATCH, "/", nil)
+		req.Header.Set(echo.HeaderXRequestID, "request-id")
+		res := httptest.NewRecorder()
+
+		var source audit_entity.Source
+
+		e := echo.New()
+		e.Use(Middleware())
+		e.PATCH("/", func(c echo.Context) error {
+			source = SourceFromContext(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})
+
+		// Act
+		e.ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, audit_entity.Source{Type: audit_entity.HttpSource, RequestId: "request-id"}, source)
+	})
+	t.Run("Should store the request id of the context", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.PATCH, "/", nil)
+		res := httptest.NewRecorder()
+
+		var source audit_entity.Source
+
+		e := echo.New()
+		e.Use(correlation.Middleware(), Middleware())
+		e.PATCH("/", func(c echo.Context) error {
+			source = SourceFromContext(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})
+
+		// Act
+		e.ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, res.Header().Get(echo.HeaderXRequestID), source.RequestId)
+		assert.NotEmpty(t, source.RequestId)
+	})
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+// Recorder appends the changes to the audit log, with the actor and the source
+// stored in the context
+type Recorder interface {
+	Record(ctx context.Context, action string, resourceType string, resourceId string, before interface{}, after interface{}) error
+}
+
+type DatabaseRecorder struct {
+	repository   repository.AuditRepository
+	timeProvider provider.TimeProvider
+}
+
+func NewRecorder(repository repository.AuditRepository, timeProvider provider.TimeProvider) *DatabaseRecorder {
+	return &DatabaseRecorder{
+		repository:   repository,
+		timeProvider: timeProvider,
+	}
+}
+
+// Record is called in the transaction of the change, started with
+// repository.Transactor, so the change is not saved when its entry cannot be
+func (r *DatabaseRecorder) Record(ctx context.Context, action string, resourceType string, resourceId string, before interface{}, after interface{}) error {
+	entry, err := audit_entity.NewEntry(
+		action,
+		resourceType,
+		resourceId,
+		ActorFromContext(ctx),
+		SourceFromContext(ctx),
+		before,
+		after,
+		r.timeProvider.GetTime(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.repository.CreateEntry(ctx, &entry)
+}
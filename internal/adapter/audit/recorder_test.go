@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecord(t *testing.T) {
+	t.Run("Should append the entry with the actor and the source of the context", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		actor := audit_entity.Actor{Type: audit_entity.UserActor, Id: "user-id"}
+		source := audit_entity.Source{Type: audit_entity.HttpSource, RequestId: "request-id"}
+
+		ctx := WithSource(WithActor(context.Background(), actor), source)
+
+		repository := repository_mocks.NewMockAuditRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		repository.On("CreateEntry", mock.Anything, mock.MatchedBy(func(entry *audit_entity.Entry) bool {
+			return entry.Action == audit_entity.OrderStateChangedAction &&
+				entry.ResourceId == "order-id" &&
+				entry.Actor == actor &&
+				entry.Source == source &&
+				string(entry.Before) == `"before"` &&
+				string(entry.After) == `"after"` &&
+				entry.CreatedAt.Equal(now)
+		})).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		recorder := NewRecorder(repository, timeProvider)
+
+		// Act
+		err := recorder.Record(ctx, audit_entity.OrderStateChangedAction, audit_entity.OrderResource, "order-id", "before", "after")
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should record the unknown actor when the context has none", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockAuditRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		repository.On("CreateEntry", mock.Anything, mock.MatchedBy(func(entry *audit_entity.Entry) bool {
+			return entry.Actor.Type == audit_entity.UnknownActor
+		})).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		recorder := NewRecorder(repository, timeProvider)
+
+		// Act
+		err := recorder.Record(ctx, audit_entity.WebhookDeletedAction, audit_entity.WebhookResource, "webhook-id", nil, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the entry cannot be saved", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockAuditRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		repository.On("CreateEntry", mock.Anything, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		recorder := NewRecorder(repository, timeProvider)
+
+		// Act
+		err := recorder.Record(ctx, audit_entity.OrderCreatedAction, audit_entity.OrderResource, "order-id", nil, "after")
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		repository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,11 @@
+package cache
+
+import "context"
+
+// Cache keeps values by key for a limited time, a miss means the value must
+// be loaded from the source
+type Cache[V any] interface {
+	Get(ctx context.Context, key string) (V, bool)
+	Set(ctx context.Context, key string, value V)
+	Delete(ctx context.Context, keys ...string)
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider/time_provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Now()
+	timeProvider := time_provider.NewTimeProvider(func() time.Time {
+		return now
+	})
+
+	t.Run("Should return the value set", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cache := NewLRU[string](2, time.Minute, timeProvider)
+
+		cache.Set(ctx, "a", "value")
+
+		// Act
+		res, ok := cache.Get(ctx, "a")
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, "value", res)
+	})
+
+	t.Run("Should evict the least recently used value when full", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cache := NewLRU[string](2, time.Minute, timeProvider)
+
+		cache.Set(ctx, "a", "a")
+		cache.Set(ctx, "b", "b")
+		cache.Get(ctx, "a")
+
+		// Act
+		cache.Set(ctx, "c", "c")
+
+		// Assert
+		_, okA := cache.Get(ctx, "a")
+		_, okB := cache.Get(ctx, "b")
+		_, okC := cache.Get(ctx, "c")
+		assert.True(t, okA)
+		assert.False(t, okB)
+		assert.True(t, okC)
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("Should not return an expired value", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cache := NewLRU[string](2, time.Minute, timeProvider)
+
+		cache.Set(ctx, "a", "value")
+		now = now.Add(time.Minute)
+
+		// Act
+		_, ok := cache.Get(ctx, "a")
+
+		// Assert
+		assert.False(t, ok)
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("Should replace the value of an existing key", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cache := NewLRU[string](2, time.Minute, timeProvider)
+
+		cache.Set(ctx, "a", "old")
+
+		// Act
+		cache.Set(ctx, "a", "new")
+
+		// Assert
+		res, ok := cache.Get(ctx, "a")
+		assert.True(t, ok)
+		assert.Equal(t, "new", res)
+		assert.Equal(t, 1, cache.Len())
+	})
+
+	t.Run("Should delete the values", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cache := NewLRU[string](2, time.Minute, timeProvider)
+
+		cache.Set(ctx, "a", "a")
+		cache.Set(ctx, "b", "b")
+
+		// Act
+		cache.Delete(ctx, "a", "b", "c")
+
+		// Assert
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("Should not keep values when the size is zero", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cache := NewLRU[string](0, time.Minute, timeProvider)
+
+		// Act
+		cache.Set(ctx, "a", "a")
+
+		// Assert
+		_, ok := cache.Get(ctx, "a")
+		assert.False(t, ok)
+	})
+}
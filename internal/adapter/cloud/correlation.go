@@ -0,0 +1,29 @@
+package cloud
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+)
+
+// ExtractMessageCorrelation stores the id of the message as the request id and
+// continues the flow of the publisher, read from the correlation_id attribute
+// of the message or of the SNS notification. The id of the notification is the
+// correlation id of the publishers that do not inform one
+func ExtractMessageCorrelation(ctx context.Context, message types.Message) context.Context {
+	correlationId := QueueMessageAttributes(message.MessageAttributes).Get(correlation.CorrelationIdKey)
+
+	if correlationId == "" {
+		if notification, ok := decodeNotification(message); ok {
+			correlationId = notification.MessageAttributes.Get(correlation.CorrelationIdKey)
+
+			if correlationId == "" {
+				correlationId = notification.MessageId
+			}
+		}
+	}
+
+	return correlation.WithIds(ctx, aws.ToString(message.MessageId), correlationId)
+}
@@ -0,0 +1,83 @@
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMessageCorrelation(t *testing.T) {
+	t.Run("Should use the correlation id of the message attributes", func(t *testing.T) {
+		// Arrange
+		message := types.Message{
+			MessageId: aws.String("message-id"),
+			Body:      aws.String(`{"Type":"Notification","MessageId":"notification-id"}`),
+			MessageAttributes: map[string]types.MessageAttributeValue{
+				"correlation_id": {
+					DataType:    aws.String("String"),
+					StringValue: aws.String("correlation-id"),
+				},
+			},
+		}
+
+		// Act
+		ctx := ExtractMessageCorrelation(context.Background(), message)
+
+		// Assert
+		assert.Equal(t, "message-id", correlation.RequestIdFromContext(ctx))
+		assert.Equal(t, "correlation-id", correlation.CorrelationIdFromContext(ctx))
+	})
+
+	t.Run("Should use the correlation id of the notification attributes", func(t *testing.T) {
+		// Arrange
+		message := types.Message{
+			MessageId: aws.String("message-id"),
+			Body: aws.String(`{
+				"Type": "Notification",
+				"MessageId": "notification-id",
+				"MessageAttributes": {
+					"correlation_id": {"Type": "String", "Value": "correlation-id"}
+				}
+			}`),
+		}
+
+		// Act
+		ctx := ExtractMessageCorrelation(context.Background(), message)
+
+		// Assert
+		assert.Equal(t, "correlation-id", correlation.CorrelationIdFromContext(ctx))
+	})
+
+	t.Run("Should use the id of the notification when the publisher informs no correlation id", func(t *testing.T) {
+		// Arrange
+		message := types.Message{
+			MessageId: aws.String("message-id"),
+			Body:      aws.String(`{"Type":"Notification","MessageId":"notification-id"}`),
+		}
+
+		// Act
+		ctx := ExtractMessageCorrelation(context.Background(), message)
+
+		// Assert
+		assert.Equal(t, "notification-id", correlation.CorrelationIdFromContext(ctx))
+	})
+
+	t.Run("Should use the id of the message when the body is not a notification", func(t *testing.T) {
+		// Arrange
+		message := types.Message{
+			MessageId: aws.String("message-id"),
+			Body:      aws.String(`invalid`),
+		}
+
+		// Act
+		ctx := ExtractMessageCorrelation(context.Background(), message)
+
+		// Assert
+		assert.Equal(t, "message-id", correlation.RequestIdFromContext(ctx))
+		assert.Equal(t, "message-id", correlation.CorrelationIdFromContext(ctx))
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
 )
 
 type AwsDbUrlSecretService struct {
@@ -29,3 +30,13 @@ func (s *AwsDbUrlSecretService) GetSecret(ctx context.Context, secretName string
 
 	return *output.SecretString, nil
 }
+
+func (s *AwsDbUrlSecretService) HealthCheck(secretName string) health.HealthCheck {
+	return health.HealthCheckFunc(func(ctx context.Context) *health.HealthStatus {
+		_, err := s.Client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+			SecretId: aws.String(secretName),
+		})
+
+		return health.NewHealthStatus(err)
+	})
+}
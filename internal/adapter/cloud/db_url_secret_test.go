@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	"github.com/awsdocs/aws-doc-sdk-examples/gov2/testtools"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -90,3 +91,51 @@ func TestGetSecret(t *testing.T) {
 		testtools.ExitTest(stubber, t)
 	})
 }
+
+func TestSecretHealthCheck(t *testing.T) {
+	t.Run("Should return healthy when the secret is described", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DescribeSecret",
+			Input: &secretsmanager.DescribeSecretInput{
+				SecretId: aws.String("my-secret"),
+			},
+			Output: &secretsmanager.DescribeSecretOutput{},
+		})
+
+		service := NewSecretService(*stubber.SdkConfig)
+
+		// Act
+		status := service.HealthCheck("my-secret").Health(ctx)
+
+		// Assert
+		assert.Equal(t, health.HealthyStatus, status.Status)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return unhealthy when DescribeSecret operation fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		raiseErr := &testtools.StubError{Err: errors.New("ClientError")}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DescribeSecret",
+			Error:         raiseErr,
+		})
+
+		service := NewSecretService(*stubber.SdkConfig)
+
+		// Act
+		status := service.HealthCheck("my-secret").Health(ctx)
+
+		// Assert
+		assert.Equal(t, health.UnhealthyStatus, status.Status)
+		assert.True(t, status.HasError())
+		testtools.ExitTest(stubber, t)
+	})
+}
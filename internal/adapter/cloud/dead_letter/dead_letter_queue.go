@@ -0,0 +1,392 @@
+package dead_letter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/schema"
+)
+
+const (
+	failureReasonAttribute   = "failure_reason"
+	sourceMessageIdAttribute = "source_message_id"
+
+	// messages received while inspecting the queue stay hidden for this amount
+	// of seconds, so the same message is not returned twice by the same scan
+	deadLetterVisibilityTimeout = 30
+)
+
+type DeadLetterQueueService interface {
+	cloud.DeadLetterSender
+
+	GetQueueName() string
+	UpdateQueueUrl(ctx context.Context) error
+	ListMessages(ctx context.Context, maxMessages int) ([]DeadLetterMessage, error)
+	Redrive(ctx context.Context, messageIds []string) ([]string, error)
+	Replay(ctx context.Context, reader io.Reader, dryRun bool) ([]ReplayResult, error)
+}
+
+type DeadLetterMessage struct {
+	MessageId       string `json:"message_id"`
+	SourceMessageId string `json:"source_message_id,omitempty"`
+	FailureReason   string `json:"failure_reason,omitempty"`
+
+	Notification *cloud.TopicNotification           `json:"notification,omitempty"`
+	Payload      *create.CreateOrderProductionInput `json:"payload,omitempty"`
+	DecodeError  string                             `json:"decode_error,omitempty"`
+
+	Body string `json:"body"`
+}
+
+type ReplayResult struct {
+	Line    int    `json:"line"`
+	OrderId string `json:"order_id,omitempty"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type AwsSqsDeadLetterQueueService struct {
+	QueueName       string
+	QueueUrl        string
+	TargetQueueName string
+	TargetQueueUrl  string
+	Client          *sqs.Client
+
+	MessageProcessor        service.CreateOrderProductionService[create.CreateOrderProductionInput]
+	UpdateOrderTopicService cloud.TopicService
+	Recorder                audit.Recorder
+}
+
+func NewDeadLetterQueueService(
+	queueName string,
+	targetQueueName string,
+	config aws.Config,
+	messageProcessor service.CreateOrderProductionService[create.CreateOrderProductionInput],
+	updateOrderTopicService cloud.TopicService,
+	recorder audit.Recorder,
+) DeadLetterQueueService {
+	client := sqs.NewFromConfig(config)
+
+	return &AwsSqsDeadLetterQueueService{
+		QueueName:       queueName,
+		TargetQueueName: targetQueueName,
+		Client:          client,
+
+		MessageProcessor:        messageProcessor,
+		UpdateOrderTopicService: updateOrderTopicService,
+		Recorder:                recorder,
+	}
+}
+
+func (s *AwsSqsDeadLetterQueueService) GetQueueName() string {
+	return s.QueueName
+}
+
+func (s *AwsSqsDeadLetterQueueService) UpdateQueueUrl(ctx context.Context) error {
+	output, err := s.Client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+		QueueName: &s.QueueName,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.QueueUrl = *output.QueueUrl
+
+	output, err = s.Client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+		QueueName: &s.TargetQueueName,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.TargetQueueUrl = *output.QueueUrl
+
+	return nil
+}
+
+func (s *AwsSqsDeadLetterQueueService) SendMessage(ctx context.Context, sourceMessageId string, body string, reason string) error {
+	_, err := s.Client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    &s.QueueUrl,
+		MessageBody: aws.String(body),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			failureReasonAttribute: {
+				DataType:    aws.String("String"),
+				StringValue: aws.String(reason),
+			},
+			sourceMessageIdAttribute: {
+				DataType:    aws.String("String"),
+				StringValue: aws.String(sourceMessageId),
+			},
+		},
+	})
+
+	return err
+}
+
+func (s *AwsSqsDeadLetterQueueService) ListMessages(ctx context.Context, maxMessages int) ([]DeadLetterMessage, error) {
+	messages, err := s.receiveMessages(ctx, maxMessages, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters := make([]DeadLetterMessage, 0, len(messages))
+
+	for _, message := range messages {
+		deadLetters = append(deadLetters, newDeadLetterMessage(message))
+	}
+
+	return deadLetters, nil
+}
+
+// Redrive sends the selected messages back to the order production queue,
+// an empty selection redrives every message
+func (s *AwsSqsDeadLetterQueueService) Redrive(ctx context.Context, messageIds []string) ([]string, error) {
+	selected := make(map[string]bool, len(messageIds))
+	for _, messageId := range messageIds {
+		selected[messageId] = true
+	}
+
+	messages, err := s.receiveMessages(ctx, len(selected), selected, true)
+	if err != nil {
+		return nil, err
+	}
+
+	redriven := make([]string, 0)
+
+	for _, message := range messages {
+		_, err := s.Client.SendMessage(ctx, &sqs.SendMessageInput{
+			QueueUrl:    &s.TargetQueueUrl,
+			MessageBody: message.Body,
+		})
+		if err != nil {
+			return redriven, err
+		}
+
+		_, err = s.Client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+			QueueUrl:      &s.QueueUrl,
+			ReceiptHandle: message.ReceiptHandle,
+		})
+		if err != nil {
+			return redriven, err
+		}
+
+		slog.InfoContext(ctx, "message redriven from dead letter queue", "message_id", *message.MessageId)
+
+		// the message is already back in the queue, so it is redriven even
+		// when its audit entry cannot be saved
+		redriven = append(redriven, *message.MessageId)
+
+		if err := s.Recorder.Record(ctx, audit_entity.DeadLetterRedrivenAction, audit_entity.DeadLetterMessageResource, *message.MessageId, newDeadLetterMessage(message), nil); err != nil {
+			return redriven, err
+		}
+	}
+
+	return redriven, nil
+}
+
+// Replay processes each line of the reader as an order production message,
+// accepting both the SNS notification envelope and the bare payload
+func (s *AwsSqsDeadLetterQueueService) Replay(ctx context.Context, reader io.Reader, dryRun bool) ([]ReplayResult, error) {
+	results := make([]ReplayResult, 0)
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		result := ReplayResult{Line: line}
+
+		request, err := decodeReplayLine(text)
+		if err == nil {
+			result.OrderId = request.OrderId
+			request.DryRun = dryRun
+
+			err = cloud.ProcessOrderProduction(ctx, s.MessageProcessor, s.UpdateOrderTopicService, request)
+		}
+
+		if err != nil {
+			result.Error = err.Error()
+		}
+
+		result.Success = err == nil
+
+		results = append(results, result)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// receiveMessages receives the messages of the queue, only the selected ones
+// when a selection is given, until the maximum is reached (zero means no
+// limit). The messages received are hidden while held, the others are made
+// visible again right away so the concurrent scans and redrives still see
+// them. As a message can be received again, it stops when a receive returns
+// no message not seen yet
+func (s *AwsSqsDeadLetterQueueService) receiveMessages(ctx context.Context, maxMessages int, selected map[string]bool, hold bool) ([]types.Message, error) {
+	messages := make([]types.Message, 0)
+	held := make(map[string]int)
+	seen := make(map[string]bool)
+
+	for maxMessages <= 0 || len(messages) < maxMessages {
+		output, err := s.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              &s.QueueUrl,
+			MaxNumberOfMessages:   10,
+			VisibilityTimeout:     deadLetterVisibilityTimeout,
+			MessageAttributeNames: []string{"All"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		released := make([]types.Message, 0, len(output.Messages))
+		received := false
+
+		for _, message := range output.Messages {
+			messageId := *message.MessageId
+
+			// a held message visible again after the timeout is only deleted
+			// with the receipt handle of its last receive
+			if index, ok := held[messageId]; ok {
+				messages[index] = message
+				continue
+			}
+
+			wanted := !seen[messageId] &&
+				(len(selected) == 0 || selected[messageId]) &&
+				(maxMessages <= 0 || len(messages) < maxMessages)
+
+			received = received || !seen[messageId]
+			seen[messageId] = true
+
+			if wanted {
+				messages = append(messages, message)
+				if hold {
+					held[messageId] = len(messages) - 1
+					continue
+				}
+			}
+
+			released = append(released, message)
+		}
+
+		s.releaseMessages(ctx, released)
+
+		if !received {
+			break
+		}
+	}
+
+	return messages, nil
+}
+
+// releaseMessages makes the messages visible again, a failure is only logged
+// as the messages are visible again after the visibility timeout anyway
+func (s *AwsSqsDeadLetterQueueService) releaseMessages(ctx context.Context, messages []types.Message) {
+	if len(messages) == 0 {
+		return
+	}
+
+	entries := make([]types.ChangeMessageVisibilityBatchRequestEntry, 0, len(messages))
+
+	for _, message := range messages {
+		entries = append(entries, types.ChangeMessageVisibilityBatchRequestEntry{
+			Id:                message.MessageId,
+			ReceiptHandle:     message.ReceiptHandle,
+			VisibilityTimeout: 0,
+		})
+	}
+
+	output, err := s.Client.ChangeMessageVisibilityBatch(ctx, &sqs.ChangeMessageVisibilityBatchInput{
+		QueueUrl: &s.QueueUrl,
+		Entries:  entries,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "error releasing the dead letter messages", "error", err)
+		return
+	}
+
+	for _, failed := range output.Failed {
+		slog.WarnContext(ctx, "error releasing the dead letter message", "message_id", *failed.Id, "error", aws.ToString(failed.Message))
+	}
+}
+
+func newDeadLetterMessage(message types.Message) DeadLetterMessage {
+	deadLetter := DeadLetterMessage{
+		MessageId:       *message.MessageId,
+		SourceMessageId: getStringAttribute(message, sourceMessageIdAttribute),
+		FailureReason:   getStringAttribute(message, failureReasonAttribute),
+		Body:            *message.Body,
+	}
+
+	notification, request, err := cloud.DecodeOrderProductionMessage(*message.Body)
+	if notification.Type != "" {
+		deadLetter.Notification = &notification
+	}
+
+	if err != nil {
+		deadLetter.DecodeError = err.Error()
+		return deadLetter
+	}
+
+	deadLetter.Payload = &request
+
+	return deadLetter
+}
+
+func decodeReplayLine(text string) (create.CreateOrderProductionInput, error) {
+	var probe struct {
+		Type string `json:"Type"`
+	}
+
+	if err := json.Unmarshal([]byte(text), &probe); err != nil {
+		return create.CreateOrderProductionInput{}, err
+	}
+
+	if probe.Type != "" {
+		_, request, err := cloud.DecodeOrderProductionMessage(text)
+		return request, err
+	}
+
+	var request create.CreateOrderProductionInput
+
+	if err := schema.Validate(schema.OrderProductionSchema, []byte(text)); err != nil {
+		return request, err
+	}
+
+	err := json.Unmarshal([]byte(text), &request)
+
+	return request, err
+}
+
+func getStringAttribute(message types.Message, name string) string {
+	attribute, ok := message.MessageAttributes[name]
+	if !ok || attribute.StringValue == nil {
+		return ""
+	}
+
+	return *attribute.StringValue
+}
@@ -0,0 +1,639 @@
+package dead_letter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/awsdocs/aws-doc-sdk-examples/gov2/testtools"
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	service_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	queueUrl       = "https://sqs.us-east-1.amazonaws.com/123456789012/test-dlq"
+	targetQueueUrl = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	notificationBody = `{"Type":"Notification","MessageId":"fc8e9ffd-6122-5c52-8fb9-c13e3ee2629a","Message":"{\"order_id\":\"c3fdab1b-3c06-4db2-9edc-4760a2429462\",\"items\":[{\"id\":\"cfdab175-1f86-4fb0-9bcb-15f2c58df30c\",\"name\":\"Hamburger\",\"quantity\":1}]}"}`
+)
+
+var receiveInput = &sqs.ReceiveMessageInput{
+	QueueUrl:              aws.String(queueUrl),
+	MaxNumberOfMessages:   10,
+	VisibilityTimeout:     deadLetterVisibilityTimeout,
+	MessageAttributeNames: []string{"All"},
+}
+
+// releaseStub expects the messages to be made visible again, the receipt
+// handle of each message is handle-<id>
+func releaseStub(messageIds ...string) testtools.Stub {
+	entries := make([]types.ChangeMessageVisibilityBatchRequestEntry, 0, len(messageIds))
+
+	for _, messageId := range messageIds {
+		entries = append(entries, types.ChangeMessageVisibilityBatchRequestEntry{
+			Id:                aws.String(messageId),
+			ReceiptHandle:     aws.String("handle-" + messageId),
+			VisibilityTimeout: 0,
+		})
+	}
+
+	return testtools.Stub{
+		OperationName: "ChangeMessageVisibilityBatch",
+		Input: &sqs.ChangeMessageVisibilityBatchInput{
+			QueueUrl: aws.String(queueUrl),
+			Entries:  entries,
+		},
+		Output: &sqs.ChangeMessageVisibilityBatchOutput{},
+	}
+}
+
+func newService(t *testing.T, config aws.Config) (
+	*AwsSqsDeadLetterQueueService,
+	*service_mocks.MockCreateOrderProductionService[create.CreateOrderProductionInput],
+	*mocks.MockTopicService,
+) {
+	processor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+	updateOrderTopic := mocks.NewMockTopicService(t)
+
+	recorder := audit_mocks.NewMockRecorder(t)
+
+	service := NewDeadLetterQueueService("test-dlq", "test-queue", config, processor, updateOrderTopic, recorder).(*AwsSqsDeadLetterQueueService)
+	service.QueueUrl = queueUrl
+	service.TargetQueueUrl = targetQueueUrl
+
+	return service, processor, updateOrderTopic
+}
+
+func TestUpdateQueueUrl(t *testing.T) {
+	t.Run("Should resolve both queue urls", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Input:         &sqs.GetQueueUrlInput{QueueName: aws.String("test-dlq")},
+			Output:        &sqs.GetQueueUrlOutput{QueueUrl: aws.String(queueUrl)},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Input:         &sqs.GetQueueUrlInput{QueueName: aws.String("test-queue")},
+			Output:        &sqs.GetQueueUrlOutput{QueueUrl: aws.String(targetQueueUrl)},
+		})
+
+		processor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		service := NewDeadLetterQueueService("test-dlq", "test-queue", *stubber.SdkConfig, processor, updateOrderTopic, recorder)
+
+		// Act
+		err := service.UpdateQueueUrl(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "test-dlq", service.GetQueueName())
+		assert.Equal(t, targetQueueUrl, service.(*AwsSqsDeadLetterQueueService).TargetQueueUrl)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error when GetQueueUrl operation fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		raiseErr := &testtools.StubError{Err: errors.New("ClientError")}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Error:         raiseErr,
+		})
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		// Act
+		err := service.UpdateQueueUrl(ctx)
+
+		// Assert
+		testtools.VerifyError(err, raiseErr, t)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestSendMessage(t *testing.T) {
+	t.Run("Should send the message with the failure reason", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "SendMessage",
+			Input: &sqs.SendMessageInput{
+				QueueUrl:    aws.String(queueUrl),
+				MessageBody: aws.String(notificationBody),
+				MessageAttributes: map[string]types.MessageAttributeValue{
+					"failure_reason": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String("order already exists"),
+					},
+					"source_message_id": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String("123"),
+					},
+				},
+			},
+			Output: &sqs.SendMessageOutput{},
+		})
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		// Act
+		err := service.SendMessage(ctx, "123", notificationBody, "order already exists")
+
+		// Assert
+		assert.NoError(t, err)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestListMessages(t *testing.T) {
+	t.Run("Should list and decode the messages", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{
+						MessageId:     aws.String("1"),
+						Body:          aws.String(notificationBody),
+						ReceiptHandle: aws.String("handle-1"),
+						MessageAttributes: map[string]types.MessageAttributeValue{
+							"failure_reason": {
+								DataType:    aws.String("String"),
+								StringValue: aws.String("order already exists"),
+							},
+						},
+					},
+					{
+						MessageId:     aws.String("2"),
+						Body:          aws.String("not-a-json"),
+						ReceiptHandle: aws.String("handle-2"),
+					},
+				},
+			},
+		})
+
+		stubber.Add(releaseStub("1", "2"))
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output:        &sqs.ReceiveMessageOutput{},
+		})
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		// Act
+		messages, err := service.ListMessages(ctx, 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, messages, 2)
+
+		assert.Equal(t, "order already exists", messages[0].FailureReason)
+		assert.NotNil(t, messages[0].Notification)
+		assert.Equal(t, "c3fdab1b-3c06-4db2-9edc-4760a2429462", messages[0].Payload.OrderId)
+
+		assert.Nil(t, messages[1].Payload)
+		assert.NotEmpty(t, messages[1].DecodeError)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should stop when the maximum is reached", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{MessageId: aws.String("1"), Body: aws.String(notificationBody), ReceiptHandle: aws.String("handle-1")},
+					{MessageId: aws.String("2"), Body: aws.String(notificationBody), ReceiptHandle: aws.String("handle-2")},
+				},
+			},
+		})
+
+		// the messages not listed are released as well
+		stubber.Add(releaseStub("1", "2"))
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		// Act
+		messages, err := service.ListMessages(ctx, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error when ReceiveMessage operation fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		raiseErr := &testtools.StubError{Err: errors.New("ClientError")}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Error:         raiseErr,
+		})
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		// Act
+		messages, err := service.ListMessages(ctx, 10)
+
+		// Assert
+		assert.Nil(t, messages)
+		testtools.VerifyError(err, raiseErr, t)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestRedrive(t *testing.T) {
+	t.Run("Should redrive only the selected messages", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{MessageId: aws.String("1"), Body: aws.String("body-1"), ReceiptHandle: aws.String("handle-1")},
+					{MessageId: aws.String("2"), Body: aws.String("body-2"), ReceiptHandle: aws.String("handle-2")},
+				},
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ChangeMessageVisibilityBatch",
+			Input: &sqs.ChangeMessageVisibilityBatchInput{
+				QueueUrl: aws.String(queueUrl),
+				Entries: []types.ChangeMessageVisibilityBatchRequestEntry{
+					{Id: aws.String("1"), ReceiptHandle: aws.String("handle-1"), VisibilityTimeout: 0},
+				},
+			},
+			Output: &sqs.ChangeMessageVisibilityBatchOutput{},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "SendMessage",
+			Input: &sqs.SendMessageInput{
+				QueueUrl:    aws.String(targetQueueUrl),
+				MessageBody: aws.String("body-2"),
+			},
+			Output: &sqs.SendMessageOutput{},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DeleteMessage",
+			Input: &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(queueUrl),
+				ReceiptHandle: aws.String("handle-2"),
+			},
+			Output: &sqs.DeleteMessageOutput{},
+		})
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		recorder := audit_mocks.NewMockRecorder(t)
+		recorder.On("Record", ctx, audit_entity.DeadLetterRedrivenAction, audit_entity.DeadLetterMessageResource, "2", mock.MatchedBy(func(before DeadLetterMessage) bool {
+			return before.MessageId == "2"
+		}), nil).
+			Return(nil).
+			Once()
+
+		service.Recorder = recorder
+
+		// Act
+		redriven, err := service.Redrive(ctx, []string{"2"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2"}, redriven)
+		recorder.AssertExpectations(t)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should stop when the selected messages are not in the queue", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		release := testtools.Stub{
+			OperationName: "ChangeMessageVisibilityBatch",
+			Input: &sqs.ChangeMessageVisibilityBatchInput{
+				QueueUrl: aws.String(queueUrl),
+				Entries: []types.ChangeMessageVisibilityBatchRequestEntry{
+					{Id: aws.String("1"), ReceiptHandle: aws.String("handle-1"), VisibilityTimeout: 0},
+				},
+			},
+			Output: &sqs.ChangeMessageVisibilityBatchOutput{},
+		}
+
+		// the released message is received again, with no message not seen yet
+		for i := 0; i < 2; i++ {
+			stubber.Add(testtools.Stub{
+				OperationName: "ReceiveMessage",
+				Input:         receiveInput,
+				Output: &sqs.ReceiveMessageOutput{
+					Messages: []types.Message{
+						{MessageId: aws.String("1"), Body: aws.String("body-1"), ReceiptHandle: aws.String("handle-1")},
+					},
+				},
+			})
+			stubber.Add(release)
+		}
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		// Act
+		redriven, err := service.Redrive(ctx, []string{"2"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, redriven)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should redrive every message when nothing is selected", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{MessageId: aws.String("1"), Body: aws.String("body-1"), ReceiptHandle: aws.String("handle-1")},
+				},
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output:        &sqs.ReceiveMessageOutput{},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "SendMessage",
+			Input: &sqs.SendMessageInput{
+				QueueUrl:    aws.String(targetQueueUrl),
+				MessageBody: aws.String("body-1"),
+			},
+			Output: &sqs.SendMessageOutput{},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DeleteMessage",
+			Input: &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(queueUrl),
+				ReceiptHandle: aws.String("handle-1"),
+			},
+			Output: &sqs.DeleteMessageOutput{},
+		})
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		recorder := audit_mocks.NewMockRecorder(t)
+		recorder.On("Record", ctx, audit_entity.DeadLetterRedrivenAction, audit_entity.DeadLetterMessageResource, "1", mock.MatchedBy(func(before DeadLetterMessage) bool {
+			return before.MessageId == "1"
+		}), nil).
+			Return(nil).
+			Once()
+
+		service.Recorder = recorder
+
+		// Act
+		redriven, err := service.Redrive(ctx, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, redriven)
+		recorder.AssertExpectations(t)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should stop when only the held messages are received again", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{MessageId: aws.String("1"), Body: aws.String("body-1"), ReceiptHandle: aws.String("handle-1")},
+				},
+			},
+		})
+
+		// the message is visible again after the visibility timeout
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{MessageId: aws.String("1"), Body: aws.String("body-1"), ReceiptHandle: aws.String("handle-1-again")},
+				},
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "SendMessage",
+			Input: &sqs.SendMessageInput{
+				QueueUrl:    aws.String(targetQueueUrl),
+				MessageBody: aws.String("body-1"),
+			},
+			Output: &sqs.SendMessageOutput{},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DeleteMessage",
+			Input: &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(queueUrl),
+				ReceiptHandle: aws.String("handle-1-again"),
+			},
+			Output: &sqs.DeleteMessageOutput{},
+		})
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		recorder := audit_mocks.NewMockRecorder(t)
+		recorder.On("Record", ctx, audit_entity.DeadLetterRedrivenAction, audit_entity.DeadLetterMessageResource, "1", mock.Anything, nil).
+			Return(nil).
+			Once()
+
+		service.Recorder = recorder
+
+		// Act
+		redriven, err := service.Redrive(ctx, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, redriven)
+		recorder.AssertExpectations(t)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error when SendMessage operation fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		raiseErr := &testtools.StubError{Err: errors.New("ClientError")}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{MessageId: aws.String("1"), Body: aws.String("body-1"), ReceiptHandle: aws.String("handle-1")},
+				},
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input:         receiveInput,
+			Output:        &sqs.ReceiveMessageOutput{},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "SendMessage",
+			Input: &sqs.SendMessageInput{
+				QueueUrl:    aws.String(targetQueueUrl),
+				MessageBody: aws.String("body-1"),
+			},
+			Error: raiseErr,
+		})
+
+		service, _, _ := newService(t, *stubber.SdkConfig)
+
+		// Act
+		redriven, err := service.Redrive(ctx, nil)
+
+		// Assert
+		assert.Empty(t, redriven)
+		testtools.VerifyError(err, raiseErr, t)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestReplay(t *testing.T) {
+	t.Run("Should replay notifications and bare payloads", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, processor, updateOrderTopic := newService(t, aws.Config{})
+
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+
+		processor.On("Handle", ctx, mock.MatchedBy(func(request create.CreateOrderProductionInput) bool {
+			return !request.DryRun
+		})).
+			Return(&order, nil).
+			Twice()
+
+		messageId := "message-id"
+
+		updateOrderTopic.On("PublishMessage", ctx, mock.Anything).
+			Return(&messageId, nil).
+			Twice()
+
+		lines := strings.Join([]string{
+			notificationBody,
+			"",
+			`{"order_id":"c3fdab1b-3c06-4db2-9edc-4760a2429462","items":[]}`,
+			"not-a-json",
+		}, "\n")
+
+		// Act
+		results, err := service.Replay(ctx, strings.NewReader(lines), false)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+
+		assert.True(t, results[0].Success)
+		assert.Equal(t, 1, results[0].Line)
+		assert.Equal(t, "c3fdab1b-3c06-4db2-9edc-4760a2429462", results[0].OrderId)
+
+		assert.True(t, results[1].Success)
+		assert.Equal(t, 3, results[1].Line)
+
+		assert.False(t, results[2].Success)
+		assert.NotEmpty(t, results[2].Error)
+
+		processor.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+
+	t.Run("Should not publish when running in dry run mode", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		service, processor, updateOrderTopic := newService(t, aws.Config{})
+
+		processor.On("Handle", ctx, mock.MatchedBy(func(request create.CreateOrderProductionInput) bool {
+			return request.DryRun
+		})).
+			Return(&order_entity.Order{}, nil).
+			Once()
+
+		processor.On("Handle", ctx, mock.Anything).
+			Return(nil, custom_error.ErrRequestNotValid).
+			Once()
+
+		lines := strings.Join([]string{notificationBody, notificationBody}, "\n")
+
+		// Act
+		results, err := service.Replay(ctx, strings.NewReader(lines), true)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.True(t, results[0].Success)
+		assert.False(t, results[1].Success)
+		assert.Equal(t, custom_error.ErrRequestNotValid.Error(), results[1].Error)
+
+		processor.AssertExpectations(t)
+		updateOrderTopic.AssertNotCalled(t, "PublishMessage", mock.Anything, mock.Anything)
+	})
+}
@@ -4,7 +4,9 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
+	health "github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -18,6 +20,34 @@ func (_m *MockQueueService) ConsumeMessages(ctx context.Context) {
 	_m.Called(ctx)
 }
 
+// GetQueueDepth provides a mock function with given fields: ctx
+func (_m *MockQueueService) GetQueueDepth(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueueDepth")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetQueueName provides a mock function with given fields:
 func (_m *MockQueueService) GetQueueName() string {
 	ret := _m.Called()
@@ -36,6 +66,44 @@ func (_m *MockQueueService) GetQueueName() string {
 	return r0
 }
 
+// Health provides a mock function with given fields: ctx
+func (_m *MockQueueService) Health(ctx context.Context) *health.HealthStatus {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Health")
+	}
+
+	var r0 *health.HealthStatus
+	if rf, ok := ret.Get(0).(func(context.Context) *health.HealthStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*health.HealthStatus)
+		}
+	}
+
+	return r0
+}
+
+// LastPollAt provides a mock function with given fields:
+func (_m *MockQueueService) LastPollAt() time.Time {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastPollAt")
+	}
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
 // UpdateQueueUrl provides a mock function with given fields: ctx
 func (_m *MockQueueService) UpdateQueueUrl(ctx context.Context) error {
 	ret := _m.Called(ctx)
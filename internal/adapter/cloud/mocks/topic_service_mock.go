@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	health "github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -31,6 +32,56 @@ func (_m *MockTopicService) GetTopicName() string {
 	return r0
 }
 
+// Health provides a mock function with given fields: ctx
+func (_m *MockTopicService) Health(ctx context.Context) *health.HealthStatus {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Health")
+	}
+
+	var r0 *health.HealthStatus
+	if rf, ok := ret.Get(0).(func(context.Context) *health.HealthStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*health.HealthStatus)
+		}
+	}
+
+	return r0
+}
+
+// PublishBatch provides a mock function with given fields: ctx, messages
+func (_m *MockTopicService) PublishBatch(ctx context.Context, messages []interface{}) ([]*string, error) {
+	ret := _m.Called(ctx, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishBatch")
+	}
+
+	var r0 []*string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []interface{}) ([]*string, error)); ok {
+		return rf(ctx, messages)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []interface{}) []*string); ok {
+		r0 = rf(ctx, messages)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []interface{}) error); ok {
+		r1 = rf(ctx, messages)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PublishMessage provides a mock function with given fields: ctx, message
 func (_m *MockTopicService) PublishMessage(ctx context.Context, message interface{}) (*string, error) {
 	ret := _m.Called(ctx, message)
@@ -3,20 +3,48 @@ package cloud
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/metrics"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/tracing"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/schema"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type QueueService interface {
 	GetQueueName() string
+	GetQueueDepth(ctx context.Context) (int, error)
 	UpdateQueueUrl(ctx context.Context) error
 	ConsumeMessages(ctx context.Context)
+	LastPollAt() time.Time
+	health.HealthCheck
+}
+
+// QueueActorId identifies the queue consumer as the author of the changes
+const QueueActorId = "order-production-queue"
+
+// DeadLetterSender receives the messages that could not be processed, along
+// with the reason of the failure
+type DeadLetterSender interface {
+	SendMessage(ctx context.Context, sourceMessageId string, body string, reason string) error
 }
 
 type AwsSqsService struct {
@@ -26,11 +54,16 @@ type AwsSqsService struct {
 
 	MessageProcessor        service.CreateOrderProductionService[create.CreateOrderProductionInput]
 	UpdateOrderTopicService TopicService
+	DeadLetterSender        DeadLetterSender
 
 	ChanMessage chan types.Message
 
 	Mutex     sync.Mutex
 	WaitGroup sync.WaitGroup
+
+	// lastPollAt is the unix time in nanoseconds of the last poll of the
+	// queue, zero before the first one
+	lastPollAt atomic.Int64
 }
 
 func NewQueueService(
@@ -38,6 +71,7 @@ func NewQueueService(
 	config aws.Config,
 	messageProcessor service.CreateOrderProductionService[create.CreateOrderProductionInput],
 	updateOrderTopicService TopicService,
+	deadLetterSender DeadLetterSender,
 ) QueueService {
 	client := sqs.NewFromConfig(config)
 
@@ -47,6 +81,7 @@ func NewQueueService(
 
 		MessageProcessor:        messageProcessor,
 		UpdateOrderTopicService: updateOrderTopicService,
+		DeadLetterSender:        deadLetterSender,
 
 		ChanMessage: make(chan types.Message, 10),
 
@@ -59,6 +94,37 @@ func (s *AwsSqsService) GetQueueName() string {
 	return s.QueueName
 }
 
+// GetQueueDepth returns the approximate number of messages visible in the
+// queue, waiting to be consumed
+func (s *AwsSqsService) GetQueueDepth(ctx context.Context) (int, error) {
+	output, err := s.Client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       &s.QueueUrl,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(output.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)])
+}
+
+// Health checks the queue can be reached by reading its attributes
+func (s *AwsSqsService) Health(ctx context.Context) *health.HealthStatus {
+	_, err := s.GetQueueDepth(ctx)
+	return health.NewHealthStatus(err)
+}
+
+// LastPollAt returns when the consumer last received from the queue, the zero
+// time when it did not poll yet
+func (s *AwsSqsService) LastPollAt() time.Time {
+	nanos := s.lastPollAt.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, nanos)
+}
+
 func (s *AwsSqsService) UpdateQueueUrl(ctx context.Context) error {
 	output, err := s.Client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
 		QueueName: &s.QueueName,
@@ -74,15 +140,20 @@ func (s *AwsSqsService) UpdateQueueUrl(ctx context.Context) error {
 
 func (s *AwsSqsService) ConsumeMessages(ctx context.Context) {
 	output, err := s.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
-		QueueUrl:            &s.QueueUrl,
-		MaxNumberOfMessages: 10,
-		WaitTimeSeconds:     20,
+		QueueUrl:              &s.QueueUrl,
+		MaxNumberOfMessages:   10,
+		WaitTimeSeconds:       20,
+		MessageAttributeNames: []string{"All"},
 	})
 	if err != nil {
 		slog.ErrorContext(ctx, "error receiving message from queue", "queue_url", s.QueueUrl, "error", err)
 		return
 	}
 
+	s.lastPollAt.Store(time.Now().UnixNano())
+
+	metrics.QueueMessagesReceived(len(output.Messages))
+
 	s.WaitGroup.Add(len(output.Messages))
 
 	for _, message := range output.Messages {
@@ -95,43 +166,37 @@ func (s *AwsSqsService) ConsumeMessages(ctx context.Context) {
 func (s *AwsSqsService) processMessage(ctx context.Context, message types.Message) {
 	defer s.WaitGroup.Done()
 	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
 
-	slog.InfoContext(ctx, "message received", "message_id", *message.MessageId)
+	ctx = ExtractMessageCorrelation(ctx, message)
 
-	var notification TopicNotification
+	ctx, span := tracing.Tracer().Start(ExtractMessageContext(ctx, message), fmt.Sprintf("%s process", s.QueueName),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemAWSSqs,
+			semconv.MessagingOperationReceive,
+			semconv.MessagingDestinationName(s.QueueName),
+			semconv.MessagingMessageID(*message.MessageId),
+		),
+	)
+	defer span.End()
 
-	err := json.Unmarshal([]byte(*message.Body), &notification)
-	if err != nil {
-		slog.ErrorContext(ctx, "error unmarshalling message", "message_id", *message.MessageId, "error", err)
-	}
+	slog.InfoContext(ctx, "message received", "message_id", *message.MessageId)
 
-	if notification.Type != "Notification" {
-		slog.ErrorContext(ctx, "invalid notification type", "message_id", *message.MessageId, "type", notification.Type)
-		return
-	}
+	start := time.Now()
+	err := s.handleMessage(ctx, message)
+	metrics.QueueMessageProcessed(time.Since(start), err)
 
-	var request create.CreateOrderProductionInput
-
-	err = json.Unmarshal([]byte(notification.Message), &request)
 	if err != nil {
-		slog.ErrorContext(ctx, "error unmarshalling message", "message_id", *message.MessageId, "error", err)
-	}
-
-	if err == nil {
-		slog.InfoContext(ctx, "message unmarshalled", "request", request)
-		order, err := s.MessageProcessor.Handle(ctx, request)
-		if err != nil {
-			slog.ErrorContext(ctx, "error processing message", "message_id", *message.MessageId, "error", err)
-		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error processing message")
 
-		if order != nil {
-			messageId, err := s.UpdateOrderTopicService.PublishMessage(ctx, NewUpdateOrderContractFromPayment(order))
-			if err != nil {
-				slog.ErrorContext(ctx, "error publishing message to update order topic", "error", err)
-			}
+		slog.ErrorContext(ctx, "error processing message", "message_id", *message.MessageId, "error", err)
 
-			if messageId != nil {
-				slog.InfoContext(ctx, "message published to update order topic", "message_id", *messageId)
+		if s.DeadLetterSender != nil {
+			if err := s.DeadLetterSender.SendMessage(ctx, *message.MessageId, *message.Body, err.Error()); err != nil {
+				slog.ErrorContext(ctx, "error sending message to dead letter queue", "message_id", *message.MessageId, "error", err)
+				return
 			}
 		}
 	}
@@ -139,8 +204,30 @@ func (s *AwsSqsService) processMessage(ctx context.Context, message types.Messag
 	if err := s.deleteMessage(ctx, message); err != nil {
 		slog.ErrorContext(ctx, "error deleting message", "message_id", *message.MessageId, "error", err)
 	}
+}
+
+func (s *AwsSqsService) handleMessage(ctx context.Context, message types.Message) error {
+	_, request, err := DecodeOrderProductionMessage(*message.Body)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "message unmarshalled", "request", request)
 
-	s.Mutex.Unlock()
+	// the orders of the queue are created by the service itself
+	ctx = authorization.WithRoles(ctx, authorization.ServiceRole)
+	ctx = audit.WithActor(ctx, audit_entity.Actor{Type: audit_entity.ServiceActor, Id: QueueActorId})
+	ctx = audit.WithSource(ctx, audit_entity.Source{Type: audit_entity.QueueSource, MessageId: *message.MessageId})
+
+	err = ProcessOrderProduction(ctx, s.MessageProcessor, s.UpdateOrderTopicService, request)
+	if errors.Is(err, custom_error.ErrOrderAlreadyExists) {
+		// the queue delivers the messages at least once, a redelivered order
+		// was already created so the message is deleted without dead lettering
+		slog.InfoContext(ctx, "order already exists, message ignored", "message_id", *message.MessageId, "order_id", request.OrderId)
+		return nil
+	}
+
+	return err
 }
 
 func (s *AwsSqsService) deleteMessage(ctx context.Context, message types.Message) error {
@@ -154,3 +241,57 @@ func (s *AwsSqsService) deleteMessage(ctx context.Context, message types.Message
 
 	return nil
 }
+
+// DecodeOrderProductionMessage unwraps the SNS notification delivered to the
+// queue and decodes the order production request it carries
+func DecodeOrderProductionMessage(body string) (TopicNotification, create.CreateOrderProductionInput, error) {
+	var notification TopicNotification
+	var request create.CreateOrderProductionInput
+
+	if err := json.Unmarshal([]byte(body), &notification); err != nil {
+		return notification, request, fmt.Errorf("error unmarshalling notification: %w", err)
+	}
+
+	if notification.Type != "Notification" {
+		return notification, request, fmt.Errorf("invalid notification type: %s", notification.Type)
+	}
+
+	if err := schema.Validate(schema.OrderProductionSchema, []byte(notification.Message)); err != nil {
+		return notification, request, err
+	}
+
+	if err := json.Unmarshal([]byte(notification.Message), &request); err != nil {
+		return notification, request, fmt.Errorf("error unmarshalling message: %w", err)
+	}
+
+	return notification, request, nil
+}
+
+// ProcessOrderProduction creates the order and publishes it to the update
+// order topic, publishing failures are only logged as the order is already created
+func ProcessOrderProduction(
+	ctx context.Context,
+	processor service.CreateOrderProductionService[create.CreateOrderProductionInput],
+	updateOrderTopicService TopicService,
+	request create.CreateOrderProductionInput,
+) error {
+	order, err := processor.Handle(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if order == nil || request.DryRun {
+		return nil
+	}
+
+	messageId, err := updateOrderTopicService.PublishMessage(ctx, NewOrderEvent(order, NewServiceActor(QueueActorId)))
+	if err != nil {
+		slog.ErrorContext(ctx, "error publishing message to update order topic", "error", err)
+	}
+
+	if messageId != nil {
+		slog.InfoContext(ctx, "message published to update order topic", "message_id", *messageId)
+	}
+
+	return nil
+}
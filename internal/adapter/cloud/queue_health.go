@@ -0,0 +1,43 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
+)
+
+// ConsumerHealthCheck fails when the consumer did not poll the queue for
+// longer than the stall timeout, e.g. a message blocked its processing, so the
+// process is restarted
+type ConsumerHealthCheck struct {
+	queue        QueueService
+	timeProvider provider.TimeProvider
+	stallTimeout time.Duration
+}
+
+func NewConsumerHealthCheck(queue QueueService, timeProvider provider.TimeProvider, stallTimeout time.Duration) health.HealthCheck {
+	return &ConsumerHealthCheck{
+		queue:        queue,
+		timeProvider: timeProvider,
+		stallTimeout: stallTimeout,
+	}
+}
+
+func (c *ConsumerHealthCheck) Health(ctx context.Context) *health.HealthStatus {
+	lastPollAt := c.queue.LastPollAt()
+
+	// the consumer starts after the probes, it did not stall before its
+	// first poll
+	if lastPollAt.IsZero() {
+		return health.NewHealthStatus(nil)
+	}
+
+	if elapsed := c.timeProvider.GetTime().Sub(lastPollAt); elapsed > c.stallTimeout {
+		return health.NewHealthStatus(fmt.Errorf("consumer did not poll the queue %s for %s", c.queue.GetQueueName(), elapsed.Truncate(time.Second)))
+	}
+
+	return health.NewHealthStatus(nil)
+}
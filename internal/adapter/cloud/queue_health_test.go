@@ -0,0 +1,80 @@
+package cloud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumerHealthCheck(t *testing.T) {
+	now := time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should return healthy when the consumer did not poll yet", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		queue := mocks.NewMockQueueService(t)
+		queue.On("LastPollAt").Return(time.Time{}).Once()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		check := NewConsumerHealthCheck(queue, timeProvider, 5*time.Minute)
+
+		// Act
+		status := check.Health(ctx)
+
+		// Assert
+		assert.Equal(t, health.HealthyStatus, status.Status)
+		queue.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return healthy when the consumer polled recently", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		queue := mocks.NewMockQueueService(t)
+		queue.On("LastPollAt").Return(now.Add(-time.Minute)).Once()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now).Once()
+
+		check := NewConsumerHealthCheck(queue, timeProvider, 5*time.Minute)
+
+		// Act
+		status := check.Health(ctx)
+
+		// Assert
+		assert.Equal(t, health.HealthyStatus, status.Status)
+		queue.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return unhealthy when the consumer stalled", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		queue := mocks.NewMockQueueService(t)
+		queue.On("LastPollAt").Return(now.Add(-6 * time.Minute)).Once()
+		queue.On("GetQueueName").Return("test-queue").Once()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now).Once()
+
+		check := NewConsumerHealthCheck(queue, timeProvider, 5*time.Minute)
+
+		// Act
+		status := check.Health(ctx)
+
+		// Assert
+		assert.Equal(t, health.UnhealthyStatus, status.Status)
+		assert.Equal(t, "consumer did not poll the queue test-queue for 6m0s", status.Err)
+		queue.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+}
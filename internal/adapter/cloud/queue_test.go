@@ -12,17 +12,26 @@ import (
 	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
 	service_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// asServiceRole matches the context of the changes made by the queue consumer
+var asServiceRole = mock.MatchedBy(func(ctx context.Context) bool {
+	return authorization.HasRole(ctx, authorization.ServiceRole)
+})
+
 func TestGetQueueName(t *testing.T) {
 	t.Run("Should return queue name", func(t *testing.T) {
 		// Arrange
 		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
 		updateOrderTopic := mocks.NewMockTopicService(t)
 
-		service := NewQueueService("test-queue", aws.Config{}, fakeProcessor, updateOrderTopic)
+		service := NewQueueService("test-queue", aws.Config{}, fakeProcessor, updateOrderTopic, nil)
 
 		// Act
 		queueName := service.GetQueueName()
@@ -32,6 +41,124 @@ func TestGetQueueName(t *testing.T) {
 	})
 }
 
+func TestGetQueueDepth(t *testing.T) {
+	t.Run("Should return the approximate number of messages", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueAttributes",
+			Input: &sqs.GetQueueAttributesInput{
+				QueueUrl:       aws.String(""),
+				AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+			},
+			Output: &sqs.GetQueueAttributesOutput{
+				Attributes: map[string]string{
+					string(types.QueueAttributeNameApproximateNumberOfMessages): "7",
+				},
+			},
+		})
+
+		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
+
+		// Act
+		depth, err := service.GetQueueDepth(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, depth)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error when GetQueueAttributes operation fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		raiseErr := &testtools.StubError{Err: errors.New("ClientError")}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueAttributes",
+			Error:         raiseErr,
+		})
+
+		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
+
+		// Act
+		_, err := service.GetQueueDepth(ctx)
+
+		// Assert
+		testtools.VerifyError(err, raiseErr, t)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestQueueHealth(t *testing.T) {
+	t.Run("Should return healthy when the queue attributes are read", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueAttributes",
+			Input: &sqs.GetQueueAttributesInput{
+				QueueUrl:       aws.String(""),
+				AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+			},
+			Output: &sqs.GetQueueAttributesOutput{
+				Attributes: map[string]string{
+					string(types.QueueAttributeNameApproximateNumberOfMessages): "0",
+				},
+			},
+		})
+
+		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
+
+		// Act
+		status := service.Health(ctx)
+
+		// Assert
+		assert.Equal(t, health.HealthyStatus, status.Status)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return unhealthy when GetQueueAttributes operation fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		raiseErr := &testtools.StubError{Err: errors.New("ClientError")}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueAttributes",
+			Error:         raiseErr,
+		})
+
+		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
+
+		// Act
+		status := service.Health(ctx)
+
+		// Assert
+		assert.Equal(t, health.UnhealthyStatus, status.Status)
+		assert.True(t, status.HasError())
+		testtools.ExitTest(stubber, t)
+	})
+}
+
 func TestUpdateQueueUrl(t *testing.T) {
 	t.Run("Should return nil when queue is found", func(t *testing.T) {
 		// Arrange
@@ -51,7 +178,7 @@ func TestUpdateQueueUrl(t *testing.T) {
 		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
 		updateOrderTopic := mocks.NewMockTopicService(t)
 
-		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic)
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
 
 		// Act
 		err := service.UpdateQueueUrl(ctx)
@@ -76,7 +203,7 @@ func TestUpdateQueueUrl(t *testing.T) {
 		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
 		updateOrderTopic := mocks.NewMockTopicService(t)
 
-		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic)
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
 
 		// Act
 		err := service.UpdateQueueUrl(ctx)
@@ -118,9 +245,10 @@ func TestStartConsuming(t *testing.T) {
 		stubber.Add(testtools.Stub{
 			OperationName: "ReceiveMessage",
 			Input: &sqs.ReceiveMessageInput{
-				QueueUrl:            aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
-				MaxNumberOfMessages: 10,
-				WaitTimeSeconds:     20,
+				QueueUrl:              aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				MaxNumberOfMessages:   10,
+				WaitTimeSeconds:       20,
+				MessageAttributeNames: []string{"All"},
 			},
 			Output: &sqs.ReceiveMessageOutput{
 				Messages: []types.Message{
@@ -159,11 +287,11 @@ func TestStartConsuming(t *testing.T) {
 		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
 		updateOrderTopic := mocks.NewMockTopicService(t)
 
-		fakeProcessor.On("Handle", ctx, mock.Anything).
+		fakeProcessor.On("Handle", asServiceRole, mock.Anything).
 			Return(nil, nil).
 			Times(2)
 
-		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic)
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
 
 		err := service.UpdateQueueUrl(ctx)
 		assert.NoError(t, err)
@@ -172,6 +300,7 @@ func TestStartConsuming(t *testing.T) {
 		service.ConsumeMessages(ctx)
 
 		// Assert
+		assert.False(t, service.LastPollAt().IsZero())
 		testtools.ExitTest(stubber, t)
 		fakeProcessor.AssertExpectations(t)
 	})
@@ -196,9 +325,10 @@ func TestStartConsuming(t *testing.T) {
 		stubber.Add(testtools.Stub{
 			OperationName: "ReceiveMessage",
 			Input: &sqs.ReceiveMessageInput{
-				QueueUrl:            aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
-				MaxNumberOfMessages: 10,
-				WaitTimeSeconds:     20,
+				QueueUrl:              aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				MaxNumberOfMessages:   10,
+				WaitTimeSeconds:       20,
+				MessageAttributeNames: []string{"All"},
 			},
 			Error: raiseErr,
 		})
@@ -206,7 +336,7 @@ func TestStartConsuming(t *testing.T) {
 		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
 		updateOrderTopic := mocks.NewMockTopicService(t)
 
-		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic)
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
 
 		err := service.UpdateQueueUrl(ctx)
 		assert.NoError(t, err)
@@ -215,6 +345,7 @@ func TestStartConsuming(t *testing.T) {
 		service.ConsumeMessages(ctx)
 
 		// Assert
+		assert.True(t, service.LastPollAt().IsZero())
 		testtools.ExitTest(stubber, t)
 		fakeProcessor.AssertExpectations(t)
 	})
@@ -249,9 +380,10 @@ func TestStartConsuming(t *testing.T) {
 		stubber.Add(testtools.Stub{
 			OperationName: "ReceiveMessage",
 			Input: &sqs.ReceiveMessageInput{
-				QueueUrl:            aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
-				MaxNumberOfMessages: 10,
-				WaitTimeSeconds:     20,
+				QueueUrl:              aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				MaxNumberOfMessages:   10,
+				WaitTimeSeconds:       20,
+				MessageAttributeNames: []string{"All"},
 			},
 			Output: &sqs.ReceiveMessageOutput{
 				Messages: []types.Message{
@@ -290,11 +422,11 @@ func TestStartConsuming(t *testing.T) {
 		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
 		updateOrderTopic := mocks.NewMockTopicService(t)
 
-		fakeProcessor.On("Handle", ctx, mock.Anything).
+		fakeProcessor.On("Handle", asServiceRole, mock.Anything).
 			Return(nil, nil).
 			Times(2)
 
-		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic)
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
 
 		err := service.UpdateQueueUrl(ctx)
 		assert.NoError(t, err)
@@ -337,9 +469,10 @@ func TestStartConsuming(t *testing.T) {
 		stubber.Add(testtools.Stub{
 			OperationName: "ReceiveMessage",
 			Input: &sqs.ReceiveMessageInput{
-				QueueUrl:            aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
-				MaxNumberOfMessages: 10,
-				WaitTimeSeconds:     20,
+				QueueUrl:              aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				MaxNumberOfMessages:   10,
+				WaitTimeSeconds:       20,
+				MessageAttributeNames: []string{"All"},
 			},
 			Output: &sqs.ReceiveMessageOutput{
 				Messages: []types.Message{
@@ -378,11 +511,11 @@ func TestStartConsuming(t *testing.T) {
 		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
 		updateOrderTopic := mocks.NewMockTopicService(t)
 
-		fakeProcessor.On("Handle", ctx, mock.Anything).
+		fakeProcessor.On("Handle", asServiceRole, mock.Anything).
 			Return(nil, assert.AnError).
 			Times(2)
 
-		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic)
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
 
 		err := service.UpdateQueueUrl(ctx)
 		assert.NoError(t, err)
@@ -427,9 +560,10 @@ func TestStartConsuming(t *testing.T) {
 		stubber.Add(testtools.Stub{
 			OperationName: "ReceiveMessage",
 			Input: &sqs.ReceiveMessageInput{
-				QueueUrl:            aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
-				MaxNumberOfMessages: 10,
-				WaitTimeSeconds:     20,
+				QueueUrl:              aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				MaxNumberOfMessages:   10,
+				WaitTimeSeconds:       20,
+				MessageAttributeNames: []string{"All"},
 			},
 			Output: &sqs.ReceiveMessageOutput{
 				Messages: []types.Message{
@@ -454,11 +588,11 @@ func TestStartConsuming(t *testing.T) {
 		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
 		updateOrderTopic := mocks.NewMockTopicService(t)
 
-		fakeProcessor.On("Handle", ctx, mock.Anything).
+		fakeProcessor.On("Handle", asServiceRole, mock.Anything).
 			Return(nil, nil).
 			Once()
 
-		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic)
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, nil)
 
 		err := service.UpdateQueueUrl(ctx)
 		assert.NoError(t, err)
@@ -470,4 +604,257 @@ func TestStartConsuming(t *testing.T) {
 		testtools.ExitTest(stubber, t)
 		fakeProcessor.AssertExpectations(t)
 	})
+
+	t.Run("Should send message to dead letter queue when cannot process message", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Input: &sqs.GetQueueUrlInput{
+				QueueName: aws.String("test-queue"),
+			},
+			Output: &sqs.GetQueueUrlOutput{
+				QueueUrl: aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+			},
+		})
+
+		response := `{
+			"Type" : "Notification",
+			"MessageId" : "fc8e9ffd-6122-5c52-8fb9-c13e3ee2629a",
+			"Message" : "{\"order_id\":\"c3fdab1b-3c06-4db2-9edc-4760a2429462\",\"items\":[]}"
+		}`
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input: &sqs.ReceiveMessageInput{
+				QueueUrl:              aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				MaxNumberOfMessages:   10,
+				WaitTimeSeconds:       20,
+				MessageAttributeNames: []string{"All"},
+			},
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{
+						MessageId:     aws.String("123"),
+						Body:          aws.String(response),
+						ReceiptHandle: aws.String("1234567891"),
+					},
+				},
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DeleteMessage",
+			Input: &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				ReceiptHandle: aws.String("1234567891"),
+			},
+			Output: &sqs.DeleteMessageOutput{},
+		})
+
+		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+		deadLetterSender := mocks.NewMockDeadLetterSender(t)
+
+		fakeProcessor.On("Handle", asServiceRole, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		deadLetterSender.On("SendMessage", mock.Anything, "123", response, assert.AnError.Error()).
+			Return(nil).
+			Once()
+
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, deadLetterSender)
+
+		err := service.UpdateQueueUrl(ctx)
+		assert.NoError(t, err)
+
+		// Act
+		service.ConsumeMessages(ctx)
+
+		// Assert
+		testtools.ExitTest(stubber, t)
+		fakeProcessor.AssertExpectations(t)
+		deadLetterSender.AssertExpectations(t)
+	})
+
+	t.Run("Should delete without dead lettering the message of an order that already exists", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Input: &sqs.GetQueueUrlInput{
+				QueueName: aws.String("test-queue"),
+			},
+			Output: &sqs.GetQueueUrlOutput{
+				QueueUrl: aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+			},
+		})
+
+		response := `{
+			"Type" : "Notification",
+			"MessageId" : "fc8e9ffd-6122-5c52-8fb9-c13e3ee2629a",
+			"Message" : "{\"order_id\":\"c3fdab1b-3c06-4db2-9edc-4760a2429462\",\"items\":[]}"
+		}`
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input: &sqs.ReceiveMessageInput{
+				QueueUrl:              aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				MaxNumberOfMessages:   10,
+				WaitTimeSeconds:       20,
+				MessageAttributeNames: []string{"All"},
+			},
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{
+						MessageId:     aws.String("123"),
+						Body:          aws.String(response),
+						ReceiptHandle: aws.String("1234567891"),
+					},
+				},
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "DeleteMessage",
+			Input: &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				ReceiptHandle: aws.String("1234567891"),
+			},
+			Output: &sqs.DeleteMessageOutput{},
+		})
+
+		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+		deadLetterSender := mocks.NewMockDeadLetterSender(t)
+
+		fakeProcessor.On("Handle", asServiceRole, mock.Anything).
+			Return(nil, custom_error.ErrOrderAlreadyExists).
+			Once()
+
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, deadLetterSender)
+
+		err := service.UpdateQueueUrl(ctx)
+		assert.NoError(t, err)
+
+		// Act
+		service.ConsumeMessages(ctx)
+
+		// Assert
+		testtools.ExitTest(stubber, t)
+		fakeProcessor.AssertExpectations(t)
+		deadLetterSender.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should keep the message when cannot send it to dead letter queue", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetQueueUrl",
+			Input: &sqs.GetQueueUrlInput{
+				QueueName: aws.String("test-queue"),
+			},
+			Output: &sqs.GetQueueUrlOutput{
+				QueueUrl: aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+			},
+		})
+
+		stubber.Add(testtools.Stub{
+			OperationName: "ReceiveMessage",
+			Input: &sqs.ReceiveMessageInput{
+				QueueUrl:              aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"),
+				MaxNumberOfMessages:   10,
+				WaitTimeSeconds:       20,
+				MessageAttributeNames: []string{"All"},
+			},
+			Output: &sqs.ReceiveMessageOutput{
+				Messages: []types.Message{
+					{
+						MessageId:     aws.String("123"),
+						Body:          aws.String(`{"Type":"SubscriptionConfirmation"}`),
+						ReceiptHandle: aws.String("1234567891"),
+					},
+				},
+			},
+		})
+
+		fakeProcessor := service_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+		deadLetterSender := mocks.NewMockDeadLetterSender(t)
+
+		deadLetterSender.On("SendMessage", mock.Anything, "123", mock.Anything, "invalid notification type: SubscriptionConfirmation").
+			Return(assert.AnError).
+			Once()
+
+		service := NewQueueService("test-queue", *stubber.SdkConfig, fakeProcessor, updateOrderTopic, deadLetterSender)
+
+		err := service.UpdateQueueUrl(ctx)
+		assert.NoError(t, err)
+
+		// Act
+		service.ConsumeMessages(ctx)
+
+		// Assert
+		testtools.ExitTest(stubber, t)
+		fakeProcessor.AssertExpectations(t)
+		deadLetterSender.AssertExpectations(t)
+	})
+}
+
+func TestDecodeOrderProductionMessage(t *testing.T) {
+	t.Run("Should decode the notification and the payload", func(t *testing.T) {
+		// Arrange
+		body := `{"Type":"Notification","MessageId":"123","Message":"{\"order_id\":\"c3fdab1b-3c06-4db2-9edc-4760a2429462\",\"items\":[{\"id\":\"cfdab175-1f86-4fb0-9bcb-15f2c58df30c\",\"name\":\"Hamburger\",\"quantity\":1}]}"}`
+
+		// Act
+		notification, request, err := DecodeOrderProductionMessage(body)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "123", notification.MessageId)
+		assert.Equal(t, "c3fdab1b-3c06-4db2-9edc-4760a2429462", request.OrderId)
+		assert.Len(t, request.Items, 1)
+	})
+
+	t.Run("Should return error when body is not a notification", func(t *testing.T) {
+		// Arrange
+		body := `not-a-json`
+
+		// Act
+		_, _, err := DecodeOrderProductionMessage(body)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when payload is not valid", func(t *testing.T) {
+		// Arrange
+		body := `{"Type":"Notification","Message":"not-a-json"}`
+
+		// Act
+		_, _, err := DecodeOrderProductionMessage(body)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return the schema rejection reasons", func(t *testing.T) {
+		// Arrange
+		body := `{"Type":"Notification","Message":"{\"order_id\":\"invalid\",\"items\":[{\"id\":\"cfdab175-1f86-4fb0-9bcb-15f2c58df30c\",\"name\":\"Hamburger\",\"quantity\":0}]}"}`
+
+		// Act
+		_, _, err := DecodeOrderProductionMessage(body)
+
+		// Assert
+		var validationErr *schema.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Len(t, validationErr.Reasons, 2)
+	})
 }
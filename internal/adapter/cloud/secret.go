@@ -1,7 +1,14 @@
 package cloud
 
-import "context"
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
+)
 
 type SecretService interface {
 	GetSecret(ctx context.Context, secretName string) (string, error)
+	// HealthCheck returns the check of the access to the secret, its value
+	// is not read
+	HealthCheck(secretName string) health.HealthCheck
 }
@@ -2,12 +2,24 @@ package cloud
 
 import (
 	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
 )
 
 type TopicService interface {
 	GetTopicName() string
 	UpdateTopicArn(ctx context.Context) error
 	PublishMessage(ctx context.Context, message interface{}) (*string, error)
+	PublishBatch(ctx context.Context, messages []interface{}) ([]*string, error)
+	health.HealthCheck
+}
+
+// TopicMessage is implemented by the messages that carry SNS metadata,
+// allowing subscribers to filter them and FIFO topics to order them
+type TopicMessage interface {
+	MessageAttributes() map[string]string
+	MessageGroupId() string
+	MessageDeduplicationId() string
 }
 
 type TopicNotification struct {
@@ -20,4 +32,6 @@ type TopicNotification struct {
 	Signature        string `json:"Signature"`
 	SigningCertURL   string `json:"SigningCertURL"`
 	UnsubscribeURL   string `json:"UnsubscribeURL"`
+
+	MessageAttributes TopicNotificationAttributes `json:"MessageAttributes,omitempty"`
 }
@@ -0,0 +1,159 @@
+package cloud
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+)
+
+const (
+	CloudEventSpecVersion = "1.0"
+	OrderEventSource      = "/fastfood/ms-production-management"
+	OrderEventDataSchema  = "urn:fastfood:production:order-event:v1"
+	OrderEventVersion     = "2"
+
+	LegacyEventFormat      = "legacy"
+	CloudEventsEventFormat = "cloudevents"
+	BothEventFormat        = "both"
+
+	UserActorType    = "user"
+	ServiceActorType = "service"
+)
+
+type EventActor struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+func NewUserActor(userId string) EventActor {
+	return EventActor{
+		Type: UserActorType,
+		Id:   userId,
+	}
+}
+
+func NewServiceActor(serviceName string) EventActor {
+	return EventActor{
+		Type: ServiceActorType,
+		Id:   serviceName,
+	}
+}
+
+// OrderEvent is what is published when an order changes, the topic service
+// turns it into the legacy contract, the cloud event or both according to
+// the configured event format
+type OrderEvent struct {
+	Order *order_entity.Order
+	Actor EventActor
+}
+
+func NewOrderEvent(order *order_entity.Order, actor EventActor) *OrderEvent {
+	return &OrderEvent{
+		Order: order,
+		Actor: actor,
+	}
+}
+
+type OrderEventItem struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	Station  string `json:"station,omitempty"`
+}
+
+type OrderEventData struct {
+	OrderId       string `json:"order_id"`
+	PreviousState string `json:"previous_state,omitempty"`
+	State         string `json:"state"`
+
+	StateUpdatedAt time.Time `json:"state_updated_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	Items []OrderEventItem `json:"items"`
+
+	Actor EventActor `json:"actor"`
+}
+
+// OrderCloudEvent follows the CloudEvents 1.0 JSON format
+type OrderCloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	Id              string         `json:"id"`
+	Type            string         `json:"type"`
+	Source          string         `json:"source"`
+	Subject         string         `json:"subject"`
+	Time            time.Time      `json:"time"`
+	DataContentType string         `json:"datacontenttype"`
+	DataSchema      string         `json:"dataschema"`
+	Data            OrderEventData `json:"data"`
+}
+
+func NewOrderCloudEvent(event *OrderEvent) *OrderCloudEvent {
+	order := event.Order
+
+	items := make([]OrderEventItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, OrderEventItem{
+			Id:       item.Id,
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Station:  item.Station,
+		})
+	}
+
+	previousState := ""
+	if order.PreviousState != order_entity.None {
+		previousState = order.PreviousState.String()
+	}
+
+	return &OrderCloudEvent{
+		SpecVersion:     CloudEventSpecVersion,
+		Id:              uuid.NewString(),
+		Type:            eventTypeFromState(order.State),
+		Source:          OrderEventSource,
+		Subject:         order.Id,
+		Time:            order.StateUpdatedAt,
+		DataContentType: "application/json",
+		DataSchema:      OrderEventDataSchema,
+		Data: OrderEventData{
+			OrderId:        order.Id,
+			PreviousState:  previousState,
+			State:          order.State.String(),
+			StateUpdatedAt: order.StateUpdatedAt,
+			CreatedAt:      order.CreatedAt,
+			UpdatedAt:      order.UpdatedAt,
+			Items:          items,
+			Actor:          event.Actor,
+		},
+	}
+}
+
+func (e *OrderCloudEvent) MessageAttributes() map[string]string {
+	return map[string]string{
+		"event_type":     e.Type,
+		"event_format":   CloudEventsEventFormat,
+		"order_state":    e.Data.State,
+		"schema_version": OrderEventVersion,
+	}
+}
+
+func (e *OrderCloudEvent) MessageGroupId() string {
+	return e.Data.OrderId
+}
+
+func (e *OrderCloudEvent) MessageDeduplicationId() string {
+	return deduplicationId(CloudEventsEventFormat, e.Data.OrderId, e.Data.State, e.Data.StateUpdatedAt)
+}
+
+// deduplicationId is derived from the order state and the moment it was
+// reached, so a retried publish of the same transition is discarded by SNS
+func deduplicationId(format string, orderId string, state string, stateUpdatedAt time.Time) string {
+	key := fmt.Sprintf("%s:%s:%s:%d", format, orderId, state, stateUpdatedAt.UnixNano())
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
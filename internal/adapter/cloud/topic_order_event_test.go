@@ -0,0 +1,100 @@
+package cloud
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrderCloudEvent(t *testing.T) {
+	t.Run("Should build the cloud event from the order", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", now)
+		err := order.AddItem(order_entity.NewItem("cfdab175-1f86-4fb0-9bcb-15f2c58df30c", "Hamburger", 2), now)
+		assert.NoError(t, err)
+
+		err = order.UpdateState(order_entity.Processing, now)
+		assert.NoError(t, err)
+
+		event := NewOrderEvent(&order, NewUserActor("user-1"))
+
+		// Act
+		cloudEvent := NewOrderCloudEvent(event)
+
+		// Assert
+		assert.Equal(t, CloudEventSpecVersion, cloudEvent.SpecVersion)
+		assert.NotEmpty(t, cloudEvent.Id)
+		assert.Equal(t, OrderStateChangedEventType, cloudEvent.Type)
+		assert.Equal(t, OrderEventSource, cloudEvent.Source)
+		assert.Equal(t, order.Id, cloudEvent.Subject)
+		assert.Equal(t, OrderEventDataSchema, cloudEvent.DataSchema)
+		assert.Equal(t, "Received", cloudEvent.Data.PreviousState)
+		assert.Equal(t, "Processing", cloudEvent.Data.State)
+		assert.Equal(t, []OrderEventItem{
+			{Id: "cfdab175-1f86-4fb0-9bcb-15f2c58df30c", Name: "Hamburger", Quantity: 2},
+		}, cloudEvent.Data.Items)
+		assert.Equal(t, EventActor{Type: UserActorType, Id: "user-1"}, cloudEvent.Data.Actor)
+	})
+
+	t.Run("Should omit the previous state of a new order", func(t *testing.T) {
+		// Arrange
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+
+		cloudEvent := NewOrderCloudEvent(NewOrderEvent(&order, NewServiceActor(QueueActorId)))
+
+		// Act
+		body, err := json.Marshal(cloudEvent)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotContains(t, string(body), "previous_state")
+		assert.Equal(t, OrderCreatedEventType, cloudEvent.Type)
+	})
+
+	t.Run("Should return the message attributes", func(t *testing.T) {
+		// Arrange
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+
+		cloudEvent := NewOrderCloudEvent(NewOrderEvent(&order, NewServiceActor(QueueActorId)))
+
+		// Act
+		attributes := cloudEvent.MessageAttributes()
+
+		// Assert
+		assert.Equal(t, map[string]string{
+			"event_format":   CloudEventsEventFormat,
+			"event_type":     OrderCreatedEventType,
+			"order_state":    "Received",
+			"schema_version": OrderEventVersion,
+		}, attributes)
+		assert.Equal(t, order.Id, cloudEvent.MessageGroupId())
+		assert.NotEqual(t, NewUpdateOrderContract(&order).MessageDeduplicationId(), cloudEvent.MessageDeduplicationId())
+	})
+
+	t.Run("Should match the order event schema", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", now)
+		err := order.AddItem(order_entity.NewItem("cfdab175-1f86-4fb0-9bcb-15f2c58df30c", "Hamburger", 1), now)
+		assert.NoError(t, err)
+
+		err = order.UpdateState(order_entity.Cancelled, now)
+		assert.NoError(t, err)
+
+		body, err := json.Marshal(NewOrderCloudEvent(NewOrderEvent(&order, NewUserActor("user-1"))))
+		assert.NoError(t, err)
+
+		// Act
+		err = schema.Validate(schema.OrderEventSchema, body)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
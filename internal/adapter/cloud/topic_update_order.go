@@ -2,26 +2,57 @@ package cloud
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/metrics"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/tracing"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// publishBatchMaxEntries is the maximum number of messages SNS accepts in a
+// single PublishBatch request
+const publishBatchMaxEntries = 10
+
 type UpdateOrderTopicService struct {
-	TopicName string
-	TopicArn  string
-	Client    *sns.Client
+	TopicName   string
+	TopicArn    string
+	IsFifo      bool
+	EventFormat string
+	StoreId     string
+	Client      *sns.Client
 }
 
-func NewUpdateOrderTopicService(topicName string, config aws.Config) TopicService {
+func NewUpdateOrderTopicService(
+	topicName string,
+	isFifo bool,
+	eventFormat string,
+	storeId string,
+	config aws.Config,
+) TopicService {
 	client := sns.NewFromConfig(config)
 
 	return &UpdateOrderTopicService{
-		TopicName: topicName,
-		Client:    client,
+		TopicName:   topicName,
+		IsFifo:      isFifo,
+		EventFormat: eventFormat,
+		StoreId:     storeId,
+		Client:      client,
 	}
 }
 
@@ -45,21 +76,234 @@ func (s *UpdateOrderTopicService) UpdateTopicArn(ctx context.Context) error {
 	return custom_error.ErrTopicNotFound
 }
 
+// Health checks the topic can be reached by reading its attributes
+func (s *UpdateOrderTopicService) Health(ctx context.Context) *health.HealthStatus {
+	_, err := s.Client.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{
+		TopicArn: aws.String(s.TopicArn),
+	})
+
+	return health.NewHealthStatus(err)
+}
+
+// PublishMessage publishes the message as is, unless it is an order event, in
+// that case one message is published for each configured event format and the
+// id of the first one is returned
 func (s *UpdateOrderTopicService) PublishMessage(ctx context.Context, message interface{}) (*string, error) {
+	event, ok := message.(*OrderEvent)
+	if !ok {
+		return s.publish(ctx, message)
+	}
+
+	var messageId *string
+
+	for _, message := range s.messagesFromEvent(event) {
+		id, err := s.publish(ctx, message)
+		if err != nil {
+			return messageId, err
+		}
+
+		if messageId == nil {
+			messageId = id
+		}
+	}
+
+	return messageId, nil
+}
+
+// PublishBatch publishes the messages in batches of up to ten entries, order
+// events are expanded like in PublishMessage. The ids are returned in the same
+// order of the messages, nil for the messages not published, and the error
+// joins every failure
+func (s *UpdateOrderTopicService) PublishBatch(ctx context.Context, messages []interface{}) ([]*string, error) {
+	ctx, span := s.startPublishSpan(ctx)
+	defer span.End()
+
+	messageIds := make([]*string, len(messages))
+
+	var entries []types.PublishBatchRequestEntry
+	var positions []int
+	var errs []error
+
+	for position, message := range messages {
+		expanded := []interface{}{message}
+		if event, ok := message.(*OrderEvent); ok {
+			expanded = s.messagesFromEvent(event)
+		}
+
+		for _, message := range expanded {
+			entry, err := s.buildBatchEntry(ctx, message, len(entries))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			entries = append(entries, entry)
+			positions = append(positions, position)
+		}
+	}
+
+	span.SetAttributes(semconv.MessagingBatchMessageCount(len(entries)))
+
+	for start := 0; start < len(entries); start += publishBatchMaxEntries {
+		end := min(start+publishBatchMaxEntries, len(entries))
+
+		out, err := s.Client.PublishBatch(ctx, &sns.PublishBatchInput{
+			TopicArn:                   aws.String(s.TopicArn),
+			PublishBatchRequestEntries: entries[start:end],
+		})
+		if err != nil {
+			metrics.SnsPublishFailed(s.TopicName, end-start)
+			errs = append(errs, err)
+			continue
+		}
+
+		metrics.SnsPublishFailed(s.TopicName, len(out.Failed))
+
+		for _, success := range out.Successful {
+			index, err := strconv.Atoi(aws.ToString(success.Id))
+			if err != nil || index >= len(positions) {
+				continue
+			}
+
+			if position := positions[index]; messageIds[position] == nil {
+				messageIds[position] = success.MessageId
+			}
+		}
+
+		for _, failed := range out.Failed {
+			errs = append(errs, fmt.Errorf("error publishing batch entry %s: %s", aws.ToString(failed.Id), aws.ToString(failed.Message)))
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error publishing batch")
+	}
+
+	return messageIds, err
+}
+
+func (s *UpdateOrderTopicService) buildBatchEntry(ctx context.Context, message interface{}, index int) (types.PublishBatchRequestEntry, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return types.PublishBatchRequestEntry{}, err
+	}
+
+	entry := types.PublishBatchRequestEntry{
+		Id:                aws.String(strconv.Itoa(index)),
+		Message:           aws.String(string(body)),
+		MessageAttributes: s.buildAttributes(ctx, message),
+	}
+
+	if s.IsFifo {
+		entry.MessageGroupId, entry.MessageDeduplicationId = s.buildFifoIds(message, body)
+	}
+
+	return entry, nil
+}
+
+func (s *UpdateOrderTopicService) messagesFromEvent(event *OrderEvent) []interface{} {
+	switch s.EventFormat {
+	case CloudEventsEventFormat:
+		return []interface{}{NewOrderCloudEvent(event)}
+	case BothEventFormat:
+		return []interface{}{NewUpdateOrderContract(event.Order), NewOrderCloudEvent(event)}
+	default:
+		return []interface{}{NewUpdateOrderContract(event.Order)}
+	}
+}
+
+func (s *UpdateOrderTopicService) publish(ctx context.Context, message interface{}) (*string, error) {
+	ctx, span := s.startPublishSpan(ctx)
+	defer span.End()
+
 	body, err := json.Marshal(message)
 	if err != nil {
 		return nil, err
 	}
 
 	req := &sns.PublishInput{
-		TopicArn: aws.String(s.TopicArn),
-		Message:  aws.String(string(body)),
+		TopicArn:          aws.String(s.TopicArn),
+		Message:           aws.String(string(body)),
+		MessageAttributes: s.buildAttributes(ctx, message),
+	}
+
+	if s.IsFifo {
+		req.MessageGroupId, req.MessageDeduplicationId = s.buildFifoIds(message, body)
 	}
 
 	out, err := s.Client.Publish(ctx, req)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error publishing message")
+
+		metrics.SnsPublishFailed(s.TopicName, 1)
 		return nil, err
 	}
 
+	span.SetAttributes(semconv.MessagingMessageID(aws.ToString(out.MessageId)))
+
 	return out.MessageId, nil
 }
+
+// startPublishSpan starts the producer span, its context is the one injected
+// in the attributes of the published messages
+func (s *UpdateOrderTopicService) startPublishSpan(ctx context.Context) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, fmt.Sprintf("%s publish", s.TopicName),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("aws_sns"),
+			semconv.MessagingOperationPublish,
+			semconv.MessagingDestinationName(s.TopicName),
+		),
+	)
+}
+
+func (s *UpdateOrderTopicService) buildAttributes(ctx context.Context, message interface{}) map[string]types.MessageAttributeValue {
+	values := make(map[string]string)
+
+	if topicMessage, ok := message.(TopicMessage); ok {
+		values = topicMessage.MessageAttributes()
+		if s.StoreId != "" {
+			values["store"] = s.StoreId
+		}
+	}
+
+	// the consumers continue the trace and the flow of the publisher
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(values))
+
+	if correlationId := correlation.CorrelationIdFromContext(ctx); correlationId != "" {
+		values[correlation.CorrelationIdKey] = correlationId
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	attributes := make(map[string]types.MessageAttributeValue, len(values))
+
+	for key, value := range values {
+		// SNS rejects attributes with empty values
+		if value == "" {
+			continue
+		}
+
+		attributes[key] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+
+	return attributes
+}
+
+func (s *UpdateOrderTopicService) buildFifoIds(message interface{}, body []byte) (*string, *string) {
+	if topicMessage, ok := message.(TopicMessage); ok {
+		return aws.String(topicMessage.MessageGroupId()), aws.String(topicMessage.MessageDeduplicationId())
+	}
+
+	hash := sha256.Sum256(body)
+
+	return aws.String(s.TopicName), aws.String(hex.EncodeToString(hash[:]))
+}
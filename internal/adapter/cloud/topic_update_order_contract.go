@@ -1,6 +1,18 @@
 package cloud
 
-import "github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+import (
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+)
+
+const (
+	UpdateOrderSchemaVersion = "1"
+
+	OrderCreatedEventType      = "production.order.created"
+	OrderStateChangedEventType = "production.order.state_changed"
+	OrderCancelledEventType    = "production.order.cancelled"
+)
 
 type UpdateOrderTopicOrderContract struct {
 	State string `json:"state"`
@@ -9,9 +21,12 @@ type UpdateOrderTopicOrderContract struct {
 type UpdateOrderTopicContract struct {
 	OrderId string                        `json:"order_id"`
 	Order   UpdateOrderTopicOrderContract `json:"order"`
+
+	eventType      string
+	stateUpdatedAt time.Time
 }
 
-func NewUpdateOrderContractFromPayment(order *order_entity.Order) *UpdateOrderTopicContract {
+func NewUpdateOrderContract(order *order_entity.Order) *UpdateOrderTopicContract {
 	order.RefreshStateTitle()
 
 	return &UpdateOrderTopicContract{
@@ -19,5 +34,40 @@ func NewUpdateOrderContractFromPayment(order *order_entity.Order) *UpdateOrderTo
 		Order: UpdateOrderTopicOrderContract{
 			State: order.StateTitle,
 		},
+
+		eventType:      eventTypeFromState(order.State),
+		stateUpdatedAt: order.StateUpdatedAt,
+	}
+}
+
+func (c *UpdateOrderTopicContract) EventType() string {
+	return c.eventType
+}
+
+func (c *UpdateOrderTopicContract) MessageAttributes() map[string]string {
+	return map[string]string{
+		"event_type":     c.eventType,
+		"event_format":   LegacyEventFormat,
+		"order_state":    c.Order.State,
+		"schema_version": UpdateOrderSchemaVersion,
+	}
+}
+
+func (c *UpdateOrderTopicContract) MessageGroupId() string {
+	return c.OrderId
+}
+
+func (c *UpdateOrderTopicContract) MessageDeduplicationId() string {
+	return deduplicationId(LegacyEventFormat, c.OrderId, c.Order.State, c.stateUpdatedAt)
+}
+
+func eventTypeFromState(state order_entity.OrderState) string {
+	switch state {
+	case order_entity.Received:
+		return OrderCreatedEventType
+	case order_entity.Cancelled:
+		return OrderCancelledEventType
+	default:
+		return OrderStateChangedEventType
 	}
 }
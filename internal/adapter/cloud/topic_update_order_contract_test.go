@@ -1,11 +1,13 @@
 package cloud
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,10 +17,73 @@ func TestNewContract(t *testing.T) {
 		order := order_entity.NewOrder(uuid.NewString(), time.Now())
 
 		// Act
-		contract := NewUpdateOrderContractFromPayment(&order)
+		contract := NewUpdateOrderContract(&order)
 
 		// Assert
 		assert.Equal(t, order.Id, contract.OrderId)
 		assert.Equal(t, order.StateTitle, contract.Order.State)
 	})
+
+	t.Run("Should return the message attributes", func(t *testing.T) {
+		// Arrange
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+
+		err := order.UpdateState(order_entity.Cancelled, time.Now())
+		assert.NoError(t, err)
+
+		contract := NewUpdateOrderContract(&order)
+
+		// Act
+		attributes := contract.MessageAttributes()
+
+		// Assert
+		assert.Equal(t, map[string]string{
+			"event_format":   LegacyEventFormat,
+			"event_type":     OrderCancelledEventType,
+			"order_state":    "Cancelled",
+			"schema_version": UpdateOrderSchemaVersion,
+		}, attributes)
+		assert.Equal(t, order.Id, contract.MessageGroupId())
+	})
+
+	t.Run("Should return the same deduplication id for the same transition", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		order := order_entity.NewOrder(uuid.NewString(), now)
+
+		first := NewUpdateOrderContract(&order)
+		second := NewUpdateOrderContract(&order)
+
+		err := order.UpdateState(order_entity.Processing, now.Add(time.Second))
+		assert.NoError(t, err)
+
+		third := NewUpdateOrderContract(&order)
+
+		// Act
+		firstId := first.MessageDeduplicationId()
+		secondId := second.MessageDeduplicationId()
+		thirdId := third.MessageDeduplicationId()
+
+		// Assert
+		assert.Equal(t, firstId, secondId)
+		assert.NotEqual(t, firstId, thirdId)
+		assert.Equal(t, OrderStateChangedEventType, third.EventType())
+	})
+
+	t.Run("Should match the update order schema", func(t *testing.T) {
+		// Arrange
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+
+		contract := NewUpdateOrderContract(&order)
+
+		body, err := json.Marshal(contract)
+		assert.NoError(t, err)
+
+		// Act
+		err = schema.Validate(schema.UpdateOrderSchema, body)
+
+		// Assert
+		assert.NoError(t, err)
+	})
 }
@@ -2,21 +2,29 @@ package cloud
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go-v2/service/sns/types"
 	"github.com/awsdocs/aws-doc-sdk-examples/gov2/testtools"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestUpdateOrderGetTopicName(t *testing.T) {
 	t.Run("Should return topic name", func(t *testing.T) {
 		// Arrange
-		service := NewUpdateOrderTopicService("test-topic", aws.Config{})
+		service := NewUpdateOrderTopicService("test-topic", false, LegacyEventFormat, "", aws.Config{})
 
 		// Act
 		topicName := service.GetTopicName()
@@ -44,7 +52,7 @@ func TestUpdateOrderUpdateTopicArn(t *testing.T) {
 			},
 		})
 
-		service := NewUpdateOrderTopicService("test-topic", *stubber.SdkConfig)
+		service := NewUpdateOrderTopicService("test-topic", false, LegacyEventFormat, "", *stubber.SdkConfig)
 
 		// Act
 		err := service.UpdateTopicArn(ctx)
@@ -71,7 +79,7 @@ func TestUpdateOrderUpdateTopicArn(t *testing.T) {
 			},
 		})
 
-		service := NewUpdateOrderTopicService("test-topic", *stubber.SdkConfig)
+		service := NewUpdateOrderTopicService("test-topic", false, LegacyEventFormat, "", *stubber.SdkConfig)
 
 		// Act
 		err := service.UpdateTopicArn(ctx)
@@ -93,7 +101,7 @@ func TestUpdateOrderUpdateTopicArn(t *testing.T) {
 			Error:         raiseErr,
 		})
 
-		service := NewUpdateOrderTopicService("test-topic", *stubber.SdkConfig)
+		service := NewUpdateOrderTopicService("test-topic", false, LegacyEventFormat, "", *stubber.SdkConfig)
 
 		// Act
 		err := service.UpdateTopicArn(ctx)
@@ -104,6 +112,55 @@ func TestUpdateOrderUpdateTopicArn(t *testing.T) {
 	})
 }
 
+func TestUpdateOrderHealth(t *testing.T) {
+	t.Run("Should return healthy when the topic attributes are read", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetTopicAttributes",
+			Input: &sns.GetTopicAttributesInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+			},
+			Output: &sns.GetTopicAttributesOutput{},
+		})
+
+		service := NewUpdateOrderTopicService("test-topic", false, LegacyEventFormat, "", *stubber.SdkConfig)
+		service.(*UpdateOrderTopicService).TopicArn = "arn:aws:sns:us-east-1:123456789012:test-topic"
+
+		// Act
+		status := service.Health(ctx)
+
+		// Assert
+		assert.Equal(t, health.HealthyStatus, status.Status)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return unhealthy when GetTopicAttributes operation fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		raiseErr := &testtools.StubError{Err: errors.New("ClientError")}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "GetTopicAttributes",
+			Error:         raiseErr,
+		})
+
+		service := NewUpdateOrderTopicService("test-topic", false, LegacyEventFormat, "", *stubber.SdkConfig)
+
+		// Act
+		status := service.Health(ctx)
+
+		// Assert
+		assert.Equal(t, health.UnhealthyStatus, status.Status)
+		assert.True(t, status.HasError())
+		testtools.ExitTest(stubber, t)
+	})
+}
+
 func TestUpdateOrderPublishMessage(t *testing.T) {
 	t.Run("Should return nil when message is published", func(t *testing.T) {
 		// Arrange
@@ -133,7 +190,7 @@ func TestUpdateOrderPublishMessage(t *testing.T) {
 			},
 		})
 
-		service := NewUpdateOrderTopicService("test-topic", *stubber.SdkConfig)
+		service := NewUpdateOrderTopicService("test-topic", false, LegacyEventFormat, "", *stubber.SdkConfig)
 
 		err := service.UpdateTopicArn(ctx)
 		assert.NoError(t, err)
@@ -177,7 +234,7 @@ func TestUpdateOrderPublishMessage(t *testing.T) {
 			Error: raiseErr,
 		})
 
-		service := NewUpdateOrderTopicService("test-topic", *stubber.SdkConfig)
+		service := NewUpdateOrderTopicService("test-topic", false, LegacyEventFormat, "", *stubber.SdkConfig)
 
 		err := service.UpdateTopicArn(ctx)
 		assert.NoError(t, err)
@@ -193,4 +250,512 @@ func TestUpdateOrderPublishMessage(t *testing.T) {
 		testtools.VerifyError(err, raiseErr, t)
 		testtools.ExitTest(stubber, t)
 	})
+
+	t.Run("Should publish the message attributes", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+		message := NewUpdateOrderContract(&order)
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+				Message:  aws.String(`{"order_id":"c3fdab1b-3c06-4db2-9edc-4760a2429462","order":{"state":"Received"}}`),
+				MessageAttributes: map[string]types.MessageAttributeValue{
+					"event_format": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String(LegacyEventFormat),
+					},
+					"event_type": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String(OrderCreatedEventType),
+					},
+					"order_state": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String("Received"),
+					},
+					"schema_version": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String(UpdateOrderSchemaVersion),
+					},
+					"store": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String("store-1"),
+					},
+				},
+			},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("1234"),
+			},
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName: "test-topic",
+			TopicArn:  "arn:aws:sns:us-east-1:123456789012:test-topic",
+			StoreId:   "store-1",
+			Client:    sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		// Act
+		resp, err := service.PublishMessage(ctx, message)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", *resp)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should publish the trace context in the message attributes", func(t *testing.T) {
+		// Arrange
+		useTraceContext(t)
+
+		traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanId, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+		ctx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceId,
+			SpanID:     spanId,
+			TraceFlags: trace.FlagsSampled,
+		}))
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+				Message:  aws.String(`{"message":"test"}`),
+				MessageAttributes: map[string]types.MessageAttributeValue{
+					"traceparent": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+					},
+				},
+			},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("1234"),
+			},
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName: "test-topic",
+			TopicArn:  "arn:aws:sns:us-east-1:123456789012:test-topic",
+			Client:    sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		// Act
+		resp, err := service.PublishMessage(ctx, map[string]string{"message": "test"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", *resp)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should publish the correlation id in the message attributes", func(t *testing.T) {
+		// Arrange
+		ctx := correlation.WithIds(context.Background(), "request-id", "correlation-id")
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+				Message:  aws.String(`{"message":"test"}`),
+				MessageAttributes: map[string]types.MessageAttributeValue{
+					"correlation_id": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String("correlation-id"),
+					},
+				},
+			},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("1234"),
+			},
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName: "test-topic",
+			TopicArn:  "arn:aws:sns:us-east-1:123456789012:test-topic",
+			Client:    sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		// Act
+		resp, err := service.PublishMessage(ctx, map[string]string{"message": "test"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", *resp)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should publish with group and deduplication ids when topic is fifo", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+		message := NewUpdateOrderContract(&order)
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn:               aws.String("arn:aws:sns:us-east-1:123456789012:test-topic.fifo"),
+				Message:                aws.String(`{"order_id":"c3fdab1b-3c06-4db2-9edc-4760a2429462","order":{"state":"Received"}}`),
+				MessageGroupId:         aws.String(order.Id),
+				MessageDeduplicationId: aws.String(message.MessageDeduplicationId()),
+			},
+			IgnoreFields: []string{"MessageAttributes"},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("1234"),
+			},
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName: "test-topic.fifo",
+			TopicArn:  "arn:aws:sns:us-east-1:123456789012:test-topic.fifo",
+			IsFifo:    true,
+			Client:    sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		// Act
+		resp, err := service.PublishMessage(ctx, message)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", *resp)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should fallback to the topic name as group id when message has no metadata", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn:       aws.String("arn:aws:sns:us-east-1:123456789012:test-topic.fifo"),
+				Message:        aws.String(`{"message":"test"}`),
+				MessageGroupId: aws.String("test-topic.fifo"),
+			},
+			IgnoreFields: []string{"MessageDeduplicationId"},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("1234"),
+			},
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName: "test-topic.fifo",
+			TopicArn:  "arn:aws:sns:us-east-1:123456789012:test-topic.fifo",
+			IsFifo:    true,
+			Client:    sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		message := map[string]string{"message": "test"}
+
+		// Act
+		resp, err := service.PublishMessage(ctx, message)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", *resp)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should publish the legacy contract and the cloud event when format is both", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+		event := NewOrderEvent(&order, NewServiceActor(QueueActorId))
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+				Message:  aws.String(`{"order_id":"c3fdab1b-3c06-4db2-9edc-4760a2429462","order":{"state":"Received"}}`),
+			},
+			IgnoreFields: []string{"MessageAttributes"},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("1234"),
+			},
+		})
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+			},
+			IgnoreFields: []string{"Message", "MessageAttributes"},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("5678"),
+			},
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName:   "test-topic",
+			TopicArn:    "arn:aws:sns:us-east-1:123456789012:test-topic",
+			EventFormat: BothEventFormat,
+			Client:      sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		// Act
+		resp, err := service.PublishMessage(ctx, event)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", *resp)
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should publish only the cloud event when format is cloudevents", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+		event := NewOrderEvent(&order, NewServiceActor(QueueActorId))
+
+		stubber.Add(testtools.Stub{
+			OperationName: "Publish",
+			Input: &sns.PublishInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+				MessageAttributes: map[string]types.MessageAttributeValue{
+					"event_format": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String(CloudEventsEventFormat),
+					},
+					"event_type": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String(OrderCreatedEventType),
+					},
+					"order_state": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String("Received"),
+					},
+					"schema_version": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String(OrderEventVersion),
+					},
+				},
+			},
+			IgnoreFields: []string{"Message"},
+			Output: &sns.PublishOutput{
+				MessageId: aws.String("5678"),
+			},
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName:   "test-topic",
+			TopicArn:    "arn:aws:sns:us-east-1:123456789012:test-topic",
+			EventFormat: CloudEventsEventFormat,
+			Client:      sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		// Act
+		resp, err := service.PublishMessage(ctx, event)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "5678", *resp)
+		testtools.ExitTest(stubber, t)
+	})
+}
+
+func TestUpdateOrderPublishBatch(t *testing.T) {
+	t.Run("Should publish the messages in batches of ten", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		messages := make([]interface{}, 12)
+		entries := make([]types.PublishBatchRequestEntry, 12)
+		for i := range messages {
+			messages[i] = map[string]int{"n": i}
+			entries[i] = types.PublishBatchRequestEntry{
+				Id:      aws.String(strconv.Itoa(i)),
+				Message: aws.String(fmt.Sprintf(`{"n":%d}`, i)),
+			}
+		}
+
+		successful := func(from, to int) []types.PublishBatchResultEntry {
+			var res []types.PublishBatchResultEntry
+			for i := from; i < to; i++ {
+				res = append(res, types.PublishBatchResultEntry{
+					Id:        aws.String(strconv.Itoa(i)),
+					MessageId: aws.String(fmt.Sprintf("message-%d", i)),
+				})
+			}
+			return res
+		}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "PublishBatch",
+			Input: &sns.PublishBatchInput{
+				TopicArn:                   aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+				PublishBatchRequestEntries: entries[:10],
+			},
+			Output: &sns.PublishBatchOutput{
+				Successful: successful(0, 10),
+			},
+		})
+		stubber.Add(testtools.Stub{
+			OperationName: "PublishBatch",
+			Input: &sns.PublishBatchInput{
+				TopicArn:                   aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+				PublishBatchRequestEntries: entries[10:],
+			},
+			Output: &sns.PublishBatchOutput{
+				Successful: successful(10, 12),
+			},
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName:   "test-topic",
+			TopicArn:    "arn:aws:sns:us-east-1:123456789012:test-topic",
+			EventFormat: LegacyEventFormat,
+			Client:      sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		// Act
+		resp, err := service.PublishBatch(ctx, messages)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, resp, 12)
+		assert.Equal(t, "message-0", *resp[0])
+		assert.Equal(t, "message-11", *resp[11])
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return the failed entries", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		first := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+		second := order_entity.NewOrder("0a1b2c3d-3c06-4db2-9edc-4760a2429462", time.Now())
+
+		stubber.Add(testtools.Stub{
+			OperationName: "PublishBatch",
+			Input: &sns.PublishBatchInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+			},
+			IgnoreFields: []string{"PublishBatchRequestEntries"},
+			Output: &sns.PublishBatchOutput{
+				Successful: []types.PublishBatchResultEntry{
+					{Id: aws.String("0"), MessageId: aws.String("1234")},
+					{Id: aws.String("1"), MessageId: aws.String("5678")},
+				},
+				Failed: []types.BatchResultErrorEntry{
+					{Id: aws.String("2"), Code: aws.String("InternalError"), Message: aws.String("failed")},
+					{Id: aws.String("3"), Code: aws.String("InternalError"), Message: aws.String("failed")},
+				},
+			},
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName:   "test-topic",
+			TopicArn:    "arn:aws:sns:us-east-1:123456789012:test-topic",
+			EventFormat: BothEventFormat,
+			Client:      sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		// Act
+		resp, err := service.PublishBatch(ctx, []interface{}{
+			NewOrderEvent(&first, NewServiceActor(QueueActorId)),
+			NewOrderEvent(&second, NewServiceActor(QueueActorId)),
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, "1234", *resp[0])
+		assert.Nil(t, resp[1])
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should return error when the batch is not published", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		raiseErr := &testtools.StubError{Err: errors.New("ClientError")}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "PublishBatch",
+			Input: &sns.PublishBatchInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+			},
+			IgnoreFields: []string{"PublishBatchRequestEntries"},
+			Error:        raiseErr,
+		})
+
+		service := &UpdateOrderTopicService{
+			TopicName:   "test-topic",
+			TopicArn:    "arn:aws:sns:us-east-1:123456789012:test-topic",
+			EventFormat: LegacyEventFormat,
+			Client:      sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		// Act
+		resp, err := service.PublishBatch(ctx, []interface{}{map[string]string{"message": "test"}})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, resp[0])
+		testtools.ExitTest(stubber, t)
+	})
+
+	t.Run("Should publish the batch with group and deduplication ids when topic is fifo", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		stubber := testtools.NewStubber()
+
+		order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+		event := NewOrderEvent(&order, NewServiceActor(QueueActorId))
+		contract := NewUpdateOrderContract(event.Order)
+
+		body, err := json.Marshal(contract)
+		assert.NoError(t, err)
+
+		service := &UpdateOrderTopicService{
+			TopicName:   "test-topic.fifo",
+			TopicArn:    "arn:aws:sns:us-east-1:123456789012:test-topic.fifo",
+			IsFifo:      true,
+			EventFormat: LegacyEventFormat,
+			Client:      sns.NewFromConfig(*stubber.SdkConfig),
+		}
+
+		stubber.Add(testtools.Stub{
+			OperationName: "PublishBatch",
+			Input: &sns.PublishBatchInput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic.fifo"),
+				PublishBatchRequestEntries: []types.PublishBatchRequestEntry{
+					{
+						Id:                     aws.String("0"),
+						Message:                aws.String(string(body)),
+						MessageAttributes:      service.buildAttributes(ctx, contract),
+						MessageGroupId:         aws.String(contract.MessageGroupId()),
+						MessageDeduplicationId: aws.String(contract.MessageDeduplicationId()),
+					},
+				},
+			},
+			Output: &sns.PublishBatchOutput{
+				Successful: []types.PublishBatchResultEntry{
+					{Id: aws.String("0"), MessageId: aws.String("1234")},
+				},
+			},
+		})
+
+		// Act
+		resp, err := service.PublishBatch(ctx, []interface{}{event})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", *resp[0])
+		testtools.ExitTest(stubber, t)
+	})
 }
@@ -0,0 +1,93 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TopicNotificationAttribute is a message attribute as SNS writes it in the
+// notifications delivered to the queues
+type TopicNotificationAttribute struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+// TopicNotificationAttributes carries the trace context of the publisher of
+// the notification
+type TopicNotificationAttributes map[string]TopicNotificationAttribute
+
+func (attributes TopicNotificationAttributes) Get(key string) string {
+	return attributes[key].Value
+}
+
+func (attributes TopicNotificationAttributes) Set(key string, value string) {
+	attributes[key] = TopicNotificationAttribute{Type: "String", Value: value}
+}
+
+func (attributes TopicNotificationAttributes) Keys() []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// QueueMessageAttributes carries the trace context of the messages sent
+// straight to the queue, or delivered by SNS with raw message delivery
+type QueueMessageAttributes map[string]types.MessageAttributeValue
+
+func (attributes QueueMessageAttributes) Get(key string) string {
+	return aws.ToString(attributes[key].StringValue)
+}
+
+func (attributes QueueMessageAttributes) Set(key string, value string) {
+	attributes[key] = types.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+}
+
+func (attributes QueueMessageAttributes) Keys() []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// ExtractMessageContext continues the trace of the publisher of the message,
+// read from the attributes of the message or, when absent, from the ones of
+// the SNS notification in its body
+func ExtractMessageContext(ctx context.Context, message types.Message) context.Context {
+	propagator := otel.GetTextMapPropagator()
+
+	extracted := propagator.Extract(ctx, QueueMessageAttributes(message.MessageAttributes))
+	if trace.SpanContextFromContext(extracted).IsRemote() {
+		return extracted
+	}
+
+	notification, ok := decodeNotification(message)
+	if !ok {
+		return ctx
+	}
+
+	return propagator.Extract(ctx, notification.MessageAttributes)
+}
+
+// decodeNotification decodes the SNS notification in the body of the message
+// without validating it, only its metadata is read
+func decodeNotification(message types.Message) (TopicNotification, bool) {
+	var notification TopicNotification
+	if err := json.Unmarshal([]byte(aws.ToString(message.Body)), &notification); err != nil {
+		return notification, false
+	}
+
+	return notification, true
+}
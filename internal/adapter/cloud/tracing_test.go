@@ -0,0 +1,102 @@
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// useTraceContext propagates the W3C trace context during the test
+func useTraceContext(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTextMapPropagator(previous)
+	})
+}
+
+func TestExtractMessageContext(t *testing.T) {
+	t.Run("Should continue the trace of the message attributes", func(t *testing.T) {
+		// Arrange
+		useTraceContext(t)
+
+		message := types.Message{
+			Body: aws.String(`{"Type":"Notification"}`),
+			MessageAttributes: map[string]types.MessageAttributeValue{
+				"traceparent": {
+					DataType:    aws.String("String"),
+					StringValue: aws.String("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+				},
+			},
+		}
+
+		// Act
+		ctx := ExtractMessageContext(context.Background(), message)
+
+		// Assert
+		spanContext := trace.SpanContextFromContext(ctx)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanContext.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", spanContext.SpanID().String())
+	})
+
+	t.Run("Should continue the trace of the notification attributes", func(t *testing.T) {
+		// Arrange
+		useTraceContext(t)
+
+		message := types.Message{
+			Body: aws.String(`{
+				"Type": "Notification",
+				"MessageAttributes": {
+					"traceparent": {"Type": "String", "Value": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+				}
+			}`),
+		}
+
+		// Act
+		ctx := ExtractMessageContext(context.Background(), message)
+
+		// Assert
+		spanContext := trace.SpanContextFromContext(ctx)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanContext.TraceID().String())
+		assert.True(t, spanContext.IsRemote())
+	})
+
+	t.Run("Should return the same context when the message has no trace", func(t *testing.T) {
+		// Arrange
+		useTraceContext(t)
+
+		ctx := context.Background()
+
+		message := types.Message{
+			Body: aws.String(`invalid`),
+		}
+
+		// Act
+		res := ExtractMessageContext(ctx, message)
+
+		// Assert
+		assert.False(t, trace.SpanContextFromContext(res).IsValid())
+	})
+}
+
+func TestQueueMessageAttributes(t *testing.T) {
+	t.Run("Should write the values as string attributes", func(t *testing.T) {
+		// Arrange
+		attributes := QueueMessageAttributes{}
+
+		// Act
+		attributes.Set("traceparent", "value")
+
+		// Assert
+		assert.Equal(t, "value", attributes.Get("traceparent"))
+		assert.Equal(t, "String", *attributes["traceparent"].DataType)
+		assert.Equal(t, []string{"traceparent"}, attributes.Keys())
+	})
+}
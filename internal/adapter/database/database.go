@@ -5,12 +5,13 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
-	"time"
 
 	_ "github.com/lib/pq"
 
+	"github.com/XSAM/otelsql"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
 type DatabaseService interface {
@@ -23,7 +24,11 @@ type Service struct {
 }
 
 func NewDatabase(config *environment.Config) DatabaseService {
-	client, err := sql.Open("postgres", config.DbConfig.Url)
+	// every query is traced as a child of the span of the request or message
+	client, err := otelsql.Open("postgres", config.DbConfig.Url,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
 	if err != nil {
 		panic(fmt.Errorf("error on connect to database: %v", err))
 	}
@@ -37,19 +42,11 @@ func (s *Service) GetInstance() *sql.DB {
 	return s.Client
 }
 
-func (s *Service) Health() *health.HealthStatus {
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancel()
-
+func (s *Service) Health(ctx context.Context) *health.HealthStatus {
 	if err := s.Client.PingContext(ctx); err != nil {
-		slog.Error("could not ping the database", "error", err)
-		return &health.HealthStatus{
-			Status: "unhealthy",
-			Err:    err.Error(),
-		}
+		slog.ErrorContext(ctx, "could not ping the database", "error", err)
+		return health.NewHealthStatus(err)
 	}
 
-	return &health.HealthStatus{
-		Status: "healthy",
-	}
+	return health.NewHealthStatus(nil)
 }
@@ -1,7 +1,9 @@
 package database
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
@@ -53,7 +55,7 @@ func TestHealth(t *testing.T) {
 		service.(*Service).Client = db
 
 		// Act
-		res := service.Health()
+		res := service.Health(context.Background())
 
 		// Assert
 		assert.NotNil(mt, res)
@@ -76,8 +78,11 @@ func TestHealth(t *testing.T) {
 
 		service := NewDatabase(config)
 
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
 		// Act
-		res := service.Health()
+		res := service.Health(ctx)
 
 		// Assert
 		assert.NotNil(mt, res)
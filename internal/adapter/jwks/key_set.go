@@ -0,0 +1,263 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"golang.org/x/sync/singleflight"
+)
+
+// unknownKeyCooldown limits how often the keys are fetched when the id is
+// unknown or the last fetch failed, so tokens with made up ids do not flood
+// the identity provider
+const unknownKeyCooldown = time.Minute
+
+// fetchTimeout bounds the fetch, it is not cancelled with the request that
+// started it
+const fetchTimeout = 10 * time.Second
+
+var ErrKeyNotFound = errors.New("key not found")
+
+// KeySet caches the public keys published by the identity provider, the keys
+// are fetched again when they expire or a token is signed by an unknown key,
+// so rotated keys are trusted without a restart
+type KeySet struct {
+	url             string
+	refreshInterval time.Duration
+	timeProvider    provider.TimeProvider
+	client          *http.Client
+
+	// the concurrent requests share a single fetch, which runs without the
+	// mutex so the requests with known keys are not blocked by it
+	group singleflight.Group
+
+	mutex     sync.Mutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+	failedAt  time.Time
+}
+
+func NewKeySet(url string, refreshInterval time.Duration, timeProvider provider.TimeProvider) *KeySet {
+	return &KeySet{
+		url:             url,
+		refreshInterval: refreshInterval,
+		timeProvider:    timeProvider,
+		client:          &http.Client{},
+		keys:            make(map[string]interface{}),
+	}
+}
+
+// Key returns the public key (*rsa.PublicKey or *ecdsa.PublicKey) with the
+// id, a token without id uses the only key of the set. A cancelled request
+// stops waiting for the fetch, which goes on for the other requests
+func (s *KeySet) Key(ctx context.Context, kid string) (interface{}, error) {
+	if s.shouldFetch(kid) {
+		fetched := s.group.DoChan("keys", func() (interface{}, error) {
+			return nil, s.refresh(context.WithoutCancel(ctx))
+		})
+
+		select {
+		case <-fetched:
+		case <-ctx.Done():
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key, ok := s.lookup(kid)
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+// shouldFetch reports if the keys expired or the id is unknown. A failed
+// fetch waits the cooldown before trying again, so an unavailable provider is
+// not called by every request, and so does an unknown id after a fetch
+func (s *KeySet) shouldFetch(kid string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.timeProvider.GetTime()
+
+	if now.Sub(s.failedAt) < unknownKeyCooldown {
+		return false
+	}
+
+	if now.Sub(s.fetchedAt) >= s.refreshInterval {
+		return true
+	}
+
+	_, known := s.lookup(kid)
+
+	return !known && now.Sub(s.fetchedAt) >= unknownKeyCooldown
+}
+
+func (s *KeySet) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	keys, err := s.fetch(ctx)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.timeProvider.GetTime()
+
+	if err != nil {
+		// the cached keys are still used while the provider is unavailable
+		slog.ErrorContext(ctx, "error fetching the json web keys", "url", s.url, "error", err)
+		s.failedAt = now
+		return err
+	}
+
+	s.keys = keys
+	s.fetchedAt = now
+
+	return nil
+}
+
+func (s *KeySet) lookup(kid string) (interface{}, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *KeySet) fetch(ctx context.Context) (map[string]interface{}, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", response.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			slog.WarnContext(ctx, "ignoring json web key", "kid", jwk.Kid, "error", err)
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// jsonWebKey is a public key as described by RFC 7517
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() {
+			return nil, errors.New("invalid rsa exponent")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+}
+
+func decodeInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, errors.New("missing key parameter")
+	}
+
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(bytes), nil
+}
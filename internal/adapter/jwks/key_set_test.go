@@ -0,0 +1,325 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider/time_provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func rsaJwk(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   encodeInt(key.N),
+		"e":   encodeInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func ecJwk(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   encodeInt(key.X),
+		"y":   encodeInt(key.Y),
+	}
+}
+
+// jwksServer serves the keys returned by keys and counts the requests
+func jwksServer(t *testing.T, keys func() []map[string]string) (*httptest.Server, *atomic.Int32) {
+	requests := &atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys()})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func TestKeySet(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	t.Run("Should return the RSA and ECDSA keys", func(t *testing.T) {
+		// Arrange
+		server, _ := jwksServer(t, func() []map[string]string {
+			return []map[string]string{
+				rsaJwk("rsa-1", &rsaKey.PublicKey),
+				ecJwk("ec-1", &ecKey.PublicKey),
+				{"kty": "oct", "kid": "secret", "k": "c2VjcmV0"},
+			}
+		})
+
+		keySet := NewKeySet(server.URL, time.Hour, time_provider.NewTimeProvider(time.Now))
+
+		// Act
+		rsaRes, rsaErr := keySet.Key(context.Background(), "rsa-1")
+		ecRes, ecErr := keySet.Key(context.Background(), "ec-1")
+		_, octErr := keySet.Key(context.Background(), "secret")
+
+		// Assert
+		assert.NoError(t, rsaErr)
+		assert.True(t, rsaKey.PublicKey.Equal(rsaRes))
+		assert.NoError(t, ecErr)
+		assert.True(t, ecKey.PublicKey.Equal(ecRes))
+		assert.ErrorIs(t, octErr, ErrKeyNotFound)
+	})
+
+	t.Run("Should cache the keys until the refresh interval", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		server, requests := jwksServer(t, func() []map[string]string {
+			return []map[string]string{rsaJwk("rsa-1", &rsaKey.PublicKey)}
+		})
+
+		keySet := NewKeySet(server.URL, time.Hour, time_provider.NewTimeProvider(func() time.Time {
+			return now
+		}))
+
+		// Act
+		_, err := keySet.Key(context.Background(), "rsa-1")
+		assert.NoError(t, err)
+
+		now = now.Add(30 * time.Minute)
+		_, err = keySet.Key(context.Background(), "rsa-1")
+		assert.NoError(t, err)
+
+		now = now.Add(31 * time.Minute)
+		_, err = keySet.Key(context.Background(), "rsa-1")
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("Should fetch the keys again when the key was rotated", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		kid := "rsa-1"
+
+		server, requests := jwksServer(t, func() []map[string]string {
+			return []map[string]string{rsaJwk(kid, &rsaKey.PublicKey)}
+		})
+
+		keySet := NewKeySet(server.URL, time.Hour, time_provider.NewTimeProvider(func() time.Time {
+			return now
+		}))
+
+		_, err := keySet.Key(context.Background(), "rsa-1")
+		assert.NoError(t, err)
+
+		kid = "rsa-2"
+		now = now.Add(2 * time.Minute)
+
+		// Act
+		res, err := keySet.Key(context.Background(), "rsa-2")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, rsaKey.PublicKey.Equal(res))
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("Should not fetch again for unknown keys during the cooldown", func(t *testing.T) {
+		// Arrange
+		server, requests := jwksServer(t, func() []map[string]string {
+			return []map[string]string{rsaJwk("rsa-1", &rsaKey.PublicKey)}
+		})
+
+		keySet := NewKeySet(server.URL, time.Hour, time_provider.NewTimeProvider(time.Now))
+
+		// Act
+		_, first := keySet.Key(context.Background(), "unknown")
+		_, second := keySet.Key(context.Background(), "unknown")
+
+		// Assert
+		assert.ErrorIs(t, first, ErrKeyNotFound)
+		assert.ErrorIs(t, second, ErrKeyNotFound)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("Should keep the cached keys when the provider fails", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		fail := false
+
+		requests := &atomic.Int32{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			if fail {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{rsaJwk("rsa-1", &rsaKey.PublicKey)}})
+		}))
+		defer server.Close()
+
+		keySet := NewKeySet(server.URL, time.Hour, time_provider.NewTimeProvider(func() time.Time {
+			return now
+		}))
+
+		_, err := keySet.Key(context.Background(), "rsa-1")
+		assert.NoError(t, err)
+
+		fail = true
+		now = now.Add(2 * time.Hour)
+
+		// Act
+		res, err := keySet.Key(context.Background(), "rsa-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, rsaKey.PublicKey.Equal(res))
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("Should use the only key when the token has no key id", func(t *testing.T) {
+		// Arrange
+		server, _ := jwksServer(t, func() []map[string]string {
+			return []map[string]string{ecJwk("ec-1", &ecKey.PublicKey)}
+		})
+
+		keySet := NewKeySet(server.URL, time.Hour, time_provider.NewTimeProvider(time.Now))
+
+		// Act
+		res, err := keySet.Key(context.Background(), "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, ecKey.PublicKey.Equal(res))
+	})
+
+	t.Run("Should share a single fetch between concurrent requests", func(t *testing.T) {
+		// Arrange
+		release := make(chan struct{})
+
+		requests := &atomic.Int32{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			<-release
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{rsaJwk("rsa-1", &rsaKey.PublicKey)}})
+		}))
+		defer server.Close()
+
+		keySet := NewKeySet(server.URL, time.Hour, time_provider.NewTimeProvider(time.Now))
+
+		errs := make(chan error, 10)
+
+		var wg sync.WaitGroup
+
+		// Act
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := keySet.Key(context.Background(), "rsa-1")
+				errs <- err
+			}()
+		}
+
+		assert.Eventually(t, func() bool { return requests.Load() == 1 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+		close(errs)
+
+		// Assert
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("Should keep fetching when the request that started the fetch is cancelled", func(t *testing.T) {
+		// Arrange
+		release := make(chan struct{})
+
+		requests := &atomic.Int32{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			<-release
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{rsaJwk("rsa-1", &rsaKey.PublicKey)}})
+		}))
+		defer server.Close()
+
+		keySet := NewKeySet(server.URL, time.Hour, time_provider.NewTimeProvider(time.Now))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := keySet.Key(ctx, "rsa-1")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+
+		close(release)
+
+		// Act
+		res, err := keySet.Key(context.Background(), "rsa-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, rsaKey.PublicKey.Equal(res))
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("Should wait the cooldown after the provider failed", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		fail := true
+
+		requests := &atomic.Int32{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			if fail {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{rsaJwk("rsa-1", &rsaKey.PublicKey)}})
+		}))
+		defer server.Close()
+
+		keySet := NewKeySet(server.URL, time.Hour, time_provider.NewTimeProvider(func() time.Time {
+			return now
+		}))
+
+		_, first := keySet.Key(context.Background(), "rsa-1")
+
+		fail = false
+
+		// Act
+		_, second := keySet.Key(context.Background(), "rsa-1")
+
+		now = now.Add(2 * time.Minute)
+		res, third := keySet.Key(context.Background(), "rsa-1")
+
+		// Assert
+		assert.ErrorIs(t, first, ErrKeyNotFound)
+		assert.ErrorIs(t, second, ErrKeyNotFound)
+		assert.NoError(t, third)
+		assert.True(t, rsaKey.PublicKey.Equal(res))
+		assert.Equal(t, int32(2), requests.Load())
+	})
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectTimeout limits the queries made while scraping, a slow database or
+// queue must not block the endpoint
+const collectTimeout = 5 * time.Second
+
+type StateCounter interface {
+	CountByState(ctx context.Context) (map[order_entity.OrderState]int, error)
+}
+
+// OrderStateCollector reports the current number of orders at each state, read
+// from the database on every scrape so every replica reports the same value
+type OrderStateCollector struct {
+	counter StateCounter
+	desc    *prometheus.Desc
+}
+
+func NewOrderStateCollector(counter StateCounter) *OrderStateCollector {
+	return &OrderStateCollector{
+		counter: counter,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "orders", "current"),
+			"Orders at each state.",
+			[]string{"state"},
+			nil,
+		),
+	}
+}
+
+func (c *OrderStateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *OrderStateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	counts, err := c.counter.CountByState(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error counting the orders by state", "error", err)
+		return
+	}
+
+	for state := order_entity.Received; state <= order_entity.Cancelled; state++ {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(counts[state]), state.String())
+	}
+}
+
+type DepthReader interface {
+	GetQueueName() string
+	GetQueueDepth(ctx context.Context) (int, error)
+}
+
+// QueueDepthCollector reports the approximate number of messages waiting in the
+// queue, to scale the replicas on the backlog
+type QueueDepthCollector struct {
+	reader DepthReader
+	desc   *prometheus.Desc
+}
+
+func NewQueueDepthCollector(reader DepthReader) *QueueDepthCollector {
+	return &QueueDepthCollector{
+		reader: reader,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "queue", "depth"),
+			"Approximate number of messages visible in the queue.",
+			[]string{"queue"},
+			nil,
+		),
+	}
+}
+
+func (c *QueueDepthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *QueueDepthCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	depth, err := c.reader.GetQueueDepth(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error reading the depth of the queue", "queue_name", c.reader.GetQueueName(), "error", err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(depth), c.reader.GetQueueName())
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	cloud_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOrderStateCollector(t *testing.T) {
+	t.Run("Should report every state, including the ones without orders", func(t *testing.T) {
+		// Arrange
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+
+		repository.On("CountByState", mock.Anything).
+			Return(map[order_entity.OrderState]int{
+				order_entity.Received:   4,
+				order_entity.Processing: 2,
+			}, nil).
+			Once()
+
+		collector := NewOrderStateCollector(repository)
+
+		expected := `
+# HELP production_orders_current Orders at each state.
+# TYPE production_orders_current gauge
+production_orders_current{state="Cancelled"} 0
+production_orders_current{state="Completed"} 0
+production_orders_current{state="Delivered"} 0
+production_orders_current{state="Processing"} 2
+production_orders_current{state="Received"} 4
+`
+
+		// Act
+		err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should report nothing when the orders can not be counted", func(t *testing.T) {
+		// Arrange
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+
+		repository.On("CountByState", mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		collector := NewOrderStateCollector(repository)
+
+		// Act
+		count := testutil.CollectAndCount(collector)
+
+		// Assert
+		assert.Zero(t, count)
+		repository.AssertExpectations(t)
+	})
+}
+
+func TestQueueDepthCollector(t *testing.T) {
+	t.Run("Should report the depth of the queue", func(t *testing.T) {
+		// Arrange
+		queue := cloud_mocks.NewMockQueueService(t)
+
+		queue.On("GetQueueDepth", mock.Anything).
+			Return(12, nil).
+			Once()
+
+		queue.On("GetQueueName").
+			Return("test-queue")
+
+		collector := NewQueueDepthCollector(queue)
+
+		expected := `
+# HELP production_queue_depth Approximate number of messages visible in the queue.
+# TYPE production_queue_depth gauge
+production_queue_depth{queue="test-queue"} 12
+`
+
+		// Act
+		err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+
+		// Assert
+		assert.NoError(t, err)
+		queue.AssertExpectations(t)
+	})
+
+	t.Run("Should report nothing when the depth can not be read", func(t *testing.T) {
+		// Arrange
+		queue := cloud_mocks.NewMockQueueService(t)
+
+		queue.On("GetQueueDepth", mock.Anything).
+			Return(0, assert.AnError).
+			Once()
+
+		queue.On("GetQueueName").
+			Return("test-queue")
+
+		collector := NewQueueDepthCollector(queue)
+
+		// Act
+		count := testutil.CollectAndCount(collector)
+
+		// Assert
+		assert.Zero(t, count)
+		queue.AssertExpectations(t)
+	})
+}
+
+func TestNewRegistry(t *testing.T) {
+	t.Run("Should register the collectors of every server", func(t *testing.T) {
+		// Arrange
+		// Act
+		first := NewRegistry()
+		second := NewRegistry()
+
+		// Assert
+		_, err := first.Gather()
+		assert.NoError(t, err)
+		_, err = second.Gather()
+		assert.NoError(t, err)
+	})
+}
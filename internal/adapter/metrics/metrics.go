@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "production"
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the HTTP requests by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	queueMessagesReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "queue",
+		Name:      "messages_received_total",
+		Help:      "Messages received from the order production queue.",
+	})
+
+	queueMessagesProcessed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "queue",
+		Name:      "messages_processed_total",
+		Help:      "Messages of the order production queue processed successfully.",
+	})
+
+	queueMessagesFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "queue",
+		Name:      "messages_failed_total",
+		Help:      "Messages of the order production queue that could not be processed.",
+	})
+
+	queueProcessingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "queue",
+		Name:      "message_processing_duration_seconds",
+		Help:      "Time spent processing the messages of the order production queue by result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	snsPublishFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sns",
+		Name:      "publish_failures_total",
+		Help:      "Messages that could not be published to the topic.",
+	}, []string{"topic"})
+
+	orderStateDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "orders",
+		Name:      "state_duration_seconds",
+		Help:      "Time the orders spent in a state before moving to the next one.",
+		Buckets:   []float64{30, 60, 120, 300, 600, 900, 1800, 3600, 7200, 14400},
+	}, []string{"state"})
+)
+
+// ObserveHttpRequest records a request, the route is the template of the path
+// so the ids do not create new series
+func ObserveHttpRequest(method string, route string, status int, duration time.Duration) {
+	labels := prometheus.Labels{
+		"method": method,
+		"route":  route,
+		"status": strconv.Itoa(status),
+	}
+
+	httpRequests.With(labels).Inc()
+	httpRequestDuration.With(labels).Observe(duration.Seconds())
+}
+
+func QueueMessagesReceived(count int) {
+	queueMessagesReceived.Add(float64(count))
+}
+
+// QueueMessageProcessed records the result of a message, a failed message was
+// sent to the dead letter queue when there is one
+func QueueMessageProcessed(duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+		queueMessagesFailed.Inc()
+	} else {
+		queueMessagesProcessed.Inc()
+	}
+
+	queueProcessingDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+func SnsPublishFailed(topic string, count int) {
+	snsPublishFailures.WithLabelValues(topic).Add(float64(count))
+}
+
+// ObserveStateDuration records the time an order spent in the state it left,
+// the orders without the time they entered the state are ignored
+func ObserveStateDuration(state string, enteredAt time.Time, leftAt time.Time) {
+	if enteredAt.IsZero() || leftAt.Before(enteredAt) {
+		return
+	}
+
+	orderStateDuration.WithLabelValues(state).Observe(leftAt.Sub(enteredAt).Seconds())
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveHttpRequest(t *testing.T) {
+	t.Run("Should count the request by method, route and status", func(t *testing.T) {
+		// Arrange
+		counter := httpRequests.WithLabelValues(http.MethodGet, "/test/:id", "200")
+		before := testutil.ToFloat64(counter)
+
+		// Act
+		ObserveHttpRequest(http.MethodGet, "/test/:id", http.StatusOK, time.Millisecond)
+
+		// Assert
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+}
+
+func TestQueueMessageProcessed(t *testing.T) {
+	t.Run("Should count the processed and the failed messages", func(t *testing.T) {
+		// Arrange
+		processed := testutil.ToFloat64(queueMessagesProcessed)
+		failed := testutil.ToFloat64(queueMessagesFailed)
+
+		// Act
+		QueueMessageProcessed(time.Millisecond, nil)
+		QueueMessageProcessed(time.Millisecond, assert.AnError)
+		QueueMessageProcessed(time.Millisecond, assert.AnError)
+
+		// Assert
+		assert.Equal(t, processed+1, testutil.ToFloat64(queueMessagesProcessed))
+		assert.Equal(t, failed+2, testutil.ToFloat64(queueMessagesFailed))
+	})
+}
+
+func TestSnsPublishFailed(t *testing.T) {
+	t.Run("Should count the failures by topic", func(t *testing.T) {
+		// Arrange
+		counter := snsPublishFailures.WithLabelValues("test-topic")
+		before := testutil.ToFloat64(counter)
+
+		// Act
+		SnsPublishFailed("test-topic", 3)
+
+		// Assert
+		assert.Equal(t, before+3, testutil.ToFloat64(counter))
+	})
+}
+
+func TestObserveStateDuration(t *testing.T) {
+	t.Run("Should observe the time spent in the state", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		before := testutil.CollectAndCount(orderStateDuration)
+
+		// Act
+		ObserveStateDuration("TestState", now.Add(-time.Minute), now)
+
+		// Assert
+		assert.Equal(t, before+1, testutil.CollectAndCount(orderStateDuration))
+	})
+
+	t.Run("Should ignore the orders without the time they entered the state", func(t *testing.T) {
+		// Arrange
+		before := testutil.CollectAndCount(orderStateDuration)
+
+		// Act
+		ObserveStateDuration("IgnoredState", time.Time{}, time.Now())
+
+		// Assert
+		assert.Equal(t, before, testutil.CollectAndCount(orderStateDuration))
+	})
+}
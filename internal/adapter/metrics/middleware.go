@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute groups the requests to unknown paths, the path itself would
+// create a series per request
+const unmatchedRoute = "unmatched"
+
+// Middleware records every request, it must be the first middleware so the
+// status is the one set by the error handler
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			ObserveHttpRequest(c.Request().Method, route, status(c, err), time.Since(start))
+
+			return err
+		}
+	}
+}
+
+// status returns the status of the response, or the one of the error when it
+// was not handled yet
+func status(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Should record the request with the template of the route", func(t *testing.T) {
+		// Arrange
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/orders/:id", func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		counter := httpRequests.WithLabelValues(http.MethodGet, "/orders/:id", "204")
+		before := testutil.ToFloat64(counter)
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/123", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+
+	t.Run("Should record the status of the error not handled yet", func(t *testing.T) {
+		// Arrange
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/orders/:id", func(c echo.Context) error {
+			return echo.NewHTTPError(http.StatusNotFound)
+		})
+
+		counter := httpRequests.WithLabelValues(http.MethodGet, "/orders/:id", "404")
+		before := testutil.ToFloat64(counter)
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/123", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+
+	t.Run("Should group the unknown routes", func(t *testing.T) {
+		// Arrange
+		e := echo.New()
+		e.Use(Middleware())
+
+		counter := httpRequests.WithLabelValues(http.MethodGet, unmatchedRoute, "404")
+		before := testutil.ToFloat64(counter)
+
+		req := httptest.NewRequest(http.MethodGet, "/unknown/123", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+}
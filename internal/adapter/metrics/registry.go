@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewRegistry returns the registry with the metrics of the service and of the
+// Go runtime, along with the collectors of the server (database pool, orders,
+// queue)
+func NewRegistry(extra ...prometheus.Collector) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+
+		httpRequests,
+		httpRequestDuration,
+		queueMessagesReceived,
+		queueMessagesProcessed,
+		queueMessagesFailed,
+		queueProcessingDuration,
+		snsPublishFailures,
+		orderStateDuration,
+	)
+
+	registry.MustRegister(extra...)
+
+	return registry
+}
+
+func Handler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,81 @@
+package printer
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/ticket"
+)
+
+// AutoPrintService creates the orders with the wrapped service and prints
+// their ticket in background, a printer failure never rejects the order
+type AutoPrintService struct {
+	service.CreateOrderProductionService[create.CreateOrderProductionInput]
+
+	printer Printer
+	storeId string
+	width   int
+
+	wg sync.WaitGroup
+}
+
+func NewAutoPrintService(
+	createService service.CreateOrderProductionService[create.CreateOrderProductionInput],
+	printer Printer,
+	storeId string,
+	width int,
+) *AutoPrintService {
+	return &AutoPrintService{
+		CreateOrderProductionService: createService,
+		printer:                      printer,
+		storeId:                      storeId,
+		width:                        width,
+	}
+}
+
+// Handle prints the orders received through the queue, the manual orders are
+// created at the counter and the reconciled ones were already printed
+func (s *AutoPrintService) Handle(ctx context.Context, request create.CreateOrderProductionInput) (*order_entity.Order, error) {
+	order, err := s.CreateOrderProductionService.Handle(ctx, request)
+	if err != nil || order == nil || request.DryRun || request.IsManual() {
+		return order, err
+	}
+
+	job := ticket.EscPos(ticket.New(*order, s.storeId), s.width)
+	ctx = context.WithoutCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		if err := s.printer.Print(ctx, job); err != nil {
+			slog.ErrorContext(ctx, "error printing the order ticket", "order_id", order.Id, "error", err)
+			return
+		}
+
+		slog.InfoContext(ctx, "order ticket printed", "order_id", order.Id)
+	}()
+
+	return order, nil
+}
+
+// Wait blocks until the pending tickets are printed or the context is done
+func (s *AutoPrintService) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,158 @@
+package printer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	printer_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/printer/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAutoPrintService(t *testing.T) {
+	order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+	order.Items = []order_entity.Item{
+		order_entity.NewItem("0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", "Hamburger", 1),
+	}
+
+	t.Run("Should print the ticket of the orders received through the queue", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		fake, err := NewFakePrinter("127.0.0.1:0")
+		assert.NoError(t, err)
+		defer fake.Close()
+
+		createService := mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+
+		createService.On("Handle", ctx, mock.Anything).
+			Return(&order, nil).
+			Once()
+
+		service := NewAutoPrintService(createService, NewNetworkPrinter(fake.Address(), time.Second), "store-01", 42)
+
+		// Act
+		res, err := service.Handle(ctx, create.CreateOrderProductionInput{OrderId: order.Id})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, &order, res)
+		assert.NoError(t, service.Wait(ctx))
+		assert.Eventually(t, func() bool {
+			return len(fake.Jobs()) == 1
+		}, time.Second, 10*time.Millisecond)
+		assert.True(t, bytes.Contains(fake.Jobs()[0], []byte("C3FDAB")))
+		createService.AssertExpectations(t)
+	})
+
+	t.Run("Should not print the manual orders", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		printer := printer_mocks.NewMockPrinter(t)
+
+		createService := mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+
+		createService.On("Handle", ctx, mock.Anything).
+			Return(&order, nil).
+			Once()
+
+		service := NewAutoPrintService(createService, printer, "store-01", 42)
+
+		// Act
+		_, err := service.Handle(ctx, create.CreateOrderProductionInput{
+			OrderId:   order.Id,
+			Origin:    order_entity.ManualOrigin,
+			CreatedBy: "user-1",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, service.Wait(ctx))
+		createService.AssertExpectations(t)
+		printer.AssertExpectations(t)
+	})
+
+	t.Run("Should not print when the order is reconciled", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		printer := printer_mocks.NewMockPrinter(t)
+
+		createService := mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+
+		createService.On("Handle", ctx, mock.Anything).
+			Return(nil, nil).
+			Once()
+
+		service := NewAutoPrintService(createService, printer, "store-01", 42)
+
+		// Act
+		res, err := service.Handle(ctx, create.CreateOrderProductionInput{OrderId: order.Id})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+		assert.NoError(t, service.Wait(ctx))
+		createService.AssertExpectations(t)
+		printer.AssertExpectations(t)
+	})
+
+	t.Run("Should keep the order when the printer fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		printer := printer_mocks.NewMockPrinter(t)
+
+		printer.On("Print", mock.Anything, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		createService := mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+
+		createService.On("Handle", ctx, mock.Anything).
+			Return(&order, nil).
+			Once()
+
+		service := NewAutoPrintService(createService, printer, "store-01", 42)
+
+		// Act
+		res, err := service.Handle(ctx, create.CreateOrderProductionInput{OrderId: order.Id})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, &order, res)
+		assert.NoError(t, service.Wait(ctx))
+		createService.AssertExpectations(t)
+		printer.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the order is not created", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		printer := printer_mocks.NewMockPrinter(t)
+
+		createService := mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+
+		createService.On("Handle", ctx, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		service := NewAutoPrintService(createService, printer, "store-01", 42)
+
+		// Act
+		_, err := service.Handle(ctx, create.CreateOrderProductionInput{OrderId: order.Id})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, service.Wait(ctx))
+		createService.AssertExpectations(t)
+		printer.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,91 @@
+package printer
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+)
+
+// FakePrinter listens on a local port like a network printer and keeps the
+// jobs it receives, for the tests and the local development
+type FakePrinter struct {
+	listener net.Listener
+
+	mutex sync.Mutex
+	jobs  [][]byte
+
+	wg sync.WaitGroup
+}
+
+// NewFakePrinter starts the printer at the address, use "127.0.0.1:0" for a
+// random port
+func NewFakePrinter(address string) (*FakePrinter, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	printer := &FakePrinter{
+		listener: listener,
+	}
+
+	printer.wg.Add(1)
+	go printer.accept()
+
+	return printer, nil
+}
+
+func (p *FakePrinter) Address() string {
+	return p.listener.Addr().String()
+}
+
+// Jobs returns the jobs received so far, in the order they were received
+func (p *FakePrinter) Jobs() [][]byte {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	jobs := make([][]byte, len(p.jobs))
+	copy(jobs, p.jobs)
+
+	return jobs
+}
+
+func (p *FakePrinter) Close() error {
+	err := p.listener.Close()
+	p.wg.Wait()
+
+	return err
+}
+
+func (p *FakePrinter) accept() {
+	defer p.wg.Done()
+
+	for {
+		conn, err := p.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+
+		p.wg.Add(1)
+		go p.receive(conn)
+	}
+}
+
+// receive reads the job until the client closes the connection
+func (p *FakePrinter) receive(conn net.Conn) {
+	defer p.wg.Done()
+	defer conn.Close()
+
+	job, err := io.ReadAll(conn)
+	if err != nil {
+		return
+	}
+
+	p.mutex.Lock()
+	p.jobs = append(p.jobs, job)
+	p.mutex.Unlock()
+}
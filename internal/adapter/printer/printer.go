@@ -0,0 +1,55 @@
+package printer
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// DefaultPort is the raw printing port (JetDirect) of the network printers
+const DefaultPort = "9100"
+
+type Printer interface {
+	Print(ctx context.Context, job []byte) error
+}
+
+// NetworkPrinter sends the jobs as they are to the printer, one connection
+// per job
+type NetworkPrinter struct {
+	address string
+	timeout time.Duration
+}
+
+func NewNetworkPrinter(address string, timeout time.Duration) *NetworkPrinter {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, DefaultPort)
+	}
+
+	return &NetworkPrinter{
+		address: address,
+		timeout: timeout,
+	}
+}
+
+func (p *NetworkPrinter) Print(ctx context.Context, job []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	_, err = conn.Write(job)
+
+	return err
+}
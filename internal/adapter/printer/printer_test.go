@@ -0,0 +1,58 @@
+package printer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkPrinter(t *testing.T) {
+	t.Run("Should send the job to the printer", func(t *testing.T) {
+		// Arrange
+		fake, err := NewFakePrinter("127.0.0.1:0")
+		assert.NoError(t, err)
+		defer fake.Close()
+
+		printer := NewNetworkPrinter(fake.Address(), time.Second)
+
+		// Act
+		err = printer.Print(context.Background(), []byte("ticket"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			return len(fake.Jobs()) == 1
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, []byte("ticket"), fake.Jobs()[0])
+	})
+
+	t.Run("Should return error when the printer is not reachable", func(t *testing.T) {
+		// Arrange
+		fake, err := NewFakePrinter("127.0.0.1:0")
+		assert.NoError(t, err)
+
+		address := fake.Address()
+		assert.NoError(t, fake.Close())
+
+		printer := NewNetworkPrinter(address, time.Second)
+
+		// Act
+		err = printer.Print(context.Background(), []byte("ticket"))
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should use the raw printing port when the address has no port", func(t *testing.T) {
+		// Arrange
+		address := "192.168.0.10"
+
+		// Act
+		printer := NewNetworkPrinter(address, time.Second)
+
+		// Assert
+		assert.Equal(t, "192.168.0.10:9100", printer.address)
+	})
+}
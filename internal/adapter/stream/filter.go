@@ -0,0 +1,76 @@
+package stream
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+)
+
+var streamStates = []order_entity.OrderState{
+	order_entity.Received,
+	order_entity.Processing,
+	order_entity.Completed,
+	order_entity.Delivered,
+	order_entity.Cancelled,
+}
+
+// Filter selects the events a client receives, empty fields match everything
+type Filter struct {
+	States  []order_entity.OrderState
+	Station string
+}
+
+// NewFilter builds a filter from a comma separated list of state titles
+// (case insensitive) and a station name
+func NewFilter(states string, station string) (Filter, error) {
+	filter := Filter{
+		Station: strings.TrimSpace(station),
+	}
+
+	for _, title := range strings.Split(states, ",") {
+		title = strings.TrimSpace(title)
+		if title == "" {
+			continue
+		}
+
+		state, err := parseState(title)
+		if err != nil {
+			return Filter{}, err
+		}
+
+		filter.States = append(filter.States, state)
+	}
+
+	return filter, nil
+}
+
+func (f Filter) Matches(event order_entity.OrderEvent) bool {
+	if len(f.States) > 0 && !f.hasState(event.Order.State) {
+		return false
+	}
+
+	if f.Station != "" && !event.Order.HasStation(f.Station) {
+		return false
+	}
+
+	return true
+}
+
+func (f Filter) hasState(state order_entity.OrderState) bool {
+	for _, s := range f.States {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+func parseState(title string) (order_entity.OrderState, error) {
+	for _, state := range streamStates {
+		if strings.EqualFold(state.String(), title) {
+			return state, nil
+		}
+	}
+	return order_entity.None, fmt.Errorf("unknown order state: %s", title)
+}
@@ -0,0 +1,92 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvent(id int64, state order_entity.OrderState, station string) order_entity.OrderEvent {
+	return order_entity.OrderEvent{
+		Id:   id,
+		Type: order_entity.OrderUpdatedEvent,
+		Order: order_entity.Order{
+			Id:    "order-1",
+			State: state,
+			Items: []order_entity.Item{
+				{Id: "item-1", Name: "Hamburger", Quantity: 1, Station: station},
+			},
+		},
+	}
+}
+
+func TestNewFilter(t *testing.T) {
+	t.Run("Should parse the states and station", func(t *testing.T) {
+		// Arrange
+		states := "received, Processing,CANCELLED"
+
+		// Act
+		res, err := NewFilter(states, " grill ")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []order_entity.OrderState{
+			order_entity.Received,
+			order_entity.Processing,
+			order_entity.Cancelled,
+		}, res.States)
+		assert.Equal(t, "grill", res.Station)
+	})
+
+	t.Run("Should return an empty filter", func(t *testing.T) {
+		// Arrange
+		// Act
+		res, err := NewFilter("", "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, res.States)
+		assert.Empty(t, res.Station)
+	})
+
+	t.Run("Should return error when the state is unknown", func(t *testing.T) {
+		// Arrange
+		// Act
+		_, err := NewFilter("Received,Baking", "")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestMatches(t *testing.T) {
+	t.Run("Should match everything when the filter is empty", func(t *testing.T) {
+		// Arrange
+		filter := Filter{}
+
+		// Act
+		res := filter.Matches(newEvent(1, order_entity.Completed, ""))
+
+		// Assert
+		assert.True(t, res)
+	})
+
+	t.Run("Should match by state and station", func(t *testing.T) {
+		// Arrange
+		filter := Filter{
+			States:  []order_entity.OrderState{order_entity.Received, order_entity.Processing},
+			Station: "grill",
+		}
+
+		// Act
+		matches := filter.Matches(newEvent(1, order_entity.Processing, "grill"))
+		wrongState := filter.Matches(newEvent(1, order_entity.Completed, "grill"))
+		wrongStation := filter.Matches(newEvent(1, order_entity.Processing, "fryer"))
+
+		// Assert
+		assert.True(t, matches)
+		assert.False(t, wrongState)
+		assert.False(t, wrongStation)
+	})
+}
@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+)
+
+const subscriptionBufferSize = 64
+
+type Subscription struct {
+	filter Filter
+	events chan order_entity.OrderEvent
+}
+
+// Events is closed when the subscription is removed or when the client
+// falls too far behind the published events
+func (s *Subscription) Events() <-chan order_entity.OrderEvent {
+	return s.events
+}
+
+// Hub fans out the events received by this replica to the connected clients
+type Hub struct {
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscription := &Subscription{
+		filter: filter,
+		events: make(chan order_entity.OrderEvent, subscriptionBufferSize),
+	}
+
+	h.subscriptions[subscription] = struct{}{}
+
+	return subscription
+}
+
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(subscription)
+}
+
+func (h *Hub) Publish(event order_entity.OrderEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscription := range h.subscriptions {
+		if !subscription.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			// the client can resume from its last event id after reconnecting
+			slog.Warn("dropping slow order stream subscriber", "event_id", event.Id)
+			h.remove(subscription)
+		}
+	}
+}
+
+func (h *Hub) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subscriptions)
+}
+
+func (h *Hub) remove(subscription *Subscription) {
+	if _, ok := h.subscriptions[subscription]; !ok {
+		return
+	}
+
+	delete(h.subscriptions, subscription)
+	close(subscription.events)
+}
+
+// Close ends every connected stream, it is called when the server shuts down
+// so the long lived connections do not hold the graceful shutdown
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscription := range h.subscriptions {
+		h.remove(subscription)
+	}
+}
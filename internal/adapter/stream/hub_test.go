@@ -0,0 +1,84 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	t.Run("Should publish the events matching the subscription filter", func(t *testing.T) {
+		// Arrange
+		hub := NewHub()
+
+		grill := hub.Subscribe(Filter{Station: "grill"})
+		all := hub.Subscribe(Filter{})
+
+		// Act
+		hub.Publish(newEvent(1, order_entity.Received, "fryer"))
+		hub.Publish(newEvent(2, order_entity.Received, "grill"))
+
+		// Assert
+		assert.Len(t, grill.Events(), 1)
+		assert.Equal(t, int64(2), (<-grill.Events()).Id)
+		assert.Len(t, all.Events(), 2)
+	})
+
+	t.Run("Should close the events when unsubscribing", func(t *testing.T) {
+		// Arrange
+		hub := NewHub()
+
+		subscription := hub.Subscribe(Filter{})
+
+		// Act
+		hub.Unsubscribe(subscription)
+		hub.Unsubscribe(subscription)
+
+		// Assert
+		_, ok := <-subscription.Events()
+		assert.False(t, ok)
+		assert.Equal(t, 0, hub.Count())
+	})
+
+	t.Run("Should drop the slow subscribers", func(t *testing.T) {
+		// Arrange
+		hub := NewHub()
+
+		subscription := hub.Subscribe(Filter{})
+
+		// Act
+		for i := 0; i <= subscriptionBufferSize; i++ {
+			hub.Publish(newEvent(int64(i+1), order_entity.Received, ""))
+		}
+
+		// Assert
+		assert.Equal(t, 0, hub.Count())
+
+		received := 0
+		for range subscription.Events() {
+			received++
+		}
+		assert.Equal(t, subscriptionBufferSize, received)
+	})
+}
+
+func TestClose(t *testing.T) {
+	t.Run("Should close every subscription", func(t *testing.T) {
+		// Arrange
+		hub := NewHub()
+
+		first := hub.Subscribe(Filter{})
+		second := hub.Subscribe(Filter{})
+
+		// Act
+		hub.Close()
+
+		// Assert
+		_, firstOpen := <-first.Events()
+		_, secondOpen := <-second.Events()
+		assert.False(t, firstOpen)
+		assert.False(t, secondOpen)
+		assert.Equal(t, 0, hub.Count())
+	})
+}
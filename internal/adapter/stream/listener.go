@@ -0,0 +1,155 @@
+package stream
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/lib/pq"
+)
+
+const (
+	listenerMinReconnectInterval = 10 * time.Second
+	listenerMaxReconnectInterval = time.Minute
+	listenerPingInterval         = 90 * time.Second
+)
+
+type Listener interface {
+	Listen(ctx context.Context)
+}
+
+// Evicter removes the orders changed by any replica from a local cache
+type Evicter interface {
+	Evict(ctx context.Context, orderId string)
+}
+
+// PostgresListener receives the ids notified by every replica on the order
+// events channel and publishes the saved events to the local hub, evicting
+// the changed orders from the cache when one is set
+type PostgresListener struct {
+	dbUrl      string
+	repository repository.OrderEventRepository
+	hub        *Hub
+	evicter    Evicter
+
+	// lastCreatedAt is the creation of the last event published, the events
+	// saved since the lookback before it are read again when the connection
+	// is re-established
+	lastCreatedAt time.Time
+}
+
+func NewPostgresListener(
+	dbUrl string,
+	repository repository.OrderEventRepository,
+	hub *Hub,
+	evicter Evicter,
+) *PostgresListener {
+	return &PostgresListener{
+		dbUrl:      dbUrl,
+		repository: repository,
+		hub:        hub,
+		evicter:    evicter,
+	}
+}
+
+func (l *PostgresListener) Listen(ctx context.Context) {
+	listener := pq.NewListener(l.dbUrl, listenerMinReconnectInterval, listenerMaxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.ErrorContext(ctx, "order stream listener error", "event", event, "error", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(repository.OrderEventsChannel); err != nil {
+		slog.ErrorContext(ctx, "error listening to the order events channel", "error", err)
+		return
+	}
+
+	slog.InfoContext(ctx, "listening to order events", "channel", repository.OrderEventsChannel)
+
+	l.lastCreatedAt = time.Now()
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "stopping the order stream listener")
+			return
+		case notification := <-listener.Notify:
+			// a nil notification is sent after the connection is re-established,
+			// the events notified while it was down were lost
+			if notification == nil {
+				l.resync(ctx)
+				continue
+			}
+			l.handle(ctx, notification.Extra)
+		case <-ticker.C:
+			go func() {
+				if err := listener.Ping(); err != nil {
+					slog.ErrorContext(ctx, "error pinging the order stream listener", "error", err)
+				}
+			}()
+		}
+	}
+}
+
+func (l *PostgresListener) handle(ctx context.Context, payload string) {
+	id, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		slog.ErrorContext(ctx, "invalid order event notification", "payload", payload, "error", err)
+		return
+	}
+
+	event, err := l.repository.GetEventByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting the order event", "event_id", id, "error", err)
+		return
+	}
+
+	l.publish(ctx, event)
+}
+
+// resync publishes the events saved since the lookback before the last one
+// published, the streams discard the events already delivered
+func (l *PostgresListener) resync(ctx context.Context) {
+	since := l.lastCreatedAt.Add(-eventLookback)
+	afterId := int64(0)
+
+	slog.InfoContext(ctx, "resyncing the order stream", "since", since)
+
+	for {
+		events, err := l.repository.ListEventsSince(ctx, since, afterId, replayBatchSize)
+		if err != nil {
+			slog.ErrorContext(ctx, "error resyncing the order stream", "since", since, "error", err)
+			return
+		}
+
+		for _, event := range events {
+			afterId = event.Id
+			l.publish(ctx, event)
+		}
+
+		if len(events) < replayBatchSize {
+			return
+		}
+	}
+}
+
+func (l *PostgresListener) publish(ctx context.Context, event order_entity.OrderEvent) {
+	if l.evicter != nil {
+		l.evicter.Evict(ctx, event.Order.Id)
+	}
+
+	event.Order.UpdateTimezone()
+
+	l.hub.Publish(event)
+
+	if event.CreatedAt.After(l.lastCreatedAt) {
+		l.lastCreatedAt = event.CreatedAt
+	}
+}
@@ -0,0 +1,204 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingEvicter records the evicted orders, the generated mocks of the
+// package can not be imported by its own tests
+type recordingEvicter struct {
+	orderIds []string
+}
+
+func (e *recordingEvicter) Evict(ctx context.Context, orderId string) {
+	e.orderIds = append(e.orderIds, orderId)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should publish the notified event", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("GetEventByID", ctx, int64(10)).
+			Return(newEvent(10, order_entity.Received, ""), nil).
+			Once()
+
+		hub := NewHub()
+		subscription := hub.Subscribe(Filter{})
+
+		listener := NewPostgresListener("", repository, hub, nil)
+
+		// Act
+		listener.handle(ctx, "10")
+
+		// Assert
+		assert.Equal(t, int64(10), (<-subscription.Events()).Id)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should evict the changed order from the cache", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("GetEventByID", ctx, int64(10)).
+			Return(newEvent(10, order_entity.Processing, ""), nil).
+			Once()
+
+		evicter := &recordingEvicter{}
+
+		hub := NewHub()
+		subscription := hub.Subscribe(Filter{})
+
+		listener := NewPostgresListener("", repository, hub, evicter)
+
+		// Act
+		listener.handle(ctx, "10")
+
+		// Assert
+		assert.Equal(t, int64(10), (<-subscription.Events()).Id)
+		assert.Equal(t, []string{"order-1"}, evicter.orderIds)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should ignore invalid notifications", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+
+		hub := NewHub()
+		subscription := hub.Subscribe(Filter{})
+
+		listener := NewPostgresListener("", repository, hub, nil)
+
+		// Act
+		listener.handle(ctx, "abc")
+
+		// Assert
+		assert.Empty(t, subscription.Events())
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should ignore the notification when the event is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("GetEventByID", ctx, int64(10)).
+			Return(order_entity.OrderEvent{}, assert.AnError).
+			Once()
+
+		hub := NewHub()
+		subscription := hub.Subscribe(Filter{})
+
+		listener := NewPostgresListener("", repository, hub, nil)
+
+		// Act
+		listener.handle(ctx, "10")
+
+		// Assert
+		assert.Empty(t, subscription.Events())
+		repository.AssertExpectations(t)
+	})
+}
+
+func TestResync(t *testing.T) {
+	t.Run("Should publish the events saved since the lookback before the last one published", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		createdAt := time.Now()
+
+		// committed after the last event published, with a lower id
+		late := newEvent(9, order_entity.Received, "")
+		late.CreatedAt = createdAt.Add(-time.Second)
+
+		missed := newEvent(11, order_entity.Processing, "")
+		missed.CreatedAt = createdAt.Add(time.Second)
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("ListEventsSince", ctx, createdAt.Add(-eventLookback), int64(0), replayBatchSize).
+			Return([]order_entity.OrderEvent{late, missed}, nil).
+			Once()
+
+		hub := NewHub()
+		subscription := hub.Subscribe(Filter{})
+
+		listener := NewPostgresListener("", repository, hub, nil)
+		listener.lastCreatedAt = createdAt
+
+		// Act
+		listener.resync(ctx)
+
+		// Assert
+		assert.Equal(t, int64(9), (<-subscription.Events()).Id)
+		assert.Equal(t, int64(11), (<-subscription.Events()).Id)
+		assert.Equal(t, missed.CreatedAt, listener.lastCreatedAt)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should read the events in batches", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		createdAt := time.Now()
+
+		batch := make([]order_entity.OrderEvent, 0, replayBatchSize)
+		for id := int64(1); id <= replayBatchSize; id++ {
+			batch = append(batch, newEvent(id, order_entity.Processing, ""))
+		}
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("ListEventsSince", ctx, createdAt.Add(-eventLookback), int64(0), replayBatchSize).
+			Return(batch, nil).
+			Once()
+		repository.On("ListEventsSince", ctx, createdAt.Add(-eventLookback), int64(replayBatchSize), replayBatchSize).
+			Return([]order_entity.OrderEvent{}, nil).
+			Once()
+
+		hub := NewHub()
+
+		listener := NewPostgresListener("", repository, hub, nil)
+		listener.lastCreatedAt = createdAt
+
+		// Act
+		listener.resync(ctx)
+
+		// Assert
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should not publish when the events can not be read", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		createdAt := time.Now()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("ListEventsSince", ctx, createdAt.Add(-eventLookback), int64(0), replayBatchSize).
+			Return(nil, assert.AnError).
+			Once()
+
+		hub := NewHub()
+		subscription := hub.Subscribe(Filter{})
+
+		listener := NewPostgresListener("", repository, hub, nil)
+		listener.lastCreatedAt = createdAt
+
+		// Act
+		listener.resync(ctx)
+
+		// Assert
+		assert.Empty(t, subscription.Events())
+		assert.Equal(t, createdAt, listener.lastCreatedAt)
+		repository.AssertExpectations(t)
+	})
+}
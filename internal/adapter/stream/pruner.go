@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Pruner interface {
+	Run(ctx context.Context)
+}
+
+// EventPruner deletes the order events older than the retention, every
+// replica runs it and the deletion is idempotent
+type EventPruner struct {
+	repository repository.OrderEventRepository
+	retention  time.Duration
+	interval   time.Duration
+}
+
+func NewEventPruner(
+	repository repository.OrderEventRepository,
+	retention time.Duration,
+	interval time.Duration,
+) *EventPruner {
+	return &EventPruner{
+		repository: repository,
+		retention:  retention,
+		interval:   interval,
+	}
+}
+
+// Run prunes the events on every interval until the context is done, a zero
+// retention or interval keeps every event
+func (p *EventPruner) Run(ctx context.Context) {
+	if p.retention <= 0 || p.interval <= 0 {
+		slog.InfoContext(ctx, "order events pruning disabled")
+		return
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.prune(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *EventPruner) prune(ctx context.Context) {
+	before := time.Now().Add(-p.retention)
+
+	deleted, err := p.repository.DeleteEventsBefore(ctx, before)
+	if err != nil {
+		slog.ErrorContext(ctx, "error pruning the order events", "before", before, "error", err)
+		return
+	}
+
+	if deleted > 0 {
+		slog.InfoContext(ctx, "order events pruned", "before", before, "deleted", deleted)
+	}
+}
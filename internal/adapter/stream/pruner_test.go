@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPrune(t *testing.T) {
+	t.Run("Should delete the events older than the retention", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("DeleteEventsBefore", ctx, mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= time.Hour && time.Since(before) < time.Hour+time.Minute
+		})).
+			Return(int64(3), nil).
+			Once()
+
+		pruner := NewEventPruner(repository, time.Hour, time.Minute)
+
+		// Act
+		pruner.prune(ctx)
+
+		// Assert
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should keep running when the events can not be deleted", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("DeleteEventsBefore", ctx, mock.Anything).
+			Return(int64(0), assert.AnError).
+			Twice()
+		repository.On("DeleteEventsBefore", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { cancel() }).
+			Return(int64(0), nil)
+
+		pruner := NewEventPruner(repository, time.Hour, time.Millisecond)
+
+		// Act
+		pruner.Run(ctx)
+
+		// Assert
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should not delete the events when the retention is not set", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+
+		pruner := NewEventPruner(repository, 0, time.Hour)
+
+		// Act
+		pruner.Run(ctx)
+
+		// Assert
+		repository.AssertNotCalled(t, "DeleteEventsBefore", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,185 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+)
+
+const (
+	replayBatchSize   = 100
+	heartbeatInterval = 15 * time.Second
+	// eventLookback is how long before an event the events with a lower id
+	// may still be committed: the ids are assigned when the event is saved
+	// but the events are notified when their transaction commits, so the
+	// events are read again from this long before the last one and sent
+	// unless already delivered
+	eventLookback = time.Minute
+)
+
+// Sink writes the events to a connected client
+type Sink interface {
+	Send(event order_entity.OrderEvent) error
+	Ping() error
+}
+
+type Streamer interface {
+	Stream(ctx context.Context, filter Filter, lastEventId int64, sink Sink) error
+}
+
+type OrderStreamer struct {
+	repository        repository.OrderEventRepository
+	hub               *Hub
+	heartbeatInterval time.Duration
+}
+
+func NewOrderStreamer(repository repository.OrderEventRepository, hub *Hub) *OrderStreamer {
+	return &OrderStreamer{
+		repository:        repository,
+		hub:               hub,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// Stream sends the events saved after lastEventId and then the live events
+// until the context is done, the client falling behind or failing to write.
+// The events saved within the lookback before lastEventId are sent again, as
+// one of them may have been committed after it
+func (s *OrderStreamer) Stream(ctx context.Context, filter Filter, lastEventId int64, sink Sink) error {
+	// subscribe before replaying so no event is lost between both phases
+	subscription := s.hub.Subscribe(filter)
+	defer s.hub.Unsubscribe(subscription)
+
+	delivered := newDeliveredEvents()
+
+	if lastEventId > 0 {
+		if err := s.replay(ctx, filter, lastEventId, sink, delivered); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(s.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return nil
+			}
+			if delivered.Contains(event.Id) {
+				continue
+			}
+			if err := sink.Send(event); err != nil {
+				return err
+			}
+			delivered.Add(event)
+		case <-ticker.C:
+			delivered.Prune()
+			if err := sink.Ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *OrderStreamer) replay(ctx context.Context, filter Filter, lastEventId int64, sink Sink, delivered *deliveredEvents) error {
+	// without the last event, e.g. already pruned, only the later ids are sent
+	since := time.Time{}
+	afterId := lastEventId
+
+	last, err := s.repository.GetEventByID(ctx, lastEventId)
+	if err != nil && !errors.Is(err, custom_error.ErrOrderEventNotFound) {
+		return err
+	}
+	if err == nil {
+		since = last.CreatedAt.Add(-eventLookback)
+		afterId = 0
+		delivered.Add(last)
+	}
+
+	for {
+		events, err := s.repository.ListEventsSince(ctx, since, afterId, replayBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			afterId = event.Id
+
+			if !filter.Matches(event) || delivered.Contains(event.Id) {
+				continue
+			}
+
+			event.Order.UpdateTimezone()
+
+			if err := sink.Send(event); err != nil {
+				return err
+			}
+			delivered.Add(event)
+		}
+
+		if len(events) < replayBatchSize {
+			return nil
+		}
+	}
+}
+
+// deliveredEvents are the ids of the events sent to a client within the
+// lookback of the last one, an event received again is not sent twice
+type deliveredEvents struct {
+	createdAt map[int64]time.Time
+	last      time.Time
+}
+
+func newDeliveredEvents() *deliveredEvents {
+	return &deliveredEvents{
+		createdAt: make(map[int64]time.Time),
+	}
+}
+
+func (d *deliveredEvents) Contains(id int64) bool {
+	_, ok := d.createdAt[id]
+	return ok
+}
+
+func (d *deliveredEvents) Add(event order_entity.OrderEvent) {
+	d.createdAt[event.Id] = event.CreatedAt
+
+	if event.CreatedAt.After(d.last) {
+		d.last = event.CreatedAt
+	}
+}
+
+// Prune forgets the events older than the lookback, they are not published
+// again
+func (d *deliveredEvents) Prune() {
+	for id, createdAt := range d.createdAt {
+		if createdAt.Before(d.last.Add(-eventLookback)) {
+			delete(d.createdAt, id)
+		}
+	}
+}
+
+// ParseLastEventId reads the id sent by the client to resume the stream, an
+// empty value means the client only wants the live events
+func ParseLastEventId(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid last event id: %s", value)
+	}
+
+	return id, nil
+}
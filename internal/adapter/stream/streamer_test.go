@@ -0,0 +1,307 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeSink struct {
+	events  chan order_entity.OrderEvent
+	pings   int
+	sendErr error
+}
+
+func newFakeSink() *fakeSink {
+	return &fakeSink{
+		events: make(chan order_entity.OrderEvent, 10),
+	}
+}
+
+func (s *fakeSink) Send(event order_entity.OrderEvent) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.events <- event
+	return nil
+}
+
+func (s *fakeSink) Ping() error {
+	s.pings++
+	return nil
+}
+
+func waitSubscribers(t *testing.T, hub *Hub, count int) {
+	assert.Eventually(t, func() bool {
+		return hub.Count() == count
+	}, time.Second, time.Millisecond)
+}
+
+func TestStream(t *testing.T) {
+	t.Run("Should replay the missed events and then stream the live events", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		createdAt := time.Now()
+
+		last := newEvent(5, order_entity.Received, "grill")
+		last.CreatedAt = createdAt
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("GetEventByID", mock.Anything, int64(5)).
+			Return(last, nil).
+			Once()
+		repository.On("ListEventsSince", mock.Anything, createdAt.Add(-eventLookback), int64(0), replayBatchSize).
+			Return([]order_entity.OrderEvent{
+				// committed after the last event received by the client
+				newEvent(4, order_entity.Received, "grill"),
+				last,
+				newEvent(6, order_entity.Received, "grill"),
+				newEvent(7, order_entity.Received, "fryer"),
+				newEvent(8, order_entity.Processing, "grill"),
+			}, nil).
+			Once()
+
+		hub := NewHub()
+		sink := newFakeSink()
+
+		streamer := NewOrderStreamer(repository, hub)
+
+		done := make(chan error)
+		go func() {
+			done <- streamer.Stream(ctx, Filter{Station: "grill"}, 5, sink)
+		}()
+
+		waitSubscribers(t, hub, 1)
+
+		// Act
+		hub.Publish(newEvent(8, order_entity.Processing, "grill"))
+		hub.Publish(newEvent(9, order_entity.Completed, "grill"))
+
+		// Assert
+		assert.Equal(t, int64(4), (<-sink.events).Id)
+		assert.Equal(t, int64(6), (<-sink.events).Id)
+		assert.Equal(t, int64(8), (<-sink.events).Id)
+		assert.Equal(t, int64(9), (<-sink.events).Id)
+
+		cancel()
+		assert.NoError(t, <-done)
+		assert.Equal(t, 0, hub.Count())
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should replay the events after the id when the last event was pruned", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("GetEventByID", mock.Anything, int64(5)).
+			Return(order_entity.OrderEvent{}, custom_error.ErrOrderEventNotFound).
+			Once()
+		repository.On("ListEventsSince", mock.Anything, time.Time{}, int64(5), replayBatchSize).
+			Return([]order_entity.OrderEvent{
+				newEvent(6, order_entity.Received, ""),
+			}, nil).
+			Once()
+
+		hub := NewHub()
+		sink := newFakeSink()
+
+		streamer := NewOrderStreamer(repository, hub)
+
+		done := make(chan error)
+		go func() {
+			done <- streamer.Stream(ctx, Filter{}, 5, sink)
+		}()
+
+		// Assert
+		assert.Equal(t, int64(6), (<-sink.events).Id)
+
+		cancel()
+		assert.NoError(t, <-done)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should stream a live event committed after an event with a greater id", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+
+		hub := NewHub()
+		sink := newFakeSink()
+
+		streamer := NewOrderStreamer(repository, hub)
+
+		done := make(chan error)
+		go func() {
+			done <- streamer.Stream(ctx, Filter{}, 0, sink)
+		}()
+
+		waitSubscribers(t, hub, 1)
+
+		// Act
+		hub.Publish(newEvent(11, order_entity.Processing, ""))
+		hub.Publish(newEvent(10, order_entity.Received, ""))
+		hub.Publish(newEvent(11, order_entity.Processing, ""))
+		hub.Publish(newEvent(12, order_entity.Completed, ""))
+
+		// Assert
+		assert.Equal(t, int64(11), (<-sink.events).Id)
+		assert.Equal(t, int64(10), (<-sink.events).Id)
+		assert.Equal(t, int64(12), (<-sink.events).Id)
+
+		cancel()
+		assert.NoError(t, <-done)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should not replay when there is no last event id", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+
+		hub := NewHub()
+		sink := newFakeSink()
+
+		streamer := NewOrderStreamer(repository, hub)
+		streamer.heartbeatInterval = time.Millisecond
+
+		done := make(chan error)
+		go func() {
+			done <- streamer.Stream(ctx, Filter{}, 0, sink)
+		}()
+
+		waitSubscribers(t, hub, 1)
+
+		// Act
+		hub.Publish(newEvent(1, order_entity.Received, ""))
+
+		// Assert
+		assert.Equal(t, int64(1), (<-sink.events).Id)
+
+		cancel()
+		assert.NoError(t, <-done)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the replay fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+		repository.On("GetEventByID", ctx, int64(5)).
+			Return(order_entity.OrderEvent{}, assert.AnError).
+			Once()
+
+		hub := NewHub()
+
+		streamer := NewOrderStreamer(repository, hub)
+
+		// Act
+		err := streamer.Stream(ctx, Filter{}, 5, newFakeSink())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 0, hub.Count())
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the client fails to receive the event", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := mocks.NewMockOrderEventRepository(t)
+
+		hub := NewHub()
+		sink := newFakeSink()
+		sink.sendErr = assert.AnError
+
+		streamer := NewOrderStreamer(repository, hub)
+
+		done := make(chan error)
+		go func() {
+			done <- streamer.Stream(ctx, Filter{}, 0, sink)
+		}()
+
+		waitSubscribers(t, hub, 1)
+
+		// Act
+		hub.Publish(newEvent(1, order_entity.Received, ""))
+
+		// Assert
+		assert.ErrorIs(t, <-done, assert.AnError)
+		assert.Equal(t, 0, hub.Count())
+	})
+}
+
+func TestParseLastEventId(t *testing.T) {
+	t.Run("Should parse the last event id", func(t *testing.T) {
+		// Arrange
+		// Act
+		res, err := ParseLastEventId("42")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), res)
+	})
+
+	t.Run("Should return zero when the value is empty", func(t *testing.T) {
+		// Arrange
+		// Act
+		res, err := ParseLastEventId("")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), res)
+	})
+
+	t.Run("Should return error when the value is invalid", func(t *testing.T) {
+		// Arrange
+		// Act
+		_, err := ParseLastEventId("-1")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestDeliveredEvents(t *testing.T) {
+	t.Run("Should forget the events older than the lookback", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		old := newEvent(1, order_entity.Received, "")
+		old.CreatedAt = now.Add(-2 * eventLookback)
+
+		recent := newEvent(2, order_entity.Received, "")
+		recent.CreatedAt = now.Add(-eventLookback / 2)
+
+		last := newEvent(3, order_entity.Received, "")
+		last.CreatedAt = now
+
+		delivered := newDeliveredEvents()
+		delivered.Add(old)
+		delivered.Add(last)
+		delivered.Add(recent)
+
+		// Act
+		delivered.Prune()
+
+		// Assert
+		assert.False(t, delivered.Contains(1))
+		assert.True(t, delivered.Contains(2))
+		assert.True(t, delivered.Contains(3))
+	})
+}
@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans installs a global provider that keeps the ended spans in memory
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	return recorder
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Should start a server span named after the route", func(t *testing.T) {
+		// Arrange
+		recorder := recordSpans(t)
+
+		var handlerSpan trace.SpanContext
+
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/orders/:id", func(c echo.Context) error {
+			handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/123", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "GET /orders/:id", spans[0].Name())
+		assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+		assert.Contains(t, spans[0].Attributes(), semconv.HTTPRoute("/orders/:id"))
+		assert.Contains(t, spans[0].Attributes(), semconv.HTTPResponseStatusCode(http.StatusNoContent))
+		assert.Equal(t, spans[0].SpanContext().SpanID(), handlerSpan.SpanID())
+	})
+
+	t.Run("Should continue the trace of the caller", func(t *testing.T) {
+		// Arrange
+		recorder := recordSpans(t)
+
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/orders/:id", func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/123", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	})
+
+	t.Run("Should fail the span when the handler returns a server error", func(t *testing.T) {
+		// Arrange
+		recorder := recordSpans(t)
+
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/orders/:id", func(c echo.Context) error {
+			return errors.New("something went wrong")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/123", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Contains(t, spans[0].Attributes(), semconv.HTTPResponseStatusCode(http.StatusInternalServerError))
+		assert.Len(t, spans[0].Events(), 1)
+	})
+
+	t.Run("Should not fail the span when the handler returns a client error", func(t *testing.T) {
+		// Arrange
+		recorder := recordSpans(t)
+
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/orders/:id", func(c echo.Context) error {
+			return echo.NewHTTPError(http.StatusNotFound)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/123", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Contains(t, spans[0].Attributes(), semconv.HTTPResponseStatusCode(http.StatusNotFound))
+	})
+
+	t.Run("Should not trace the health check and the metrics", func(t *testing.T) {
+		// Arrange
+		recorder := recordSpans(t)
+
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/health", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Empty(t, recorder.Ended())
+	})
+}
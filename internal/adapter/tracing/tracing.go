@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// InstrumentationName identifies the spans created by the service
+	InstrumentationName = "github.com/jfelipearaujo-org/ms-production-management"
+
+	OtlpExporter   = "otlp"
+	StdoutExporter = "stdout"
+	NoneExporter   = "none"
+)
+
+// Tracer returns the tracer of the global provider, spans are not recorded
+// until Setup is called
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Setup registers the tracer provider and the W3C trace context propagator as
+// the global ones, the returned function flushes the pending spans
+func Setup(ctx context.Context, config *environment.Config) (func(context.Context) error, error) {
+	provider, err := NewTracerProvider(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+// NewTracerProvider returns the provider with the configured exporter, the
+// traces started by the service are sampled with the configured ratio and the
+// ones started by the callers follow their decision
+func NewTracerProvider(ctx context.Context, config *environment.Config) (*sdktrace.TracerProvider, error) {
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName(config.TracingConfig.ServiceName),
+			semconv.ServiceVersion(config.ApiConfig.ApiVersion),
+			semconv.DeploymentEnvironment(config.ApiConfig.EnvName),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.TracingConfig.SampleRatio))),
+	}
+
+	exporter, err := newExporter(ctx, config.TracingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	return sdktrace.NewTracerProvider(opts...), nil
+}
+
+func newExporter(ctx context.Context, config *environment.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch config.Exporter {
+	case OtlpExporter:
+		var opts []otlptracegrpc.Option
+
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		return otlptracegrpc.New(ctx, opts...)
+	case StdoutExporter:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case NoneExporter, "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", config.Exporter)
+	}
+}
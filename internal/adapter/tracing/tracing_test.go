@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+)
+
+func newConfig(exporter string) *environment.Config {
+	return &environment.Config{
+		ApiConfig: &environment.ApiConfig{
+			EnvName:    "development",
+			ApiVersion: "v1",
+		},
+		TracingConfig: &environment.TracingConfig{
+			Exporter:    exporter,
+			SampleRatio: 1,
+			ServiceName: "ms-production-management",
+		},
+	}
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	t.Run("Should sample the spans without exporting them when the exporter is none", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		provider, err := NewTracerProvider(ctx, newConfig(NoneExporter))
+
+		// Assert
+		assert.NoError(t, err)
+
+		_, span := provider.Tracer(InstrumentationName).Start(ctx, "test")
+		defer span.End()
+
+		assert.True(t, span.SpanContext().IsSampled())
+		assert.NoError(t, provider.Shutdown(ctx))
+	})
+
+	t.Run("Should not sample the spans when the ratio is zero", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		config := newConfig(NoneExporter)
+		config.TracingConfig.SampleRatio = 0
+
+		// Act
+		provider, err := NewTracerProvider(ctx, config)
+
+		// Assert
+		assert.NoError(t, err)
+
+		_, span := provider.Tracer(InstrumentationName).Start(ctx, "test")
+		defer span.End()
+
+		assert.True(t, span.SpanContext().IsValid())
+		assert.False(t, span.SpanContext().IsSampled())
+	})
+
+	t.Run("Should create the provider with the stdout exporter", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		provider, err := NewTracerProvider(ctx, newConfig(StdoutExporter))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, provider)
+		assert.NoError(t, provider.Shutdown(ctx))
+	})
+
+	t.Run("Should return error when the exporter is unknown", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		provider, err := NewTracerProvider(ctx, newConfig("zipkin"))
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, provider)
+	})
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("Should register the provider and the propagator as the global ones", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		previousProvider := otel.GetTracerProvider()
+		previousPropagator := otel.GetTextMapPropagator()
+		defer otel.SetTracerProvider(previousProvider)
+		defer otel.SetTextMapPropagator(previousPropagator)
+
+		// Act
+		shutdown, err := Setup(ctx, newConfig(NoneExporter))
+
+		// Assert
+		assert.NoError(t, err)
+
+		_, span := Tracer().Start(ctx, "test")
+		span.End()
+
+		assert.True(t, span.SpanContext().IsValid())
+		assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+		assert.NoError(t, shutdown(ctx))
+	})
+}
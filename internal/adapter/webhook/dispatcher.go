@@ -0,0 +1,240 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+)
+
+type Dispatcher interface {
+	Dispatch(ctx context.Context, event *cloud.OrderEvent)
+	Wait(ctx context.Context) error
+}
+
+type HttpDispatcher struct {
+	repository   repository.WebhookRepository
+	timeProvider provider.TimeProvider
+	config       *environment.WebhookConfig
+	client       *http.Client
+
+	sleep func(ctx context.Context, d time.Duration) error
+	wg    sync.WaitGroup
+}
+
+func NewDispatcher(
+	repository repository.WebhookRepository,
+	timeProvider provider.TimeProvider,
+	config *environment.WebhookConfig,
+) *HttpDispatcher {
+	return &HttpDispatcher{
+		repository:   repository,
+		timeProvider: timeProvider,
+		config:       config,
+		client: &http.Client{
+			Timeout: config.Timeout,
+		},
+		sleep: sleep,
+	}
+}
+
+// Dispatch delivers the event in background to every active subscription
+// that accepts it, the request that produced the event is not delayed
+func (d *HttpDispatcher) Dispatch(ctx context.Context, event *cloud.OrderEvent) {
+	ctx = context.WithoutCancel(ctx)
+
+	cloudEvent := cloud.NewOrderCloudEvent(event)
+
+	body, err := json.Marshal(cloudEvent)
+	if err != nil {
+		slog.ErrorContext(ctx, "error marshalling webhook event", "error", err)
+		return
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		subscriptions, err := d.repository.ListActiveSubscriptions(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "error listing webhook subscriptions", "error", err)
+			return
+		}
+
+		for _, subscription := range subscriptions {
+			if !subscription.Accepts(cloudEvent.Type) {
+				continue
+			}
+
+			d.wg.Add(1)
+			go func(subscription webhook_entity.Subscription) {
+				defer d.wg.Done()
+				d.deliver(ctx, subscription, cloudEvent, body)
+			}(subscription)
+		}
+	}()
+}
+
+// Wait blocks until every pending delivery is finished or the context is done
+func (d *HttpDispatcher) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *HttpDispatcher) deliver(
+	ctx context.Context,
+	subscription webhook_entity.Subscription,
+	event *cloud.OrderCloudEvent,
+	body []byte,
+) {
+	for attempt := 1; attempt <= d.config.MaxAttempts; attempt++ {
+		delivery := webhook_entity.NewDelivery(uuid.NewString(), subscription.Id, event.Id, event.Type, attempt, d.timeProvider.GetTime())
+
+		start := time.Now()
+		statusCode, err := d.send(ctx, subscription, event, body)
+		delivery.Finish(statusCode, err, time.Since(start))
+
+		if err := d.repository.CreateDelivery(ctx, &delivery); err != nil {
+			slog.ErrorContext(ctx, "error saving webhook delivery", "webhook_id", subscription.Id, "error", err)
+		}
+
+		if delivery.Success {
+			d.registerOutcome(ctx, subscription.Id, true)
+			return
+		}
+
+		slog.WarnContext(ctx, "webhook delivery failed",
+			"webhook_id", subscription.Id,
+			"event_id", event.Id,
+			"attempt", attempt,
+			"status_code", statusCode,
+			"error", delivery.Error)
+
+		if attempt == d.config.MaxAttempts {
+			break
+		}
+
+		if err := d.sleep(ctx, d.backoff(attempt)); err != nil {
+			break
+		}
+	}
+
+	d.registerOutcome(ctx, subscription.Id, false)
+}
+
+func (d *HttpDispatcher) send(
+	ctx context.Context,
+	subscription webhook_entity.Subscription,
+	event *cloud.OrderCloudEvent,
+	body []byte,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := d.timeProvider.GetTime().Unix()
+
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	req.Header.Set(EventIdHeader, event.Id)
+	req.Header.Set(EventTypeHeader, event.Type)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, body))
+
+	if correlationId := correlation.CorrelationIdFromContext(ctx); correlationId != "" {
+		req.Header.Set(correlation.HeaderXCorrelationID, correlationId)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// registerOutcome reloads the subscription so concurrent deliveries do not
+// overwrite each other failure count
+func (d *HttpDispatcher) registerOutcome(ctx context.Context, subscriptionId string, success bool) {
+	subscription, err := d.repository.GetSubscriptionByID(ctx, subscriptionId)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting webhook subscription", "webhook_id", subscriptionId, "error", err)
+		return
+	}
+
+	now := d.timeProvider.GetTime()
+
+	if success {
+		if subscription.ConsecutiveFailures == 0 {
+			return
+		}
+		subscription.RegisterSuccess(now)
+	} else {
+		subscription.RegisterFailure(d.config.MaxConsecutiveFailures, now)
+	}
+
+	if err := d.repository.UpdateSubscription(ctx, &subscription); err != nil {
+		slog.ErrorContext(ctx, "error updating webhook subscription", "webhook_id", subscriptionId, "error", err)
+		return
+	}
+
+	if !subscription.Active {
+		slog.WarnContext(ctx, "webhook disabled after consecutive failures",
+			"webhook_id", subscriptionId,
+			"consecutive_failures", subscription.ConsecutiveFailures)
+	}
+}
+
+func (d *HttpDispatcher) backoff(attempt int) time.Duration {
+	backoff := d.config.InitialBackoff << (attempt - 1)
+
+	if backoff <= 0 || backoff > d.config.MaxBackoff {
+		return d.config.MaxBackoff
+	}
+
+	return backoff
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
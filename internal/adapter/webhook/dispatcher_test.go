@@ -0,0 +1,256 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDispatcher(t *testing.T, repository *repository_mocks.MockWebhookRepository, now time.Time, maxFailures int) *HttpDispatcher {
+	timeProvider := provider_mocks.NewMockTimeProvider(t)
+	timeProvider.On("GetTime").Return(now).Maybe()
+
+	dispatcher := NewDispatcher(repository, timeProvider, &environment.WebhookConfig{
+		MaxAttempts:            3,
+		InitialBackoff:         time.Second,
+		MaxBackoff:             time.Minute,
+		Timeout:                time.Second,
+		MaxConsecutiveFailures: maxFailures,
+	})
+	dispatcher.sleep = func(ctx context.Context, d time.Duration) error {
+		return nil
+	}
+
+	return dispatcher
+}
+
+func newEvent(now time.Time) *cloud.OrderEvent {
+	order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", now)
+
+	return cloud.NewOrderEvent(&order, cloud.NewServiceActor(cloud.QueueActorId))
+}
+
+func TestDispatch(t *testing.T) {
+	t.Run("Should deliver the signed event", func(t *testing.T) {
+		// Arrange
+		ctx := correlation.WithIds(context.Background(), "request-id", "correlation-id")
+		now := time.Now()
+
+		var received atomic.Bool
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			assert.Equal(t, cloud.OrderCreatedEventType, r.Header.Get(EventTypeHeader))
+			assert.Equal(t, strconv.FormatInt(now.Unix(), 10), r.Header.Get(TimestampHeader))
+			assert.True(t, Verify("secret", r.Header.Get(TimestampHeader), body, r.Header.Get(SignatureHeader)))
+			assert.Equal(t, "correlation-id", r.Header.Get(correlation.HeaderXCorrelationID))
+
+			received.Store(true)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		subscription := webhook_entity.NewSubscription("webhook-1", server.URL, nil, "secret", now)
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		repository.On("ListActiveSubscriptions", mock.Anything).
+			Return([]webhook_entity.Subscription{subscription}, nil).
+			Once()
+		repository.On("CreateDelivery", mock.Anything, mock.MatchedBy(func(delivery *webhook_entity.Delivery) bool {
+			return delivery.Success && delivery.Attempt == 1
+		})).
+			Return(nil).
+			Once()
+		repository.On("GetSubscriptionByID", mock.Anything, "webhook-1").
+			Return(subscription, nil).
+			Once()
+
+		dispatcher := newDispatcher(t, repository, now, 10)
+
+		// Act
+		dispatcher.Dispatch(ctx, newEvent(now))
+		err := dispatcher.Wait(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, received.Load())
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should skip subscriptions that do not accept the event", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		subscription := webhook_entity.NewSubscription("webhook-1", "http://localhost", []string{cloud.OrderCancelledEventType}, "secret", now)
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		repository.On("ListActiveSubscriptions", mock.Anything).
+			Return([]webhook_entity.Subscription{subscription}, nil).
+			Once()
+
+		dispatcher := newDispatcher(t, repository, now, 10)
+
+		// Act
+		dispatcher.Dispatch(ctx, newEvent(now))
+		err := dispatcher.Wait(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should retry and log every failed attempt", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		var calls atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		subscription := webhook_entity.NewSubscription("webhook-1", server.URL, nil, "secret", now)
+		subscription.ConsecutiveFailures = 2
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		repository.On("ListActiveSubscriptions", mock.Anything).
+			Return([]webhook_entity.Subscription{subscription}, nil).
+			Once()
+		repository.On("CreateDelivery", mock.Anything, mock.MatchedBy(func(delivery *webhook_entity.Delivery) bool {
+			return !delivery.Success && delivery.StatusCode == http.StatusServiceUnavailable
+		})).
+			Return(nil).
+			Twice()
+		repository.On("CreateDelivery", mock.Anything, mock.MatchedBy(func(delivery *webhook_entity.Delivery) bool {
+			return delivery.Success && delivery.Attempt == 3
+		})).
+			Return(nil).
+			Once()
+		repository.On("GetSubscriptionByID", mock.Anything, "webhook-1").
+			Return(subscription, nil).
+			Once()
+		repository.On("UpdateSubscription", mock.Anything, mock.MatchedBy(func(subscription *webhook_entity.Subscription) bool {
+			return subscription.ConsecutiveFailures == 0
+		})).
+			Return(nil).
+			Once()
+
+		dispatcher := newDispatcher(t, repository, now, 10)
+
+		// Act
+		dispatcher.Dispatch(ctx, newEvent(now))
+		err := dispatcher.Wait(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should disable the subscription when it keeps failing", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		subscription := webhook_entity.NewSubscription("webhook-1", server.URL, nil, "secret", now)
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		repository.On("ListActiveSubscriptions", mock.Anything).
+			Return([]webhook_entity.Subscription{subscription}, nil).
+			Once()
+		repository.On("CreateDelivery", mock.Anything, mock.Anything).
+			Return(nil).
+			Times(3)
+		repository.On("GetSubscriptionByID", mock.Anything, "webhook-1").
+			Return(subscription, nil).
+			Once()
+		repository.On("UpdateSubscription", mock.Anything, mock.MatchedBy(func(subscription *webhook_entity.Subscription) bool {
+			return !subscription.Active && subscription.ConsecutiveFailures == 1
+		})).
+			Return(nil).
+			Once()
+
+		dispatcher := newDispatcher(t, repository, now, 1)
+
+		// Act
+		dispatcher.Dispatch(ctx, newEvent(now))
+		err := dispatcher.Wait(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should not deliver when the subscriptions cannot be listed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		repository.On("ListActiveSubscriptions", mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		dispatcher := newDispatcher(t, repository, now, 10)
+
+		// Act
+		dispatcher.Dispatch(ctx, newEvent(now))
+		err := dispatcher.Wait(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Run("Should double the backoff up to the max", func(t *testing.T) {
+		// Arrange
+		dispatcher := &HttpDispatcher{
+			config: &environment.WebhookConfig{
+				InitialBackoff: time.Second,
+				MaxBackoff:     5 * time.Second,
+			},
+		}
+
+		// Act
+		first := dispatcher.backoff(1)
+		second := dispatcher.backoff(2)
+		third := dispatcher.backoff(3)
+		fourth := dispatcher.backoff(4)
+
+		// Assert
+		assert.Equal(t, time.Second, first)
+		assert.Equal(t, 2*time.Second, second)
+		assert.Equal(t, 4*time.Second, third)
+		assert.Equal(t, 5*time.Second, fourth)
+	})
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	t.Run("Should sign the timestamp and the body", func(t *testing.T) {
+		// Arrange
+		body := []byte(`{"id":"1"}`)
+
+		// Act
+		signature := Sign("secret", 1700000000, body)
+
+		// Assert
+		assert.Equal(t, "sha256=086f6aff7bd084c98679825129c5a64dbad88c760016d6d2c0fb123f27951d54", signature)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("Should verify a valid signature", func(t *testing.T) {
+		// Arrange
+		body := []byte(`{"id":"1"}`)
+		signature := Sign("secret", 1700000000, body)
+
+		// Act
+		res := Verify("secret", "1700000000", body, signature)
+
+		// Assert
+		assert.True(t, res)
+	})
+
+	t.Run("Should reject a signature made with another timestamp", func(t *testing.T) {
+		// Arrange
+		body := []byte(`{"id":"1"}`)
+		signature := Sign("secret", 1700000000, body)
+
+		// Act
+		res := Verify("secret", "1700000001", body, signature)
+
+		// Assert
+		assert.False(t, res)
+	})
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+)
+
+// TopicService publishes to the wrapped topic and delivers the order events
+// to the webhook subscriptions, so every publisher also feeds the webhooks
+type TopicService struct {
+	cloud.TopicService
+	dispatcher Dispatcher
+}
+
+func NewTopicService(topicService cloud.TopicService, dispatcher Dispatcher) cloud.TopicService {
+	return &TopicService{
+		TopicService: topicService,
+		dispatcher:   dispatcher,
+	}
+}
+
+func (s *TopicService) PublishMessage(ctx context.Context, message interface{}) (*string, error) {
+	messageId, err := s.TopicService.PublishMessage(ctx, message)
+
+	if event, ok := message.(*cloud.OrderEvent); ok {
+		s.dispatcher.Dispatch(ctx, event)
+	}
+
+	return messageId, err
+}
+
+func (s *TopicService) PublishBatch(ctx context.Context, messages []interface{}) ([]*string, error) {
+	messageIds, err := s.TopicService.PublishBatch(ctx, messages)
+
+	for _, message := range messages {
+		if event, ok := message.(*cloud.OrderEvent); ok {
+			s.dispatcher.Dispatch(ctx, event)
+		}
+	}
+
+	return messageIds, err
+}
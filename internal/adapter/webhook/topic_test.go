@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	cloud_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/webhook/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPublishMessage(t *testing.T) {
+	t.Run("Should publish and dispatch order events", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		event := newEvent(time.Now())
+		messageId := "1234"
+
+		topic := cloud_mocks.NewMockTopicService(t)
+		topic.On("PublishMessage", ctx, event).
+			Return(&messageId, nil).
+			Once()
+
+		dispatcher := mocks.NewMockDispatcher(t)
+		dispatcher.On("Dispatch", ctx, event).
+			Once()
+
+		service := NewTopicService(topic, dispatcher)
+
+		// Act
+		resp, err := service.PublishMessage(ctx, event)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", *resp)
+		topic.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("Should dispatch even when the publish fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		event := newEvent(time.Now())
+
+		topic := cloud_mocks.NewMockTopicService(t)
+		topic.On("PublishMessage", ctx, event).
+			Return(nil, assert.AnError).
+			Once()
+
+		dispatcher := mocks.NewMockDispatcher(t)
+		dispatcher.On("Dispatch", ctx, event).
+			Once()
+
+		service := NewTopicService(topic, dispatcher)
+
+		// Act
+		_, err := service.PublishMessage(ctx, event)
+
+		// Assert
+		assert.Error(t, err)
+		topic.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("Should not dispatch other messages", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		message := map[string]string{"message": "test"}
+		messageId := "1234"
+
+		topic := cloud_mocks.NewMockTopicService(t)
+		topic.On("PublishMessage", ctx, mock.Anything).
+			Return(&messageId, nil).
+			Once()
+
+		dispatcher := mocks.NewMockDispatcher(t)
+
+		service := NewTopicService(topic, dispatcher)
+
+		// Act
+		_, err := service.PublishMessage(ctx, message)
+
+		// Assert
+		assert.NoError(t, err)
+		topic.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+}
+
+func TestPublishBatch(t *testing.T) {
+	t.Run("Should publish the batch and dispatch the order events", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		event := newEvent(time.Now())
+		messages := []interface{}{event, map[string]string{"message": "test"}}
+		messageId := "1234"
+
+		topic := cloud_mocks.NewMockTopicService(t)
+		topic.On("PublishBatch", ctx, messages).
+			Return([]*string{&messageId, nil}, assert.AnError).
+			Once()
+
+		dispatcher := mocks.NewMockDispatcher(t)
+		dispatcher.On("Dispatch", ctx, event).
+			Once()
+
+		service := NewTopicService(topic, dispatcher)
+
+		// Act
+		resp, err := service.PublishBatch(ctx, messages)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Len(t, resp, 2)
+		topic.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+}
+
+var _ cloud.TopicService = (*TopicService)(nil)
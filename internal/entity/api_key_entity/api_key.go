@@ -0,0 +1,158 @@
+package api_key_entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// KeyScheme starts every key, so leaked keys are easy to find by the
+	// secret scanners
+	KeyScheme = "pmk"
+
+	prefixSize = 6
+	secretSize = 32
+)
+
+// ApiKey authenticates a machine client. Only the hash of the key is stored,
+// the key itself is returned once, when it is created or rotated
+type ApiKey struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	// Prefix is the public part of the key, used to find it and to tell the
+	// keys apart in the lists
+	Prefix string   `json:"prefix"`
+	Hash   string   `json:"-"`
+	Scopes []string `json:"scopes"`
+	Key    string   `json:"key,omitempty"`
+
+	// ReplacedBy is the key created by the rotation of this one
+	ReplacedBy *string    `json:"replaced_by,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewApiKey generates a random key, it is only available in the Key field of
+// the returned value
+func NewApiKey(id string, name string, scopes []string, expiresAt *time.Time, now time.Time) (ApiKey, error) {
+	prefix, err := randomHex(prefixSize)
+	if err != nil {
+		return ApiKey{}, err
+	}
+
+	secret, err := randomHex(secretSize)
+	if err != nil {
+		return ApiKey{}, err
+	}
+
+	if scopes == nil {
+		scopes = make([]string, 0)
+	}
+
+	key := fmt.Sprintf("%s_%s_%s", KeyScheme, prefix, secret)
+
+	return ApiKey{
+		Id:     id,
+		Name:   name,
+		Prefix: prefix,
+		Hash:   HashKey(key),
+		Scopes: scopes,
+		Key:    key,
+
+		ExpiresAt: expiresAt,
+
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ParsePrefix returns the prefix of a key, false when the key does not have
+// the format of the generated keys
+func ParsePrefix(key string) (string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != KeyScheme || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Matches compares the key with the stored hash in constant time
+func (k *ApiKey) Matches(key string) bool {
+	return subtle.ConstantTimeCompare([]byte(k.Hash), []byte(HashKey(key))) == 1
+}
+
+// IsActive reports if the key can be used, it is neither revoked nor expired
+func (k *ApiKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+func (k *ApiKey) Revoke(now time.Time) {
+	if k.RevokedAt != nil {
+		return
+	}
+
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+}
+
+// ReplaceWith keeps the key valid until the end of the overlap, so the clients
+// have time to switch to the new key, an earlier expiration is kept
+func (k *ApiKey) ReplaceWith(newKeyId string, overlap time.Duration, now time.Time) {
+	expiresAt := now.Add(overlap)
+
+	if k.ExpiresAt == nil || expiresAt.Before(*k.ExpiresAt) {
+		k.ExpiresAt = &expiresAt
+	}
+
+	k.ReplacedBy = &newKeyId
+	k.UpdatedAt = now
+}
+
+// NeedsTouch reports if the last use is older than the precision, so the key
+// is not written on every request
+func (k *ApiKey) NeedsTouch(precision time.Duration, now time.Time) bool {
+	return k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= precision
+}
+
+func (k *ApiKey) Touch(now time.Time) {
+	k.LastUsedAt = &now
+}
+
+func (k *ApiKey) HideKey() {
+	k.Key = ""
+}
+
+// Snapshot returns a copy of the key without the key, to be kept in the audit
+// log
+func (k ApiKey) Snapshot() ApiKey {
+	k.HideKey()
+	return k
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
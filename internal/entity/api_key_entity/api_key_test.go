@@ -0,0 +1,153 @@
+package api_key_entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewApiKey(t *testing.T) {
+	t.Run("Should generate a key that matches the stored hash", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		// Act
+		apiKey, err := NewApiKey(uuid.NewString(), "billing", []string{"service"}, nil, now)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(apiKey.Key, "pmk_"+apiKey.Prefix+"_"))
+		assert.NotContains(t, apiKey.Hash, apiKey.Key)
+		assert.True(t, apiKey.Matches(apiKey.Key))
+		assert.False(t, apiKey.Matches(apiKey.Key+"x"))
+		assert.Equal(t, []string{"service"}, apiKey.Scopes)
+	})
+
+	t.Run("Should generate different keys", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		// Act
+		first, err1 := NewApiKey(uuid.NewString(), "billing", nil, nil, now)
+		second, err2 := NewApiKey(uuid.NewString(), "billing", nil, nil, now)
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.NotEqual(t, first.Key, second.Key)
+		assert.NotEqual(t, first.Prefix, second.Prefix)
+		assert.NotNil(t, first.Scopes)
+	})
+}
+
+func TestParsePrefix(t *testing.T) {
+	t.Run("Should return the prefix of the key", func(t *testing.T) {
+		// Arrange
+		// Act
+		prefix, ok := ParsePrefix("pmk_abc123_secret")
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, "abc123", prefix)
+	})
+
+	t.Run("Should not parse the keys with other formats", func(t *testing.T) {
+		for _, key := range []string{"", "pmk", "pmk_abc", "pmk__secret", "pmk_abc_", "xyz_abc_secret", "pmk_a_b_c"} {
+			// Arrange
+			// Act
+			_, ok := ParsePrefix(key)
+
+			// Assert
+			assert.False(t, ok, key)
+		}
+	})
+}
+
+func TestIsActive(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Should be active without expiration", func(t *testing.T) {
+		// Arrange
+		apiKey, _ := NewApiKey(uuid.NewString(), "billing", nil, nil, now)
+
+		// Act
+		// Assert
+		assert.True(t, apiKey.IsActive(now))
+	})
+
+	t.Run("Should not be active after the expiration", func(t *testing.T) {
+		// Arrange
+		expiresAt := now.Add(time.Hour)
+		apiKey, _ := NewApiKey(uuid.NewString(), "billing", nil, &expiresAt, now)
+
+		// Act
+		// Assert
+		assert.True(t, apiKey.IsActive(now))
+		assert.False(t, apiKey.IsActive(expiresAt))
+	})
+
+	t.Run("Should not be active when revoked", func(t *testing.T) {
+		// Arrange
+		apiKey, _ := NewApiKey(uuid.NewString(), "billing", nil, nil, now)
+
+		// Act
+		apiKey.Revoke(now)
+
+		// Assert
+		assert.False(t, apiKey.IsActive(now))
+		assert.Equal(t, now, *apiKey.RevokedAt)
+	})
+}
+
+func TestReplaceWith(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Should expire the key at the end of the overlap", func(t *testing.T) {
+		// Arrange
+		apiKey, _ := NewApiKey(uuid.NewString(), "billing", nil, nil, now)
+		newId := uuid.NewString()
+
+		// Act
+		apiKey.ReplaceWith(newId, time.Hour, now)
+
+		// Assert
+		assert.Equal(t, now.Add(time.Hour), *apiKey.ExpiresAt)
+		assert.Equal(t, newId, *apiKey.ReplacedBy)
+		assert.True(t, apiKey.IsActive(now.Add(time.Minute)))
+		assert.False(t, apiKey.IsActive(now.Add(time.Hour)))
+	})
+
+	t.Run("Should keep an earlier expiration", func(t *testing.T) {
+		// Arrange
+		expiresAt := now.Add(time.Minute)
+		apiKey, _ := NewApiKey(uuid.NewString(), "billing", nil, &expiresAt, now)
+
+		// Act
+		apiKey.ReplaceWith(uuid.NewString(), time.Hour, now)
+
+		// Assert
+		assert.Equal(t, expiresAt, *apiKey.ExpiresAt)
+	})
+}
+
+func TestNeedsTouch(t *testing.T) {
+	t.Run("Should touch the key once per precision", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		apiKey, _ := NewApiKey(uuid.NewString(), "billing", nil, nil, now)
+
+		// Act
+		first := apiKey.NeedsTouch(time.Minute, now)
+		apiKey.Touch(now)
+		second := apiKey.NeedsTouch(time.Minute, now.Add(time.Second))
+		third := apiKey.NeedsTouch(time.Minute, now.Add(time.Minute))
+
+		// Assert
+		assert.True(t, first)
+		assert.False(t, second)
+		assert.True(t, third)
+	})
+}
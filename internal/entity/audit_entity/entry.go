@@ -0,0 +1,153 @@
+package audit_entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+)
+
+const (
+	OrderCreatedAction      = "order.created"
+	OrderReconciledAction   = "order.reconciled"
+	OrderStateChangedAction = "order.state_changed"
+	OrderCancelledAction    = "order.cancelled"
+
+	WebhookCreatedAction = "webhook.created"
+	WebhookUpdatedAction = "webhook.updated"
+	WebhookDeletedAction = "webhook.deleted"
+
+	ApiKeyCreatedAction = "api_key.created"
+	ApiKeyRotatedAction = "api_key.rotated"
+	ApiKeyRevokedAction = "api_key.revoked"
+
+	DeadLetterRedrivenAction = "dead_letter.redriven"
+
+	LogLevelChangedAction = "log_level.changed"
+)
+
+const (
+	OrderResource             = "order"
+	WebhookResource           = "webhook"
+	ApiKeyResource            = "api_key"
+	DeadLetterMessageResource = "dead_letter_message"
+	LogLevelResource          = "log_level"
+)
+
+const (
+	UserActor     = "user"
+	ApiKeyActor   = "api_key"
+	ServiceActor  = "service"
+	OperatorActor = "operator"
+	// UnknownActor is the author of the changes made without an authenticated
+	// context, it should never be seen
+	UnknownActor = "unknown"
+)
+
+const (
+	HttpSource  = "http"
+	GrpcSource  = "grpc"
+	QueueSource = "queue"
+	CliSource   = "cli"
+)
+
+// OrderUpdateAction returns the action of the update of an order to the state
+func OrderUpdateAction(state order_entity.OrderState) string {
+	if state == order_entity.Cancelled {
+		return OrderCancelledAction
+	}
+
+	return OrderStateChangedAction
+}
+
+// Actor is the author of a change
+type Actor struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+// Source is where the change came from, MessageId is the id of the queue
+// message and RequestId the id of the HTTP or gRPC request
+type Source struct {
+	Type      string `json:"type"`
+	MessageId string `json:"message_id,omitempty"`
+	RequestId string `json:"request_id,omitempty"`
+}
+
+// Entry records a change, the entries are never updated nor deleted. Before
+// is empty for the created resources and After for the deleted ones
+type Entry struct {
+	Id           int64           `json:"id"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceId   string          `json:"resource_id"`
+	Actor        Actor           `json:"actor"`
+	Source       Source          `json:"source"`
+	Before       json.RawMessage `json:"before,omitempty"`
+	After        json.RawMessage `json:"after,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// NewEntry snapshots before and after as JSON, a nil value is not stored
+func NewEntry(
+	action string,
+	resourceType string,
+	resourceId string,
+	actor Actor,
+	source Source,
+	before interface{},
+	after interface{},
+	now time.Time,
+) (Entry, error) {
+	beforeSnapshot, err := snapshot(before)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	afterSnapshot, err := snapshot(after)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	return Entry{
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		Actor:        actor,
+		Source:       source,
+		Before:       beforeSnapshot,
+		After:        afterSnapshot,
+		CreatedAt:    now,
+	}, nil
+}
+
+func snapshot(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// typed nil pointers are marshaled as null
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	return data, nil
+}
+
+// Filter selects the entries, newest first. The empty fields are ignored and
+// BeforeId pages through the entries older than the last one received
+type Filter struct {
+	Action       string
+	ResourceType string
+	ResourceId   string
+	ActorId      string
+	From         *time.Time
+	To           *time.Time
+	BeforeId     int64
+	Limit        int
+}
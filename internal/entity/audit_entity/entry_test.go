@@ -0,0 +1,75 @@
+package audit_entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/stretchr/testify/assert"
+)
+
+type resource struct {
+	Id    string `json:"id"`
+	State string `json:"state"`
+}
+
+func TestNewEntry(t *testing.T) {
+	t.Run("Should snapshot the resource before and after the change", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		actor := Actor{Type: UserActor, Id: "user-id"}
+		source := Source{Type: HttpSource, RequestId: "request-id"}
+
+		// Act
+		entry, err := NewEntry(
+			OrderStateChangedAction,
+			OrderResource,
+			"order-id",
+			actor,
+			source,
+			resource{Id: "order-id", State: "Completed"},
+			&resource{Id: "order-id", State: "Delivered"},
+			now,
+		)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, actor, entry.Actor)
+		assert.Equal(t, source, entry.Source)
+		assert.JSONEq(t, `{"id":"order-id","state":"Completed"}`, string(entry.Before))
+		assert.JSONEq(t, `{"id":"order-id","state":"Delivered"}`, string(entry.After))
+		assert.Equal(t, now, entry.CreatedAt)
+	})
+
+	t.Run("Should not snapshot the nil values", func(t *testing.T) {
+		// Arrange
+		var deleted *resource
+
+		// Act
+		entry, err := NewEntry(OrderCreatedAction, OrderResource, "order-id", Actor{}, Source{}, nil, deleted, time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, entry.Before)
+		assert.Nil(t, entry.After)
+	})
+
+	t.Run("Should return error when the value cannot be marshaled", func(t *testing.T) {
+		// Arrange
+		// Act
+		_, err := NewEntry(OrderCreatedAction, OrderResource, "order-id", Actor{}, Source{}, nil, make(chan int), time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestOrderUpdateAction(t *testing.T) {
+	t.Run("Should return the cancelled action only for the cancelled orders", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.Equal(t, OrderCancelledAction, OrderUpdateAction(order_entity.Cancelled))
+		assert.Equal(t, OrderStateChangedAction, OrderUpdateAction(order_entity.Delivered))
+	})
+}
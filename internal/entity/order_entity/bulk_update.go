@@ -0,0 +1,29 @@
+package order_entity
+
+import "github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+
+// BulkUpdateResult is the outcome of one order in a bulk state update
+type BulkUpdateResult struct {
+	OrderId string                 `json:"order_id"`
+	Success bool                   `json:"success"`
+	Order   *Order                 `json:"order,omitempty"`
+	Error   *custom_error.AppError `json:"error,omitempty"`
+}
+
+func NewBulkUpdateSuccess(order *Order) BulkUpdateResult {
+	return BulkUpdateResult{
+		OrderId: order.Id,
+		Success: true,
+		Order:   order,
+	}
+}
+
+func NewBulkUpdateFailure(orderId string, err error) BulkUpdateResult {
+	appErr := custom_error.NewAppError(err)
+
+	return BulkUpdateResult{
+		OrderId: orderId,
+		Success: false,
+		Error:   &appErr,
+	}
+}
@@ -0,0 +1,37 @@
+package order_entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkUpdateResult(t *testing.T) {
+	t.Run("Should create a success result", func(t *testing.T) {
+		// Arrange
+		order := NewOrder("order-1", time.Now())
+
+		// Act
+		res := NewBulkUpdateSuccess(&order)
+
+		// Assert
+		assert.True(t, res.Success)
+		assert.Equal(t, "order-1", res.OrderId)
+		assert.Equal(t, &order, res.Order)
+		assert.Nil(t, res.Error)
+	})
+
+	t.Run("Should create a failure result with the business error", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := NewBulkUpdateFailure("order-1", custom_error.ErrOrderInvalidStateTransition)
+
+		// Assert
+		assert.False(t, res.Success)
+		assert.Nil(t, res.Order)
+		assert.Equal(t, 400, res.Error.Code)
+		assert.Equal(t, "invalid state transition", res.Error.Details)
+	})
+}
@@ -0,0 +1,11 @@
+package order_entity
+
+import "time"
+
+// ExportFilter selects the orders created in [From, To), at any of the
+// states or at every state when none is informed
+type ExportFilter struct {
+	From   time.Time
+	To     time.Time
+	States []OrderState
+}
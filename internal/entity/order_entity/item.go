@@ -4,6 +4,12 @@ type Item struct {
 	Id       string `json:"id"`
 	Name     string `json:"name"`
 	Quantity int    `json:"quantity"`
+
+	// Station is the kitchen station that prepares the item, it is optional
+	Station string `json:"station,omitempty"`
+
+	// Modifiers are the changes asked by the customer, e.g. "no onions"
+	Modifiers []string `json:"modifiers,omitempty"`
 }
 
 func NewItem(id string, name string, quantity int) Item {
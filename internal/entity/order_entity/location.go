@@ -0,0 +1,31 @@
+package order_entity
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimezone is the timezone of the orders when none is configured
+const DefaultTimezone = "America/Sao_Paulo"
+
+var location atomic.Pointer[time.Location]
+
+func init() {
+	loc, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	location.Store(loc)
+}
+
+// Location is the timezone the times of the orders are presented in
+func Location() *time.Location {
+	return location.Load()
+}
+
+// SetLocation changes the timezone of the orders, it is called once when the
+// application starts with the configured timezone
+func SetLocation(loc *time.Location) {
+	location.Store(loc)
+}
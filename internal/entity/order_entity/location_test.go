@@ -0,0 +1,38 @@
+package order_entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocation(t *testing.T) {
+	t.Run("Should use the default timezone", func(t *testing.T) {
+		// Arrange
+
+		// Act
+		res := Location()
+
+		// Assert
+		assert.Equal(t, DefaultTimezone, res.String())
+	})
+
+	t.Run("Should present the orders in the configured timezone", func(t *testing.T) {
+		// Arrange
+		loc, err := time.LoadLocation("Europe/Lisbon")
+		assert.NoError(t, err)
+
+		previous := Location()
+		SetLocation(loc)
+		defer SetLocation(previous)
+
+		order := NewOrder("order_id", time.Now().UTC())
+
+		// Act
+		order.UpdateTimezone()
+
+		// Assert
+		assert.Equal(t, loc, order.CreatedAt.Location())
+	})
+}
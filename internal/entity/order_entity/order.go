@@ -1,6 +1,7 @@
 package order_entity
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
@@ -13,8 +14,21 @@ type Order struct {
 	StateTitle     string     `json:"state_title"`
 	StateUpdatedAt time.Time  `json:"state_updated_at"`
 
+	// AllowedTransitions are the titles of the states the order can move to,
+	// so the clients do not duplicate the state machine
+	AllowedTransitions []string `json:"allowed_transitions"`
+
+	// PreviousState is the state before the last transition made in memory,
+	// it is not persisted
+	PreviousState OrderState `json:"-"`
+
 	Items []Item `json:"items"`
 
+	Origin    OrderOrigin `json:"origin"`
+	CreatedBy string      `json:"created_by,omitempty"`
+	// ReconciledAt is when a manual order was received through the queue
+	ReconciledAt *time.Time `json:"reconciled_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -28,6 +42,8 @@ func NewOrder(orderID string, now time.Time) Order {
 
 		Items: make([]Item, 0),
 
+		Origin: QueueOrigin,
+
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -55,33 +71,78 @@ func (o *Order) UpdateState(toState OrderState, now time.Time) error {
 		return custom_error.ErrOrderInvalidStateTransition
 	}
 
+	o.PreviousState = o.State
 	o.State = toState
 	o.StateTitle = toState.String()
+	o.AllowedTransitions = toState.AllowedTransitionTitles()
 	o.StateUpdatedAt = now
 	o.UpdatedAt = now
 
 	return nil
 }
 
+// Version identifies the saved state of the order, every write changes the
+// UpdatedAt
+func (o Order) Version() string {
+	return fmt.Sprintf("%s@%d", o.Id, o.UpdatedAt.UnixNano())
+}
+
+// RefreshStateTitle fills the fields derived from the state, the title and
+// the allowed transitions
 func (o *Order) RefreshStateTitle() {
 	o.StateTitle = o.State.String()
+	o.AllowedTransitions = o.State.AllowedTransitionTitles()
 }
 
 func (o *Order) IsCompleted() bool {
 	return o.State == Delivered || o.State == Cancelled
 }
 
+// HasStation reports if any item of the order is prepared at the station
+func (o *Order) HasStation(station string) bool {
+	for _, item := range o.Items {
+		if item.Station == station {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (o *Order) HasItems() bool {
 	return len(o.Items) > 0
 }
 
+// SetManualOrigin marks the order as created by the user instead of received
+// through the queue
+func (o *Order) SetManualOrigin(userId string) {
+	o.Origin = ManualOrigin
+	o.CreatedBy = userId
+}
+
+// NeedsReconciliation reports if the order was created manually and was not
+// received through the queue yet
+func (o *Order) NeedsReconciliation() bool {
+	return o.Origin == ManualOrigin && o.ReconciledAt == nil
+}
+
+func (o *Order) Reconcile(now time.Time) {
+	o.ReconciledAt = &now
+	o.UpdatedAt = now
+}
+
 func (o *Order) Exists() bool {
 	return o.Id != ""
 }
 
 func (o *Order) UpdateTimezone() {
-	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	loc := Location()
 	o.StateUpdatedAt = o.StateUpdatedAt.In(loc)
 	o.CreatedAt = o.CreatedAt.In(loc)
 	o.UpdatedAt = o.UpdatedAt.In(loc)
+
+	if o.ReconciledAt != nil {
+		reconciledAt := o.ReconciledAt.In(loc)
+		o.ReconciledAt = &reconciledAt
+	}
 }
@@ -0,0 +1,39 @@
+package order_entity
+
+import "time"
+
+const (
+	OrderCreatedEvent   = "created"
+	OrderUpdatedEvent   = "updated"
+	OrderCancelledEvent = "cancelled"
+)
+
+// OrderEvent is a snapshot of the order saved on every write, its id is
+// sequential so clients can resume the stream from the last event received
+type OrderEvent struct {
+	Id        int64     `json:"id"`
+	Type      string    `json:"type"`
+	Order     Order     `json:"order"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewOrderEvent(order Order, now time.Time) OrderEvent {
+	order.RefreshStateTitle()
+
+	return OrderEvent{
+		Type:      orderEventType(order.State),
+		Order:     order,
+		CreatedAt: now,
+	}
+}
+
+func orderEventType(state OrderState) string {
+	switch state {
+	case Received:
+		return OrderCreatedEvent
+	case Cancelled:
+		return OrderCancelledEvent
+	default:
+		return OrderUpdatedEvent
+	}
+}
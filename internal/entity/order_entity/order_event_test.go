@@ -0,0 +1,52 @@
+package order_entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrderEvent(t *testing.T) {
+	t.Run("Should create an event for a new order", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		order := NewOrder("order_id", now)
+
+		// Act
+		res := NewOrderEvent(order, now)
+
+		// Assert
+		assert.Equal(t, OrderCreatedEvent, res.Type)
+		assert.Equal(t, "Received", res.Order.StateTitle)
+		assert.Equal(t, now, res.CreatedAt)
+	})
+
+	t.Run("Should create an event for an updated order", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		order := NewOrder("order_id", now)
+		err := order.UpdateState(Processing, now)
+		assert.NoError(t, err)
+
+		// Act
+		res := NewOrderEvent(order, now)
+
+		// Assert
+		assert.Equal(t, OrderUpdatedEvent, res.Type)
+	})
+
+	t.Run("Should create an event for a cancelled order", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		order := NewOrder("order_id", now)
+		err := order.UpdateState(Cancelled, now)
+		assert.NoError(t, err)
+
+		// Act
+		res := NewOrderEvent(order, now)
+
+		// Assert
+		assert.Equal(t, OrderCancelledEvent, res.Type)
+	})
+}
@@ -0,0 +1,15 @@
+package order_entity
+
+type OrderOrigin string
+
+const (
+	// QueueOrigin is an order received from the order service through the queue
+	QueueOrigin OrderOrigin = "queue"
+	// ManualOrigin is an order created by the kitchen staff, for walk-in
+	// customers or when the order pipeline is down
+	ManualOrigin OrderOrigin = "manual"
+)
+
+func (o OrderOrigin) IsValid() bool {
+	return o == QueueOrigin || o == ManualOrigin
+}
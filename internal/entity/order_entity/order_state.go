@@ -1,5 +1,12 @@
 package order_entity
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+)
+
 type OrderState int
 
 const (
@@ -18,20 +25,34 @@ var (
 		Processing: {Completed, Cancelled},
 		Completed:  {Delivered},
 	}
+
+	// order_state_titles are the states accepted in the requests
+	order_state_titles = []OrderState{Received, Processing, Completed, Delivered, Cancelled}
 )
 
 func NewOrderState(title string) OrderState {
-	state, ok := map[string]OrderState{
-		"Received":   Received,
-		"Processing": Processing,
-		"Completed":  Completed,
-		"Delivered":  Delivered,
-	}[title]
-	if !ok {
-		return None
+	for _, state := range order_state_titles {
+		if state.String() == title {
+			return state
+		}
 	}
 
-	return state
+	return None
+}
+
+// NewStateViolation reports a field of the request that is not a state
+// accepted by NewOrderState
+func NewStateViolation(field string) custom_error.Violation {
+	titles := make([]string, 0, len(order_state_titles))
+	for _, state := range order_state_titles {
+		titles = append(titles, state.String())
+	}
+
+	return custom_error.Violation{
+		Field:   field,
+		Rule:    "state",
+		Message: fmt.Sprintf("must be one of: %s", strings.Join(titles, ", ")),
+	}
 }
 
 func (s OrderState) CanTransitionTo(to OrderState) bool {
@@ -62,3 +83,29 @@ func (s OrderState) String() string {
 func IsValidState(s OrderState) bool {
 	return s >= Received && s <= Cancelled
 }
+
+// AllowedTransitions lists the states the order can move to from s, a final
+// state has none
+func (s OrderState) AllowedTransitions() []OrderState {
+	allowed := make([]OrderState, 0)
+
+	for to := Received; to <= Cancelled; to++ {
+		if s.CanTransitionTo(to) {
+			allowed = append(allowed, to)
+		}
+	}
+
+	return allowed
+}
+
+// AllowedTransitionTitles is AllowedTransitions with the title of the states
+func (s OrderState) AllowedTransitionTitles() []string {
+	allowed := s.AllowedTransitions()
+
+	titles := make([]string, 0, len(allowed))
+	for _, state := range allowed {
+		titles = append(titles, state.String())
+	}
+
+	return titles
+}
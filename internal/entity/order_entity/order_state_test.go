@@ -145,3 +145,39 @@ func TestString(t *testing.T) {
 		assert.Equal(t, "Unknown", res)
 	})
 }
+
+func TestAllowedTransitions(t *testing.T) {
+	t.Run("Should return the states the order can move to", func(t *testing.T) {
+		// Arrange
+		cases := []struct {
+			from     OrderState
+			expected []OrderState
+		}{
+			{None, []OrderState{Received}},
+			{Received, []OrderState{Processing, Cancelled}},
+			{Processing, []OrderState{Completed, Cancelled}},
+			{Completed, []OrderState{Delivered}},
+			{Delivered, []OrderState{}},
+			{Cancelled, []OrderState{}},
+		}
+
+		for _, c := range cases {
+			// Act
+			res := c.from.AllowedTransitions()
+
+			// Assert
+			assert.Equal(t, c.expected, res, c.from.String())
+		}
+	})
+
+	t.Run("Should return the titles of the states", func(t *testing.T) {
+		// Arrange
+		state := Received
+
+		// Act
+		res := state.AllowedTransitionTitles()
+
+		// Assert
+		assert.Equal(t, []string{"Processing", "Cancelled"}, res)
+	})
+}
@@ -23,6 +23,7 @@ func TestOrder(t *testing.T) {
 		assert.Empty(t, res.Items)
 		assert.Equal(t, now, res.CreatedAt)
 		assert.Equal(t, now, res.UpdatedAt)
+		assert.Equal(t, QueueOrigin, res.Origin)
 	})
 
 	t.Run("Should add an item to the order", func(t *testing.T) {
@@ -59,6 +60,8 @@ func TestOrder(t *testing.T) {
 		// Assert
 		assert.NoError(t, err)
 		assert.Equal(t, Processing, order.State)
+		assert.Equal(t, Received, order.PreviousState)
+		assert.Equal(t, []string{"Completed", "Cancelled"}, order.AllowedTransitions)
 		assert.Equal(t, now, order.StateUpdatedAt)
 		assert.Equal(t, now, order.UpdatedAt)
 	})
@@ -105,6 +108,7 @@ func TestOrder(t *testing.T) {
 
 		// Assert
 		assert.Equal(t, "Received", order.StateTitle)
+		assert.Equal(t, []string{"Processing", "Cancelled"}, order.AllowedTransitions)
 	})
 
 	t.Run("Should return true if the order is already completed", func(t *testing.T) {
@@ -194,4 +198,63 @@ func TestOrder(t *testing.T) {
 		assert.Equal(t, now.In(loc), order.UpdatedAt)
 		assert.Equal(t, now.In(loc), order.StateUpdatedAt)
 	})
+
+	t.Run("Should report if the order has items of the station", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		order := NewOrder("order_id", now)
+
+		item := NewItem("item_id", "Hamburger", 1)
+		item.Station = "grill"
+
+		err := order.AddItem(item, now)
+		assert.NoError(t, err)
+
+		// Act
+		grill := order.HasStation("grill")
+		drinks := order.HasStation("drinks")
+
+		// Assert
+		assert.True(t, grill)
+		assert.False(t, drinks)
+	})
+	t.Run("Should mark the order as manual", func(t *testing.T) {
+		// Arrange
+		order := NewOrder("order_id", time.Now())
+
+		// Act
+		order.SetManualOrigin("user-1")
+
+		// Assert
+		assert.Equal(t, ManualOrigin, order.Origin)
+		assert.Equal(t, "user-1", order.CreatedBy)
+		assert.True(t, order.NeedsReconciliation())
+	})
+
+	t.Run("Should reconcile a manual order", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		order := NewOrder("order_id", now.Add(-time.Minute))
+		order.SetManualOrigin("user-1")
+
+		// Act
+		order.Reconcile(now)
+
+		// Assert
+		assert.False(t, order.NeedsReconciliation())
+		assert.Equal(t, now, *order.ReconciledAt)
+		assert.Equal(t, now, order.UpdatedAt)
+	})
+
+	t.Run("Should not need reconciliation when the order came from the queue", func(t *testing.T) {
+		// Arrange
+		order := NewOrder("order_id", time.Now())
+
+		// Act
+		res := order.NeedsReconciliation()
+
+		// Assert
+		assert.False(t, res)
+	})
 }
@@ -0,0 +1,56 @@
+package order_entity
+
+import (
+	"strings"
+	"time"
+)
+
+const displayCodeLength = 6
+
+// PickupBoard is the public view of the orders waiting at the counter, it
+// only exposes the display code of each order
+type PickupBoard struct {
+	Preparing []PickupBoardOrder `json:"preparing"`
+	Ready     []PickupBoardOrder `json:"ready"`
+}
+
+type PickupBoardOrder struct {
+	Code  string    `json:"code"`
+	Since time.Time `json:"since"`
+}
+
+// NewPickupBoard splits the orders in preparing (Processing) and ready
+// (Completed), orders in other states are ignored
+func NewPickupBoard(orders []Order) PickupBoard {
+	board := PickupBoard{
+		Preparing: make([]PickupBoardOrder, 0),
+		Ready:     make([]PickupBoardOrder, 0),
+	}
+
+	for _, order := range orders {
+		entry := PickupBoardOrder{
+			Code:  order.DisplayCode(),
+			Since: order.StateUpdatedAt,
+		}
+
+		switch order.State {
+		case Processing:
+			board.Preparing = append(board.Preparing, entry)
+		case Completed:
+			board.Ready = append(board.Ready, entry)
+		}
+	}
+
+	return board
+}
+
+// DisplayCode is the short code called at the counter, the first characters
+// of the order id
+func (o *Order) DisplayCode() string {
+	code := strings.ToUpper(strings.ReplaceAll(o.Id, "-", ""))
+	if len(code) > displayCodeLength {
+		code = code[:displayCodeLength]
+	}
+
+	return code
+}
@@ -0,0 +1,62 @@
+package order_entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickupBoard(t *testing.T) {
+	t.Run("Should split the orders in preparing and ready", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		orders := []Order{
+			{Id: "c3fdab1b-3c06-4db2-9edc-4760a2429462", State: Processing, StateUpdatedAt: now, Items: []Item{{Name: "Hamburger"}}},
+			{Id: "0a1b2c3d-3c06-4db2-9edc-4760a2429462", State: Completed, StateUpdatedAt: now},
+			{Id: "ffffffff-3c06-4db2-9edc-4760a2429462", State: Delivered, StateUpdatedAt: now},
+		}
+
+		// Act
+		res := NewPickupBoard(orders)
+
+		// Assert
+		assert.Equal(t, []PickupBoardOrder{{Code: "C3FDAB", Since: now}}, res.Preparing)
+		assert.Equal(t, []PickupBoardOrder{{Code: "0A1B2C", Since: now}}, res.Ready)
+	})
+
+	t.Run("Should return empty lists when there are no orders", func(t *testing.T) {
+		// Arrange
+		// Act
+		res := NewPickupBoard(nil)
+
+		// Assert
+		assert.NotNil(t, res.Preparing)
+		assert.NotNil(t, res.Ready)
+	})
+}
+
+func TestDisplayCode(t *testing.T) {
+	t.Run("Should return the first characters of the id", func(t *testing.T) {
+		// Arrange
+		order := Order{Id: "c3-fdab1b"}
+
+		// Act
+		res := order.DisplayCode()
+
+		// Assert
+		assert.Equal(t, "C3FDAB", res)
+	})
+
+	t.Run("Should return the whole id when it is short", func(t *testing.T) {
+		// Arrange
+		order := Order{Id: "a-1"}
+
+		// Act
+		res := order.DisplayCode()
+
+		// Assert
+		assert.Equal(t, "A1", res)
+	})
+}
@@ -0,0 +1,111 @@
+package order_entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+var order_state_descriptions = map[OrderState]string{
+	Received:   "The order is received and is ready to be processed by the kitchen",
+	Processing: "The order is being processed by the kitchen",
+	Completed:  "The order is completed and ready to be delivered",
+	Delivered:  "The order is delivered to the customer",
+	Cancelled:  "The order is cancelled",
+}
+
+// StateDescription describes a state and the states it can move to
+type StateDescription struct {
+	Value       OrderState `json:"value"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Final       bool       `json:"final"`
+	Transitions []string   `json:"transitions"`
+}
+
+// StateTransition is an edge of the state machine
+type StateTransition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// StateMachine describes every state of the orders and their transitions
+type StateMachine struct {
+	Initial string             `json:"initial"`
+	States  []StateDescription `json:"states"`
+	Edges   []StateTransition  `json:"edges"`
+}
+
+func NewStateMachine() StateMachine {
+	machine := StateMachine{
+		Initial: None.AllowedTransitionTitles()[0],
+		States:  make([]StateDescription, 0),
+		Edges:   make([]StateTransition, 0),
+	}
+
+	for state := Received; state <= Cancelled; state++ {
+		transitions := state.AllowedTransitionTitles()
+
+		machine.States = append(machine.States, StateDescription{
+			Value:       state,
+			Title:       state.String(),
+			Description: order_state_descriptions[state],
+			Final:       len(transitions) == 0,
+			Transitions: transitions,
+		})
+
+		for _, to := range transitions {
+			machine.Edges = append(machine.Edges, StateTransition{
+				From: state.String(),
+				To:   to,
+			})
+		}
+	}
+
+	return machine
+}
+
+// Mermaid renders the state machine as a Mermaid state diagram
+func (m StateMachine) Mermaid() string {
+	var builder strings.Builder
+
+	builder.WriteString("stateDiagram-v2\n")
+	fmt.Fprintf(&builder, "    [*] --> %s\n", m.Initial)
+
+	for _, edge := range m.Edges {
+		fmt.Fprintf(&builder, "    %s --> %s\n", edge.From, edge.To)
+	}
+
+	for _, state := range m.States {
+		if state.Final {
+			fmt.Fprintf(&builder, "    %s --> [*]\n", state.Title)
+		}
+	}
+
+	return builder.String()
+}
+
+// Dot renders the state machine as a Graphviz DOT digraph
+func (m StateMachine) Dot() string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph OrderState {\n")
+	builder.WriteString("    rankdir=LR;\n")
+	builder.WriteString("    node [shape=box, style=rounded];\n")
+	builder.WriteString("    start [shape=point];\n")
+
+	for _, state := range m.States {
+		if state.Final {
+			fmt.Fprintf(&builder, "    %s [peripheries=2];\n", state.Title)
+		}
+	}
+
+	fmt.Fprintf(&builder, "    start -> %s;\n", m.Initial)
+
+	for _, edge := range m.Edges {
+		fmt.Fprintf(&builder, "    %s -> %s;\n", edge.From, edge.To)
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
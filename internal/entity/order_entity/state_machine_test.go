@@ -0,0 +1,83 @@
+package order_entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStateMachine(t *testing.T) {
+	t.Run("Should describe every state and transition", func(t *testing.T) {
+		// Arrange
+
+		// Act
+		res := NewStateMachine()
+
+		// Assert
+		assert.Equal(t, "Received", res.Initial)
+		assert.Len(t, res.States, 5)
+		assert.Equal(t, StateDescription{
+			Value:       Received,
+			Title:       "Received",
+			Description: "The order is received and is ready to be processed by the kitchen",
+			Final:       false,
+			Transitions: []string{"Processing", "Cancelled"},
+		}, res.States[0])
+		assert.True(t, res.States[3].Final)
+		assert.Equal(t, []StateTransition{
+			{From: "Received", To: "Processing"},
+			{From: "Received", To: "Cancelled"},
+			{From: "Processing", To: "Completed"},
+			{From: "Processing", To: "Cancelled"},
+			{From: "Completed", To: "Delivered"},
+		}, res.Edges)
+	})
+}
+
+func TestMermaid(t *testing.T) {
+	t.Run("Should render the state diagram", func(t *testing.T) {
+		// Arrange
+		machine := NewStateMachine()
+
+		// Act
+		res := machine.Mermaid()
+
+		// Assert
+		assert.Equal(t, `stateDiagram-v2
+    [*] --> Received
+    Received --> Processing
+    Received --> Cancelled
+    Processing --> Completed
+    Processing --> Cancelled
+    Completed --> Delivered
+    Delivered --> [*]
+    Cancelled --> [*]
+`, res)
+	})
+}
+
+func TestDot(t *testing.T) {
+	t.Run("Should render the digraph", func(t *testing.T) {
+		// Arrange
+		machine := NewStateMachine()
+
+		// Act
+		res := machine.Dot()
+
+		// Assert
+		assert.Equal(t, `digraph OrderState {
+    rankdir=LR;
+    node [shape=box, style=rounded];
+    start [shape=point];
+    Delivered [peripheries=2];
+    Cancelled [peripheries=2];
+    start -> Received;
+    Received -> Processing;
+    Received -> Cancelled;
+    Processing -> Completed;
+    Processing -> Cancelled;
+    Completed -> Delivered;
+}
+`, res)
+	})
+}
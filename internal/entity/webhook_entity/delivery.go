@@ -0,0 +1,42 @@
+package webhook_entity
+
+import "time"
+
+type Delivery struct {
+	Id             string `json:"id"`
+	SubscriptionId string `json:"subscription_id"`
+	EventId        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Attempt        int    `json:"attempt"`
+
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewDelivery(id string, subscriptionId string, eventId string, eventType string, attempt int, now time.Time) Delivery {
+	return Delivery{
+		Id:             id,
+		SubscriptionId: subscriptionId,
+		EventId:        eventId,
+		EventType:      eventType,
+		Attempt:        attempt,
+
+		CreatedAt: now,
+	}
+}
+
+// Finish records the outcome of the attempt, only 2xx responses are successful
+func (d *Delivery) Finish(statusCode int, err error, duration time.Duration) {
+	d.StatusCode = statusCode
+	d.DurationMs = duration.Milliseconds()
+
+	if err != nil {
+		d.Error = err.Error()
+	}
+
+	d.Success = err == nil && statusCode >= 200 && statusCode < 300
+}
@@ -0,0 +1,48 @@
+package webhook_entity
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinish(t *testing.T) {
+	t.Run("Should be successful when the response is 2xx", func(t *testing.T) {
+		// Arrange
+		delivery := NewDelivery(uuid.NewString(), uuid.NewString(), uuid.NewString(), "production.order.created", 1, time.Now())
+
+		// Act
+		delivery.Finish(http.StatusNoContent, nil, 150*time.Millisecond)
+
+		// Assert
+		assert.True(t, delivery.Success)
+		assert.Equal(t, int64(150), delivery.DurationMs)
+	})
+
+	t.Run("Should fail when the response is not 2xx", func(t *testing.T) {
+		// Arrange
+		delivery := NewDelivery(uuid.NewString(), uuid.NewString(), uuid.NewString(), "production.order.created", 1, time.Now())
+
+		// Act
+		delivery.Finish(http.StatusInternalServerError, nil, time.Second)
+
+		// Assert
+		assert.False(t, delivery.Success)
+		assert.Equal(t, http.StatusInternalServerError, delivery.StatusCode)
+	})
+
+	t.Run("Should fail when the request fails", func(t *testing.T) {
+		// Arrange
+		delivery := NewDelivery(uuid.NewString(), uuid.NewString(), uuid.NewString(), "production.order.created", 1, time.Now())
+
+		// Act
+		delivery.Finish(0, assert.AnError, time.Second)
+
+		// Assert
+		assert.False(t, delivery.Success)
+		assert.Equal(t, assert.AnError.Error(), delivery.Error)
+	})
+}
@@ -0,0 +1,95 @@
+package webhook_entity
+
+import "time"
+
+type Subscription struct {
+	Id         string   `json:"id"`
+	Url        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret,omitempty"`
+
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewSubscription(id string, url string, eventTypes []string, secret string, now time.Time) Subscription {
+	if eventTypes == nil {
+		eventTypes = make([]string, 0)
+	}
+
+	return Subscription{
+		Id:         id,
+		Url:        url,
+		EventTypes: eventTypes,
+		Secret:     secret,
+
+		Active: true,
+
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Accepts reports if the event should be delivered to the subscription, a
+// subscription without event types receives every event
+func (s *Subscription) Accepts(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+
+	for _, accepted := range s.EventTypes {
+		if accepted == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Subscription) RegisterSuccess(now time.Time) {
+	s.ConsecutiveFailures = 0
+	s.UpdatedAt = now
+}
+
+// RegisterFailure counts a delivery that failed after every attempt and
+// disables the subscription once maxFailures is reached
+func (s *Subscription) RegisterFailure(maxFailures int, now time.Time) {
+	s.ConsecutiveFailures++
+	s.UpdatedAt = now
+
+	if maxFailures > 0 && s.ConsecutiveFailures >= maxFailures {
+		s.Disable(now)
+	}
+}
+
+func (s *Subscription) Enable(now time.Time) {
+	s.Active = true
+	s.ConsecutiveFailures = 0
+	s.DisabledAt = nil
+	s.UpdatedAt = now
+}
+
+func (s *Subscription) Disable(now time.Time) {
+	s.Active = false
+	s.DisabledAt = &now
+	s.UpdatedAt = now
+}
+
+func (s *Subscription) HideSecret() {
+	s.Secret = ""
+}
+
+// Snapshot returns a copy of the subscription without the secret, to be kept
+// in the audit log
+func (s Subscription) Snapshot() Subscription {
+	s.HideSecret()
+	return s
+}
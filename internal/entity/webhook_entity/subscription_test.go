@@ -0,0 +1,100 @@
+package webhook_entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccepts(t *testing.T) {
+	t.Run("Should accept every event when there is no event type", func(t *testing.T) {
+		// Arrange
+		subscription := NewSubscription(uuid.NewString(), "http://localhost", nil, "secret", time.Now())
+
+		// Act
+		res := subscription.Accepts("production.order.created")
+
+		// Assert
+		assert.True(t, res)
+	})
+
+	t.Run("Should accept only the subscribed event types", func(t *testing.T) {
+		// Arrange
+		subscription := NewSubscription(uuid.NewString(), "http://localhost", []string{"production.order.cancelled"}, "secret", time.Now())
+
+		// Act
+		accepted := subscription.Accepts("production.order.cancelled")
+		rejected := subscription.Accepts("production.order.created")
+
+		// Assert
+		assert.True(t, accepted)
+		assert.False(t, rejected)
+	})
+
+	t.Run("Should not accept events when disabled", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		subscription := NewSubscription(uuid.NewString(), "http://localhost", nil, "secret", now)
+		subscription.Disable(now)
+
+		// Act
+		res := subscription.Accepts("production.order.created")
+
+		// Assert
+		assert.False(t, res)
+	})
+}
+
+func TestRegisterFailure(t *testing.T) {
+	t.Run("Should disable the subscription after the max failures", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		subscription := NewSubscription(uuid.NewString(), "http://localhost", nil, "secret", now)
+
+		// Act
+		subscription.RegisterFailure(2, now)
+		activeAfterFirst := subscription.Active
+		subscription.RegisterFailure(2, now)
+
+		// Assert
+		assert.True(t, activeAfterFirst)
+		assert.False(t, subscription.Active)
+		assert.Equal(t, 2, subscription.ConsecutiveFailures)
+		assert.NotNil(t, subscription.DisabledAt)
+	})
+
+	t.Run("Should reset the failures on success", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		subscription := NewSubscription(uuid.NewString(), "http://localhost", nil, "secret", now)
+		subscription.RegisterFailure(5, now)
+
+		// Act
+		subscription.RegisterSuccess(now)
+
+		// Assert
+		assert.True(t, subscription.Active)
+		assert.Equal(t, 0, subscription.ConsecutiveFailures)
+	})
+
+	t.Run("Should clear the failures when enabled again", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+
+		subscription := NewSubscription(uuid.NewString(), "http://localhost", nil, "secret", now)
+		subscription.RegisterFailure(1, now)
+
+		// Act
+		subscription.Enable(now)
+
+		// Assert
+		assert.True(t, subscription.Active)
+		assert.Equal(t, 0, subscription.ConsecutiveFailures)
+		assert.Nil(t, subscription.DisabledAt)
+	})
+}
@@ -2,12 +2,18 @@ package environment
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 )
 
 type ApiConfig struct {
 	Port       int    `env:"PORT, default=8080"`
 	EnvName    string `env:"ENV_NAME, default=development"`
 	ApiVersion string `env:"VERSION, default=v1"`
+	StoreId    string `env:"STORE_ID"`
+	// Timezone of the times returned by the API and the exports
+	Timezone string `env:"TIMEZONE, default=America/Sao_Paulo"`
 }
 
 func (c *ApiConfig) IsDevelopment() bool {
@@ -21,7 +27,11 @@ type DatabaseConfig struct {
 
 type CloudConfig struct {
 	OrderProductionQueue string `env:"ORDER_PRODUCTION_QUEUE_NAME, required"`
+	OrderProductionDLQ   string `env:"ORDER_PRODUCTION_DLQ_NAME"`
 	UpdateOrderTopic     string `env:"UPDATE_ORDER_TOPIC_NAME, required"`
+	UpdateOrderTopicFifo bool   `env:"UPDATE_ORDER_TOPIC_FIFO, default=false"`
+	// UpdateOrderEventFormat is one of legacy, cloudevents or both
+	UpdateOrderEventFormat string `env:"UPDATE_ORDER_EVENT_FORMAT, default=legacy"`
 
 	BaseEndpoint string `env:"BASE_ENDPOINT"`
 }
@@ -30,10 +40,179 @@ func (c *CloudConfig) IsBaseEndpointSet() bool {
 	return c.BaseEndpoint != ""
 }
 
+func (c *CloudConfig) IsDeadLetterQueueSet() bool {
+	return c.OrderProductionDLQ != ""
+}
+
+// Validate fails when the event format is unknown, so a typo does not silently
+// publish the legacy events
+func (c *CloudConfig) Validate() error {
+	switch c.UpdateOrderEventFormat {
+	case "legacy", "cloudevents", "both":
+		return nil
+	}
+
+	return fmt.Errorf("unknown update order event format %q, please set AWS_UPDATE_ORDER_EVENT_FORMAT to legacy, cloudevents or both", c.UpdateOrderEventFormat)
+}
+
+type WebhookConfig struct {
+	MaxAttempts            int           `env:"MAX_ATTEMPTS, default=5"`
+	InitialBackoff         time.Duration `env:"INITIAL_BACKOFF, default=1s"`
+	MaxBackoff             time.Duration `env:"MAX_BACKOFF, default=1m"`
+	Timeout                time.Duration `env:"TIMEOUT, default=10s"`
+	MaxConsecutiveFailures int           `env:"MAX_CONSECUTIVE_FAILURES, default=10"`
+}
+
+type GrpcConfig struct {
+	Port int `env:"PORT, default=9090"`
+}
+
+type PickupBoardConfig struct {
+	// ReadyTtl is how long a completed order stays on the board
+	ReadyTtl time.Duration `env:"READY_TTL, default=15m"`
+	// MaxAge is the Cache-Control max-age of the board responses
+	MaxAge time.Duration `env:"MAX_AGE, default=5s"`
+}
+
+type OrderCacheConfig struct {
+	// Enabled reads the orders by id through an in-process LRU cache
+	Enabled bool          `env:"ENABLED, default=false"`
+	Size    int           `env:"SIZE, default=1000"`
+	Ttl     time.Duration `env:"TTL, default=30s"`
+}
+
+type OrderStreamConfig struct {
+	// Retention is how long the order events are kept, a client resuming
+	// after an older event only receives the ones still kept
+	Retention time.Duration `env:"RETENTION, default=168h"`
+	// PruneInterval is the time between two deletions of the expired events
+	PruneInterval time.Duration `env:"PRUNE_INTERVAL, default=1h"`
+}
+
+type PrinterConfig struct {
+	// Address of the kitchen printer (host:port, 9100 when omitted), the
+	// orders received through the queue are only printed when it is set
+	Address string        `env:"ADDRESS"`
+	Timeout time.Duration `env:"TIMEOUT, default=5s"`
+	// Width is the number of characters of a line of the paper
+	Width int `env:"WIDTH, default=42"`
+}
+
+func (config *PrinterConfig) IsAutoPrintEnabled() bool {
+	return config.Address != ""
+}
+
+type AuthConfig struct {
+	// Secret verifies the tokens signed with HMAC (HS256, HS384 and HS512)
+	Secret string `env:"SECRET"`
+	// JwksUrl is where the public keys of the tokens signed with RSA or ECDSA
+	// are fetched from, they are cached for JwksRefreshInterval
+	JwksUrl             string        `env:"JWKS_URL"`
+	JwksRefreshInterval time.Duration `env:"JWKS_REFRESH_INTERVAL, default=1h"`
+	// Issuer and Audience are only checked when set
+	Issuer   string `env:"ISSUER"`
+	Audience string `env:"AUDIENCE"`
+	// Leeway is the clock skew tolerated when checking the times of the token
+	Leeway time.Duration `env:"LEEWAY, default=30s"`
+	// RolesClaim is the claim with the roles of the user, a dotted path reads
+	// nested claims, e.g. realm_access.roles
+	RolesClaim string `env:"ROLES_CLAIM, default=roles"`
+	// StoreClaim is the claim with the store the user has access to, a
+	// dotted path reads nested claims
+	StoreClaim string `env:"STORE_CLAIM, default=store_id"`
+	// PolicyFile is the YAML file with the transitions and endpoints of every
+	// role, the default policy is used when it is not set
+	PolicyFile string `env:"POLICY_FILE"`
+}
+
+func (config *AuthConfig) IsJwksSet() bool {
+	return config.JwksUrl != ""
+}
+
+// Validate fails when no token could ever be verified
+func (config *AuthConfig) Validate() error {
+	if config.Secret == "" && config.JwksUrl == "" {
+		return errors.New("authentication is not configured, please set AUTH_SECRET or AUTH_JWKS_URL")
+	}
+
+	return nil
+}
+
+type ApiKeyConfig struct {
+	// RotationOverlap is how long a rotated key is still accepted when the
+	// rotation does not inform the overlap
+	RotationOverlap time.Duration `env:"ROTATION_OVERLAP, default=24h"`
+	// LastUsedPrecision is the minimum time between two writes of the last use
+	// of a key
+	LastUsedPrecision time.Duration `env:"LAST_USED_PRECISION, default=1m"`
+}
+
+type TracingConfig struct {
+	// Exporter is one of otlp, stdout or none, with none the trace context is
+	// still propagated but the spans are not exported
+	Exporter string `env:"EXPORTER, default=none"`
+	// Endpoint of the OTLP collector (host:port), the OTEL_EXPORTER_OTLP_*
+	// variables are used when it is not set
+	Endpoint string `env:"ENDPOINT"`
+	Insecure bool   `env:"INSECURE, default=false"`
+	// SampleRatio is the fraction of the traces started by the service that
+	// are sampled, the decision of the caller is always respected
+	SampleRatio float64 `env:"SAMPLE_RATIO, default=1"`
+	ServiceName string  `env:"SERVICE_NAME, default=ms-production-management"`
+}
+
+type HealthConfig struct {
+	// Timeout of a check that does not set its own
+	Timeout time.Duration `env:"TIMEOUT, default=2s"`
+	// CacheTtl is how long the result of a check is reused by the probes
+	CacheTtl time.Duration `env:"CACHE_TTL, default=5s"`
+	// ConsumerStallTimeout is how long the queue consumer may go without
+	// polling before the liveness probe fails
+	ConsumerStallTimeout time.Duration `env:"CONSUMER_STALL_TIMEOUT, default=5m"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn or error, debug in development and
+	// info otherwise when not set, it can be changed at runtime
+	Level string `env:"LEVEL"`
+	// Format is json or text, text in development and json otherwise when
+	// not set
+	Format string `env:"FORMAT"`
+	// Output is stdout, stderr or the path of a file the logs are appended to
+	Output string `env:"OUTPUT, default=stdout"`
+	// AccessLog logs every request, the failed ones are always logged
+	AccessLog bool `env:"ACCESS_LOG, default=true"`
+	// SampleInitial is how many records with the same message are logged in
+	// each SampleTick before only one of every SampleThereafter is, zero
+	// disables the sampling, warnings and errors are never sampled
+	SampleInitial    int           `env:"SAMPLE_INITIAL, default=0"`
+	SampleThereafter int           `env:"SAMPLE_THEREAFTER, default=100"`
+	SampleTick       time.Duration `env:"SAMPLE_TICK, default=1s"`
+	// RedactKeys are the attributes and query parameters, in any case, whose
+	// values are never logged
+	RedactKeys []string `env:"REDACT_KEYS, default=authorization,cookie,set-cookie,token,access_token,refresh_token,api_key,password,secret"`
+}
+
+func (c *LogConfig) IsSamplingEnabled() bool {
+	return c.SampleInitial > 0
+}
+
 type Config struct {
-	ApiConfig   *ApiConfig      `env:",prefix=API_"`
-	DbConfig    *DatabaseConfig `env:",prefix=DB_"`
-	CloudConfig *CloudConfig    `env:",prefix=AWS_"`
+	ApiConfig     *ApiConfig      `env:",prefix=API_"`
+	GrpcConfig    *GrpcConfig     `env:",prefix=GRPC_"`
+	DbConfig      *DatabaseConfig `env:",prefix=DB_"`
+	CloudConfig   *CloudConfig    `env:",prefix=AWS_"`
+	WebhookConfig *WebhookConfig  `env:",prefix=WEBHOOK_"`
+
+	PickupBoardConfig *PickupBoardConfig `env:",prefix=PICKUP_BOARD_"`
+	OrderCacheConfig  *OrderCacheConfig  `env:",prefix=ORDER_CACHE_"`
+	OrderStreamConfig *OrderStreamConfig `env:",prefix=ORDER_STREAM_"`
+	PrinterConfig     *PrinterConfig     `env:",prefix=PRINTER_"`
+	AuthConfig        *AuthConfig        `env:",prefix=AUTH_"`
+	ApiKeyConfig      *ApiKeyConfig      `env:",prefix=API_KEY_"`
+	TracingConfig     *TracingConfig     `env:",prefix=TRACING_"`
+	HealthConfig      *HealthConfig      `env:",prefix=HEALTH_"`
+	LogConfig         *LogConfig         `env:",prefix=LOG_"`
 }
 
 type Environment interface {
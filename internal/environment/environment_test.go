@@ -0,0 +1,60 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudConfigValidate(t *testing.T) {
+	t.Run("Should accept the known event formats", func(t *testing.T) {
+		for _, format := range []string{"legacy", "cloudevents", "both"} {
+			// Arrange
+			config := &CloudConfig{UpdateOrderEventFormat: format}
+
+			// Act
+			err := config.Validate()
+
+			// Assert
+			assert.NoError(t, err, format)
+		}
+	})
+
+	t.Run("Should return error when the event format is unknown", func(t *testing.T) {
+		// Arrange
+		config := &CloudConfig{UpdateOrderEventFormat: "cloudevent"}
+
+		// Act
+		err := config.Validate()
+
+		// Assert
+		assert.ErrorContains(t, err, `unknown update order event format "cloudevent"`)
+	})
+}
+
+func TestAuthConfigValidate(t *testing.T) {
+	t.Run("Should accept a secret or a key set", func(t *testing.T) {
+		// Arrange
+		withSecret := &AuthConfig{Secret: "my-secret"}
+		withJwks := &AuthConfig{JwksUrl: "https://idp/.well-known/jwks.json"}
+
+		// Act
+		secretErr := withSecret.Validate()
+		jwksErr := withJwks.Validate()
+
+		// Assert
+		assert.NoError(t, secretErr)
+		assert.NoError(t, jwksErr)
+	})
+
+	t.Run("Should return error when no token could be verified", func(t *testing.T) {
+		// Arrange
+		config := &AuthConfig{}
+
+		// Act
+		err := config.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
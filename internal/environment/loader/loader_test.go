@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
 	"github.com/stretchr/testify/assert"
@@ -53,15 +54,71 @@ func TestGetEnvironment(t *testing.T) {
 				Port:       8080,
 				EnvName:    "development",
 				ApiVersion: "v1",
+				Timezone:   "America/Sao_Paulo",
+			},
+			GrpcConfig: &environment.GrpcConfig{
+				Port: 9090,
 			},
 			DbConfig: &environment.DatabaseConfig{
 				Url:           "db://host:1234",
 				UrlSecretName: "db-secret-url",
 			},
 			CloudConfig: &environment.CloudConfig{
-				BaseEndpoint:         "http://localhost:4566",
-				OrderProductionQueue: "order_production",
-				UpdateOrderTopic:     "update_order",
+				BaseEndpoint:           "http://localhost:4566",
+				OrderProductionQueue:   "order_production",
+				UpdateOrderTopic:       "update_order",
+				UpdateOrderEventFormat: "legacy",
+			},
+			WebhookConfig: &environment.WebhookConfig{
+				MaxAttempts:            5,
+				InitialBackoff:         time.Second,
+				MaxBackoff:             time.Minute,
+				Timeout:                10 * time.Second,
+				MaxConsecutiveFailures: 10,
+			},
+			PickupBoardConfig: &environment.PickupBoardConfig{
+				ReadyTtl: 15 * time.Minute,
+				MaxAge:   5 * time.Second,
+			},
+			OrderCacheConfig: &environment.OrderCacheConfig{
+				Enabled: false,
+				Size:    1000,
+				Ttl:     30 * time.Second,
+			},
+			OrderStreamConfig: &environment.OrderStreamConfig{
+				Retention:     7 * 24 * time.Hour,
+				PruneInterval: time.Hour,
+			},
+			PrinterConfig: &environment.PrinterConfig{
+				Timeout: 5 * time.Second,
+				Width:   42,
+			},
+			AuthConfig: &environment.AuthConfig{
+				JwksRefreshInterval: time.Hour,
+				Leeway:              30 * time.Second,
+				RolesClaim:          "roles",
+				StoreClaim:          "store_id",
+			},
+			ApiKeyConfig: &environment.ApiKeyConfig{
+				RotationOverlap:   24 * time.Hour,
+				LastUsedPrecision: time.Minute,
+			},
+			TracingConfig: &environment.TracingConfig{
+				Exporter:    "none",
+				SampleRatio: 1,
+				ServiceName: "ms-production-management",
+			},
+			HealthConfig: &environment.HealthConfig{
+				Timeout:              2 * time.Second,
+				CacheTtl:             5 * time.Second,
+				ConsumerStallTimeout: 5 * time.Minute,
+			},
+			LogConfig: &environment.LogConfig{
+				Output:           "stdout",
+				AccessLog:        true,
+				SampleThereafter: 100,
+				SampleTick:       time.Second,
+				RedactKeys:       []string{"authorization", "cookie", "set-cookie", "token", "access_token", "refresh_token", "api_key", "password", "secret"},
 			},
 		}
 
@@ -111,15 +168,71 @@ func TestGetEnvironmentFromFile(t *testing.T) {
 				Port:       8080,
 				EnvName:    "development",
 				ApiVersion: "v1",
+				Timezone:   "America/Sao_Paulo",
+			},
+			GrpcConfig: &environment.GrpcConfig{
+				Port: 9090,
 			},
 			DbConfig: &environment.DatabaseConfig{
 				Url:           "db://host:1234",
 				UrlSecretName: "db-secret-url",
 			},
 			CloudConfig: &environment.CloudConfig{
-				BaseEndpoint:         "http://localhost:4566",
-				OrderProductionQueue: "order_production",
-				UpdateOrderTopic:     "update_order",
+				BaseEndpoint:           "http://localhost:4566",
+				OrderProductionQueue:   "order_production",
+				UpdateOrderTopic:       "update_order",
+				UpdateOrderEventFormat: "legacy",
+			},
+			WebhookConfig: &environment.WebhookConfig{
+				MaxAttempts:            5,
+				InitialBackoff:         time.Second,
+				MaxBackoff:             time.Minute,
+				Timeout:                10 * time.Second,
+				MaxConsecutiveFailures: 10,
+			},
+			PickupBoardConfig: &environment.PickupBoardConfig{
+				ReadyTtl: 15 * time.Minute,
+				MaxAge:   5 * time.Second,
+			},
+			OrderCacheConfig: &environment.OrderCacheConfig{
+				Enabled: false,
+				Size:    1000,
+				Ttl:     30 * time.Second,
+			},
+			OrderStreamConfig: &environment.OrderStreamConfig{
+				Retention:     7 * 24 * time.Hour,
+				PruneInterval: time.Hour,
+			},
+			PrinterConfig: &environment.PrinterConfig{
+				Timeout: 5 * time.Second,
+				Width:   42,
+			},
+			AuthConfig: &environment.AuthConfig{
+				JwksRefreshInterval: time.Hour,
+				Leeway:              30 * time.Second,
+				RolesClaim:          "roles",
+				StoreClaim:          "store_id",
+			},
+			ApiKeyConfig: &environment.ApiKeyConfig{
+				RotationOverlap:   24 * time.Hour,
+				LastUsedPrecision: time.Minute,
+			},
+			TracingConfig: &environment.TracingConfig{
+				Exporter:    "none",
+				SampleRatio: 1,
+				ServiceName: "ms-production-management",
+			},
+			HealthConfig: &environment.HealthConfig{
+				Timeout:              2 * time.Second,
+				CacheTtl:             5 * time.Second,
+				ConsumerStallTimeout: 5 * time.Minute,
+			},
+			LogConfig: &environment.LogConfig{
+				Output:           "stdout",
+				AccessLog:        true,
+				SampleThereafter: 100,
+				SampleTick:       time.Second,
+				RedactKeys:       []string{"authorization", "cookie", "set-cookie", "token", "access_token", "refresh_token", "api_key", "password", "secret"},
 			},
 		}
 
@@ -0,0 +1,35 @@
+package grpc_server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var businessCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.FailedPrecondition,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+}
+
+// toStatus converts the errors returned by the services to the gRPC status
+// equivalent to the HTTP status code of the REST API
+func toStatus(err error) error {
+	if businessErr, ok := custom_error.AsBusinessErr(err); ok {
+
+		code, ok := businessCodes[businessErr.Code()]
+		if !ok {
+			code = codes.Unknown
+		}
+
+		return status.Error(code, fmt.Sprintf("%s: %s", businessErr.Title(), err.Error()))
+	}
+
+	return status.Error(codes.Internal, "internal server error")
+}
@@ -0,0 +1,102 @@
+package grpc_server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// healthServicePrefix is the method prefix of the gRPC health checking
+// protocol, probes do not send tokens
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+func UnaryAuthInterceptor(authenticator *token.Authenticator, policy *authorization.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, authenticator, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamAuthInterceptor(authenticator *token.Authenticator, policy *authorization.Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), authenticator, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate stores the principal of the token or the API key in the
+// context of the call, the same way the HTTP middleware does, and checks if its
+// roles can call the method. The call is also the source of the audit log, the
+// request and correlation ids are returned in the header even when it fails
+func authenticate(ctx context.Context, authenticator *token.Authenticator, policy *authorization.Policy, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	ctx = correlation.WithIds(ctx, firstValue(md, "x-request-id"), firstValue(md, "x-correlation-id"))
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(
+		"x-request-id", correlation.RequestIdFromContext(ctx),
+		"x-correlation-id", correlation.CorrelationIdFromContext(ctx),
+	))
+
+	principal, err := authenticator.Authenticate(ctx, firstValue(md, "authorization"), firstValue(md, "x-api-key"))
+	if err != nil {
+		if !token.IsUnauthenticated(err) {
+			return nil, status.Error(codes.Internal, "internal server error")
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if !policy.CanAccess(principal.Roles, authorization.GrpcMethod, fullMethod) {
+		return nil, status.Error(codes.PermissionDenied, custom_error.ErrAccessDenied.Error())
+	}
+
+	ctx = audit.WithSource(ctx, audit_entity.Source{
+		Type:      audit_entity.GrpcSource,
+		RequestId: correlation.RequestIdFromContext(ctx),
+	})
+
+	return token.WithPrincipal(ctx, principal), nil
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,53 @@
+package grpc_server
+
+import (
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/grpc_server/productionpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// stateTitle converts the state of a request to the title used by the
+// services, an unknown state results in a title rejected by their validation
+func stateTitle(state productionpb.OrderState) string {
+	return order_entity.OrderState(state).String()
+}
+
+func toOrder(order order_entity.Order) *productionpb.Order {
+	order.RefreshStateTitle()
+
+	items := make([]*productionpb.Item, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, &productionpb.Item{
+			Id:       item.Id,
+			Name:     item.Name,
+			Quantity: int32(item.Quantity),
+			Station:  item.Station,
+		})
+	}
+
+	allowedTransitions := make([]productionpb.OrderState, 0)
+	for _, state := range order.State.AllowedTransitions() {
+		allowedTransitions = append(allowedTransitions, productionpb.OrderState(state))
+	}
+
+	return &productionpb.Order{
+		Id:             order.Id,
+		State:          productionpb.OrderState(order.State),
+		StateTitle:     order.StateTitle,
+		StateUpdatedAt: timestamppb.New(order.StateUpdatedAt),
+		Items:          items,
+		CreatedAt:      timestamppb.New(order.CreatedAt),
+		UpdatedAt:      timestamppb.New(order.UpdatedAt),
+
+		AllowedTransitions: allowedTransitions,
+	}
+}
+
+func toOrderEvent(event order_entity.OrderEvent) *productionpb.OrderEvent {
+	return &productionpb.OrderEvent{
+		Id:        event.Id,
+		Type:      event.Type,
+		Order:     toOrder(event.Order),
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+}
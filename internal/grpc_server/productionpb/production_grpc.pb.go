@@ -0,0 +1,299 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v27.1.0
+// source: production/v1/production.proto
+
+package productionpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	ProductionService_GetOrder_FullMethodName         = "/production.v1.ProductionService/GetOrder"
+	ProductionService_ListOrders_FullMethodName       = "/production.v1.ProductionService/ListOrders"
+	ProductionService_UpdateOrderState_FullMethodName = "/production.v1.ProductionService/UpdateOrderState"
+	ProductionService_WatchOrders_FullMethodName      = "/production.v1.ProductionService/WatchOrders"
+)
+
+// ProductionServiceClient is the client API for ProductionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ProductionService exposes the orders in production to the other services
+// of the store. Every call requires the same bearer token of the REST API in
+// the "authorization" metadata.
+type ProductionServiceClient interface {
+	// GetOrder returns an order by its id
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// ListOrders streams the orders at a state, most recent first
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (ProductionService_ListOrdersClient, error)
+	// UpdateOrderState moves an order to another state and publishes the
+	// update order event
+	UpdateOrderState(ctx context.Context, in *UpdateOrderStateRequest, opts ...grpc.CallOption) (*Order, error)
+	// WatchOrders streams the order events, the events after last_event_id are
+	// sent before the live ones
+	WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (ProductionService_WatchOrdersClient, error)
+}
+
+type productionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProductionServiceClient(cc grpc.ClientConnInterface) ProductionServiceClient {
+	return &productionServiceClient{cc}
+}
+
+func (c *productionServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, ProductionService_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productionServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (ProductionService_ListOrdersClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductionService_ServiceDesc.Streams[0], ProductionService_ListOrders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productionServiceListOrdersClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProductionService_ListOrdersClient interface {
+	Recv() (*Order, error)
+	grpc.ClientStream
+}
+
+type productionServiceListOrdersClient struct {
+	grpc.ClientStream
+}
+
+func (x *productionServiceListOrdersClient) Recv() (*Order, error) {
+	m := new(Order)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productionServiceClient) UpdateOrderState(ctx context.Context, in *UpdateOrderStateRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, ProductionService_UpdateOrderState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productionServiceClient) WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (ProductionService_WatchOrdersClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductionService_ServiceDesc.Streams[1], ProductionService_WatchOrders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productionServiceWatchOrdersClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProductionService_WatchOrdersClient interface {
+	Recv() (*OrderEvent, error)
+	grpc.ClientStream
+}
+
+type productionServiceWatchOrdersClient struct {
+	grpc.ClientStream
+}
+
+func (x *productionServiceWatchOrdersClient) Recv() (*OrderEvent, error) {
+	m := new(OrderEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ProductionServiceServer is the server API for ProductionService service.
+// All implementations must embed UnimplementedProductionServiceServer
+// for forward compatibility
+//
+// ProductionService exposes the orders in production to the other services
+// of the store. Every call requires the same bearer token of the REST API in
+// the "authorization" metadata.
+type ProductionServiceServer interface {
+	// GetOrder returns an order by its id
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	// ListOrders streams the orders at a state, most recent first
+	ListOrders(*ListOrdersRequest, ProductionService_ListOrdersServer) error
+	// UpdateOrderState moves an order to another state and publishes the
+	// update order event
+	UpdateOrderState(context.Context, *UpdateOrderStateRequest) (*Order, error)
+	// WatchOrders streams the order events, the events after last_event_id are
+	// sent before the live ones
+	WatchOrders(*WatchOrdersRequest, ProductionService_WatchOrdersServer) error
+	mustEmbedUnimplementedProductionServiceServer()
+}
+
+// UnimplementedProductionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedProductionServiceServer struct {
+}
+
+func (UnimplementedProductionServiceServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedProductionServiceServer) ListOrders(*ListOrdersRequest, ProductionService_ListOrdersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (UnimplementedProductionServiceServer) UpdateOrderState(context.Context, *UpdateOrderStateRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderState not implemented")
+}
+func (UnimplementedProductionServiceServer) WatchOrders(*WatchOrdersRequest, ProductionService_WatchOrdersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrders not implemented")
+}
+func (UnimplementedProductionServiceServer) mustEmbedUnimplementedProductionServiceServer() {}
+
+// UnsafeProductionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProductionServiceServer will
+// result in compilation errors.
+type UnsafeProductionServiceServer interface {
+	mustEmbedUnimplementedProductionServiceServer()
+}
+
+func RegisterProductionServiceServer(s grpc.ServiceRegistrar, srv ProductionServiceServer) {
+	s.RegisterService(&ProductionService_ServiceDesc, srv)
+}
+
+func _ProductionService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductionServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductionService_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductionServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductionService_ListOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductionServiceServer).ListOrders(m, &productionServiceListOrdersServer{ServerStream: stream})
+}
+
+type ProductionService_ListOrdersServer interface {
+	Send(*Order) error
+	grpc.ServerStream
+}
+
+type productionServiceListOrdersServer struct {
+	grpc.ServerStream
+}
+
+func (x *productionServiceListOrdersServer) Send(m *Order) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProductionService_UpdateOrderState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductionServiceServer).UpdateOrderState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductionService_UpdateOrderState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductionServiceServer).UpdateOrderState(ctx, req.(*UpdateOrderStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductionService_WatchOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductionServiceServer).WatchOrders(m, &productionServiceWatchOrdersServer{ServerStream: stream})
+}
+
+type ProductionService_WatchOrdersServer interface {
+	Send(*OrderEvent) error
+	grpc.ServerStream
+}
+
+type productionServiceWatchOrdersServer struct {
+	grpc.ServerStream
+}
+
+func (x *productionServiceWatchOrdersServer) Send(m *OrderEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ProductionService_ServiceDesc is the grpc.ServiceDesc for ProductionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProductionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "production.v1.ProductionService",
+	HandlerType: (*ProductionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOrder",
+			Handler:    _ProductionService_GetOrder_Handler,
+		},
+		{
+			MethodName: "UpdateOrderState",
+			Handler:    _ProductionService_UpdateOrderState_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListOrders",
+			Handler:       _ProductionService_ListOrders_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchOrders",
+			Handler:       _ProductionService_WatchOrders_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "production/v1/production.proto",
+}
@@ -0,0 +1,152 @@
+package grpc_server
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/grpc_server/productionpb"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_id"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_state"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/update"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	productionpb.UnimplementedProductionServiceServer
+
+	getOrderProductionById    service.GetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput]
+	getOrderProductionByState service.GetOrderProductionByStateService[get_by_state.GetOrderProductionByStateInput]
+	updateOrderProduction     service.UpdateOrderProductionService[update.UpdateOrderProductionInput]
+	updateOrderTopic          cloud.TopicService
+	streamer                  stream.Streamer
+}
+
+func NewServer(
+	getOrderProductionById service.GetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput],
+	getOrderProductionByState service.GetOrderProductionByStateService[get_by_state.GetOrderProductionByStateInput],
+	updateOrderProduction service.UpdateOrderProductionService[update.UpdateOrderProductionInput],
+	updateOrderTopic cloud.TopicService,
+	streamer stream.Streamer,
+) *Server {
+	return &Server{
+		getOrderProductionById:    getOrderProductionById,
+		getOrderProductionByState: getOrderProductionByState,
+		updateOrderProduction:     updateOrderProduction,
+		updateOrderTopic:          updateOrderTopic,
+		streamer:                  streamer,
+	}
+}
+
+// NewGrpcServer returns a gRPC server with the production service behind the
+// token interceptors and the health checking service
+func NewGrpcServer(server *Server, healthServer *health.Server, authenticator *token.Authenticator, policy *authorization.Policy) *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryAuthInterceptor(authenticator, policy)),
+		grpc.ChainStreamInterceptor(StreamAuthInterceptor(authenticator, policy)),
+	)
+
+	productionpb.RegisterProductionServiceServer(grpcServer, server)
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+
+	healthServer.SetServingStatus(productionpb.ProductionService_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+
+	return grpcServer
+}
+
+func (s *Server) GetOrder(ctx context.Context, request *productionpb.GetOrderRequest) (*productionpb.Order, error) {
+	order, err := s.getOrderProductionById.Handle(ctx, get_by_id.GetOrderProductionByIdInput{
+		OrderId: request.GetId(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toOrder(order), nil
+}
+
+func (s *Server) ListOrders(request *productionpb.ListOrdersRequest, srv productionpb.ProductionService_ListOrdersServer) error {
+	orders, err := s.getOrderProductionByState.Handle(srv.Context(), get_by_state.GetOrderProductionByStateInput{
+		State: stateTitle(request.GetState()),
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for _, order := range orders {
+		if err := srv.Send(toOrder(order)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) UpdateOrderState(ctx context.Context, request *productionpb.UpdateOrderStateRequest) (*productionpb.Order, error) {
+	order, err := s.updateOrderProduction.Handle(ctx, update.UpdateOrderProductionInput{
+		OrderId: request.GetId(),
+		State:   stateTitle(request.GetState()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	order.RefreshStateTitle()
+
+	messageId, err := s.updateOrderTopic.PublishMessage(ctx, cloud.NewOrderEvent(order, token.EventActorFromContext(ctx)))
+	if err != nil {
+		slog.ErrorContext(ctx, "error publishing message to update order topic", "error", err)
+	}
+
+	if messageId != nil {
+		slog.InfoContext(ctx, "message published to update order topic", "message_id", *messageId)
+	}
+
+	return toOrder(*order), nil
+}
+
+func (s *Server) WatchOrders(request *productionpb.WatchOrdersRequest, srv productionpb.ProductionService_WatchOrdersServer) error {
+	states := make([]string, 0, len(request.GetStates()))
+	for _, state := range request.GetStates() {
+		states = append(states, stateTitle(state))
+	}
+
+	filter, err := stream.NewFilter(strings.Join(states, ","), request.GetStation())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := srv.Context()
+
+	if err := s.streamer.Stream(ctx, filter, request.GetLastEventId(), &sink{stream: srv}); err != nil {
+		slog.ErrorContext(ctx, "order stream closed", "error", err)
+		return status.Error(codes.Unavailable, "order stream closed")
+	}
+
+	return nil
+}
+
+// sink writes the order events to a gRPC stream, the heartbeat of the
+// streamer only checks the call is still alive since gRPC has its own
+// keepalive
+type sink struct {
+	stream productionpb.ProductionService_WatchOrdersServer
+}
+
+func (s *sink) Send(event order_entity.OrderEvent) error {
+	return s.stream.Send(toOrderEvent(event))
+}
+
+func (s *sink) Ping() error {
+	return s.stream.Context().Err()
+}
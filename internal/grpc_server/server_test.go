@@ -0,0 +1,479 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	cloud_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream"
+	stream_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/grpc_server/productionpb"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	services_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_id"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_state"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/update"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type dependencies struct {
+	getById    *services_mocks.MockGetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput]
+	getByState *services_mocks.MockGetOrderProductionByStateService[get_by_state.GetOrderProductionByStateInput]
+	update     *services_mocks.MockUpdateOrderProductionService[update.UpdateOrderProductionInput]
+	topic      *cloud_mocks.MockTopicService
+	streamer   *stream_mocks.MockStreamer
+}
+
+func newDependencies(t *testing.T) dependencies {
+	return dependencies{
+		getById:    services_mocks.NewMockGetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput](t),
+		getByState: services_mocks.NewMockGetOrderProductionByStateService[get_by_state.GetOrderProductionByStateInput](t),
+		update:     services_mocks.NewMockUpdateOrderProductionService[update.UpdateOrderProductionInput](t),
+		topic:      cloud_mocks.NewMockTopicService(t),
+		streamer:   stream_mocks.NewMockStreamer(t),
+	}
+}
+
+func startServer(t *testing.T, deps dependencies) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := NewGrpcServer(
+		NewServer(deps.getById, deps.getByState, deps.update, deps.topic, deps.streamer),
+		health.NewServer(),
+		token.NewAuthenticator(token.NewVerifier(&environment.AuthConfig{Secret: "my-secret", RolesClaim: "roles"}, nil), nil, ""),
+		authorization.DefaultPolicy(),
+	)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		grpcServer.Stop()
+	})
+
+	return conn
+}
+
+func authenticated(t *testing.T, userId string) context.Context {
+	return authenticatedAs(t, userId, "manager")
+}
+
+func authenticatedAs(t *testing.T, userId string, roles ...string) context.Context {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   userId,
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"roles": roles,
+	})
+
+	tokenString, err := token.SignedString([]byte("my-secret"))
+	assert.NoError(t, err)
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", fmt.Sprintf("Bearer %s", tokenString))
+}
+
+func TestGetOrder(t *testing.T) {
+	t.Run("Should return the order", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		orderId := uuid.NewString()
+		order := order_entity.NewOrder(orderId, time.Now())
+		order.Items = append(order.Items, order_entity.NewItem(uuid.NewString(), "Hamburger", 2))
+
+		deps.getById.On("Handle", mock.Anything, get_by_id.GetOrderProductionByIdInput{OrderId: orderId}).
+			Return(order, nil).
+			Once()
+
+		// Act
+		res, err := client.GetOrder(authenticated(t, "user-1"), &productionpb.GetOrderRequest{Id: orderId})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, orderId, res.GetId())
+		assert.Equal(t, productionpb.OrderState_ORDER_STATE_RECEIVED, res.GetState())
+		assert.Equal(t, "Received", res.GetStateTitle())
+		assert.Equal(t, []productionpb.OrderState{
+			productionpb.OrderState_ORDER_STATE_PROCESSING,
+			productionpb.OrderState_ORDER_STATE_CANCELLED,
+		}, res.GetAllowedTransitions())
+		assert.Len(t, res.GetItems(), 1)
+		assert.Equal(t, int32(2), res.GetItems()[0].GetQuantity())
+	})
+
+	t.Run("Should return the request and correlation ids in the header", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		orderId := uuid.NewString()
+
+		var requestId, correlationId string
+
+		deps.getById.On("Handle", mock.Anything, get_by_id.GetOrderProductionByIdInput{OrderId: orderId}).
+			Run(func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				requestId = correlation.RequestIdFromContext(ctx)
+				correlationId = correlation.CorrelationIdFromContext(ctx)
+			}).
+			Return(order_entity.NewOrder(orderId, time.Now()), nil).
+			Once()
+
+		ctx := metadata.AppendToOutgoingContext(authenticated(t, "user-1"), "x-request-id", "request-id")
+
+		var header metadata.MD
+
+		// Act
+		_, err := client.GetOrder(ctx, &productionpb.GetOrderRequest{Id: orderId}, grpc.Header(&header))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "request-id", requestId)
+		assert.Equal(t, "request-id", correlationId)
+		assert.Equal(t, []string{"request-id"}, header.Get("x-request-id"))
+		assert.Equal(t, []string{"request-id"}, header.Get("x-correlation-id"))
+	})
+
+	t.Run("Should return not found when the order does not exist", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		deps.getById.On("Handle", mock.Anything, mock.Anything).
+			Return(order_entity.Order{}, custom_error.ErrOrderNotFound).
+			Once()
+
+		// Act
+		res, err := client.GetOrder(authenticated(t, "user-1"), &productionpb.GetOrderRequest{Id: uuid.NewString()})
+
+		// Assert
+		assert.Nil(t, res)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Should return internal error when the service fails", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		deps.getById.On("Handle", mock.Anything, mock.Anything).
+			Return(order_entity.Order{}, errors.New("something got wrong")).
+			Once()
+
+		// Act
+		res, err := client.GetOrder(authenticated(t, "user-1"), &productionpb.GetOrderRequest{Id: uuid.NewString()})
+
+		// Assert
+		assert.Nil(t, res)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, "internal server error", status.Convert(err).Message())
+	})
+
+	t.Run("Should return unauthenticated when the token is missing", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		// Act
+		res, err := client.GetOrder(context.Background(), &productionpb.GetOrderRequest{Id: uuid.NewString()})
+
+		// Assert
+		assert.Nil(t, res)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Should return permission denied when the roles cannot call the method", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		// Act
+		res, err := client.GetOrder(authenticatedAs(t, "user-1", "unknown"), &productionpb.GetOrderRequest{Id: uuid.NewString()})
+
+		// Assert
+		assert.Nil(t, res)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestListOrders(t *testing.T) {
+	t.Run("Should stream the orders at the state", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		orders := []order_entity.Order{
+			order_entity.NewOrder(uuid.NewString(), time.Now()),
+			order_entity.NewOrder(uuid.NewString(), time.Now()),
+		}
+
+		deps.getByState.On("Handle", mock.Anything, get_by_state.GetOrderProductionByStateInput{State: "Received"}).
+			Return(orders, nil).
+			Once()
+
+		// Act
+		res, err := client.ListOrders(authenticated(t, "user-1"), &productionpb.ListOrdersRequest{
+			State: productionpb.OrderState_ORDER_STATE_RECEIVED,
+		})
+		assert.NoError(t, err)
+
+		received := make([]string, 0)
+		for {
+			order, err := res.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			assert.NoError(t, err)
+			received = append(received, order.GetId())
+		}
+
+		// Assert
+		assert.Equal(t, []string{orders[0].Id, orders[1].Id}, received)
+	})
+
+	t.Run("Should return invalid argument when the state is not valid", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		deps.getByState.On("Handle", mock.Anything, get_by_state.GetOrderProductionByStateInput{State: "None"}).
+			Return(nil, custom_error.ErrRequestNotValid).
+			Once()
+
+		// Act
+		res, err := client.ListOrders(authenticated(t, "user-1"), &productionpb.ListOrdersRequest{})
+		assert.NoError(t, err)
+
+		_, err = res.Recv()
+
+		// Assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Should return unauthenticated when the token is missing", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		// Act
+		res, err := client.ListOrders(context.Background(), &productionpb.ListOrdersRequest{})
+		assert.NoError(t, err)
+
+		_, err = res.Recv()
+
+		// Assert
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestUpdateOrderState(t *testing.T) {
+	t.Run("Should update the order and publish the event", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		orderId := uuid.NewString()
+		order := order_entity.NewOrder(orderId, time.Now())
+		order.State = order_entity.Processing
+
+		deps.update.On("Handle", mock.Anything, update.UpdateOrderProductionInput{OrderId: orderId, State: "Processing"}).
+			Return(&order, nil).
+			Once()
+
+		messageId := uuid.NewString()
+
+		deps.topic.On("PublishMessage", mock.Anything, mock.MatchedBy(func(event *cloud.OrderEvent) bool {
+			return event.Actor == cloud.NewUserActor("user-1")
+		})).
+			Return(&messageId, nil).
+			Once()
+
+		// Act
+		res, err := client.UpdateOrderState(authenticated(t, "user-1"), &productionpb.UpdateOrderStateRequest{
+			Id:    orderId,
+			State: productionpb.OrderState_ORDER_STATE_PROCESSING,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, productionpb.OrderState_ORDER_STATE_PROCESSING, res.GetState())
+		assert.Equal(t, "Processing", res.GetStateTitle())
+	})
+
+	t.Run("Should return the order even when the event is not published", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+
+		deps.update.On("Handle", mock.Anything, mock.Anything).
+			Return(&order, nil).
+			Once()
+
+		deps.topic.On("PublishMessage", mock.Anything, mock.Anything).
+			Return(nil, errors.New("something got wrong")).
+			Once()
+
+		// Act
+		res, err := client.UpdateOrderState(authenticated(t, "user-1"), &productionpb.UpdateOrderStateRequest{
+			Id:    order.Id,
+			State: productionpb.OrderState_ORDER_STATE_PROCESSING,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, order.Id, res.GetId())
+	})
+
+	t.Run("Should return failed precondition when the transition is not allowed", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		deps.update.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, custom_error.ErrOrderInvalidStateTransition).
+			Once()
+
+		// Act
+		res, err := client.UpdateOrderState(authenticated(t, "user-1"), &productionpb.UpdateOrderStateRequest{
+			Id:    uuid.NewString(),
+			State: productionpb.OrderState_ORDER_STATE_DELIVERED,
+		})
+
+		// Assert
+		assert.Nil(t, res)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, "unable to update order state: invalid state transition", status.Convert(err).Message())
+	})
+}
+
+func TestWatchOrders(t *testing.T) {
+	t.Run("Should stream the order events", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		expectedFilter := stream.Filter{
+			States:  []order_entity.OrderState{order_entity.Received, order_entity.Cancelled},
+			Station: "grill",
+		}
+
+		event := order_entity.NewOrderEvent(order_entity.NewOrder(uuid.NewString(), time.Now()), time.Now())
+		event.Id = 42
+
+		deps.streamer.On("Stream", mock.Anything, expectedFilter, int64(10), mock.Anything).
+			Run(func(args mock.Arguments) {
+				sink := args.Get(3).(stream.Sink)
+				assert.NoError(t, sink.Send(event))
+				assert.NoError(t, sink.Ping())
+			}).
+			Return(nil).
+			Once()
+
+		// Act
+		res, err := client.WatchOrders(authenticated(t, "user-1"), &productionpb.WatchOrdersRequest{
+			States: []productionpb.OrderState{
+				productionpb.OrderState_ORDER_STATE_RECEIVED,
+				productionpb.OrderState_ORDER_STATE_CANCELLED,
+			},
+			Station:     "grill",
+			LastEventId: 10,
+		})
+		assert.NoError(t, err)
+
+		received, err := res.Recv()
+		assert.NoError(t, err)
+
+		_, endErr := res.Recv()
+
+		// Assert
+		assert.Equal(t, int64(42), received.GetId())
+		assert.Equal(t, event.Order.Id, received.GetOrder().GetId())
+		assert.ErrorIs(t, endErr, io.EOF)
+	})
+
+	t.Run("Should return invalid argument when a state is not valid", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		// Act
+		res, err := client.WatchOrders(authenticated(t, "user-1"), &productionpb.WatchOrdersRequest{
+			States: []productionpb.OrderState{productionpb.OrderState_ORDER_STATE_UNSPECIFIED},
+		})
+		assert.NoError(t, err)
+
+		_, err = res.Recv()
+
+		// Assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Should return unavailable when the stream fails", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := productionpb.NewProductionServiceClient(startServer(t, deps))
+
+		deps.streamer.On("Stream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("something got wrong")).
+			Once()
+
+		// Act
+		res, err := client.WatchOrders(authenticated(t, "user-1"), &productionpb.WatchOrdersRequest{})
+		assert.NoError(t, err)
+
+		_, err = res.Recv()
+
+		// Assert
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestHealth(t *testing.T) {
+	t.Run("Should report serving without a token", func(t *testing.T) {
+		// Arrange
+		deps := newDependencies(t)
+		client := grpc_health_v1.NewHealthClient(startServer(t, deps))
+
+		// Act
+		res, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{
+			Service: productionpb.ProductionService_ServiceDesc.ServiceName,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, res.GetStatus())
+	})
+}
@@ -0,0 +1,40 @@
+package api_key_revoke
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/revoke"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service service.RevokeApiKeyService[revoke.RevokeApiKeyInput]
+}
+
+func NewHandler(
+	service service.RevokeApiKeyService[revoke.RevokeApiKeyInput],
+) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	var request revoke.RevokeApiKeyInput
+
+	if err := ctx.Bind(&request); err != nil {
+		return err
+	}
+
+	context := ctx.Request().Context()
+
+	if err := h.service.Handle(context, request); err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,124 @@
+package api_key_revoke
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/revoke"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should revoke the API key", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockRevokeApiKeyService[revoke.RevokeApiKeyInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		req := httptest.NewRequest(echo.DELETE, "/", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/api-keys/:id")
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(uuid.NewString())
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return not found error", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockRevokeApiKeyService[revoke.RevokeApiKeyInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(custom_error.ErrApiKeyNotFound).
+			Once()
+
+		req := httptest.NewRequest(echo.DELETE, "/", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/api-keys/:id")
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(uuid.NewString())
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusNotFound,
+			Message: "unable to find the API key",
+			Details: "API key not found",
+		}, he.Message)
+
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockRevokeApiKeyService[revoke.RevokeApiKeyInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.DELETE, "/", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/api-keys/:id")
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(uuid.NewString())
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+			Details: "assert.AnError general error for testing",
+		}, he.Message)
+
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,41 @@
+package api_key_rotate
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/rotate"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service service.RotateApiKeyService[rotate.RotateApiKeyInput]
+}
+
+func NewHandler(
+	service service.RotateApiKeyService[rotate.RotateApiKeyInput],
+) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	var request rotate.RotateApiKeyInput
+
+	if err := ctx.Bind(&request); err != nil {
+		return err
+	}
+
+	context := ctx.Request().Context()
+
+	res, err := h.service.Handle(context, request)
+	if err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, res)
+}
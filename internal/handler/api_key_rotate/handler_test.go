@@ -0,0 +1,129 @@
+package api_key_rotate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/rotate"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should rotate the API key", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockRotateApiKeyService[rotate.RotateApiKeyInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(&api_key_entity.ApiKey{}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/", strings.NewReader(`{"overlap_minutes":60}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/api-keys/:id/rotate")
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(uuid.NewString())
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the API key is revoked", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockRotateApiKeyService[rotate.RotateApiKeyInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, custom_error.ErrApiKeyRevoked).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/", strings.NewReader(`{"overlap_minutes":60}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/api-keys/:id/rotate")
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(uuid.NewString())
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusBadRequest,
+			Message: "unable to rotate the API key",
+			Details: "API key is revoked or expired",
+		}, he.Message)
+
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockRotateApiKeyService[rotate.RotateApiKeyInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/", strings.NewReader(`{"overlap_minutes":60}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/api-keys/:id/rotate")
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(uuid.NewString())
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+			Details: "assert.AnError general error for testing",
+		}, he.Message)
+
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,117 @@
+package audit_list
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/audit/list"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should list the entries", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockListAuditService[list.ListAuditInput](t)
+
+		service.On("Handle", mock.Anything, list.ListAuditInput{
+			ResourceType: audit_entity.OrderResource,
+			ActorId:      "user-1",
+			BeforeId:     10,
+		}).
+			Return([]audit_entity.Entry{{Id: 9}}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/?resource_type=order&actor_id=user-1&before_id=10", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the request is not valid", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockListAuditService[list.ListAuditInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, custom_error.NewValidationError(custom_error.Violation{
+				Field:   "from",
+				Rule:    "datetime",
+				Message: "must be a RFC 3339 time",
+			})).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/?from=today", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockListAuditService[list.ListAuditInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusInternalServerError,
+			Message: "internal server error",
+			Details: "assert.AnError general error for testing",
+		}, he.Message)
+
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,77 @@
+package bulk_update
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/bulk_update"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	bulkUpdateOrderProductionService service.BulkUpdateOrderProductionService[bulk_update.BulkUpdateOrderProductionInput]
+	updateOrderTopic                 cloud.TopicService
+}
+
+func NewHandler(
+	bulkUpdateOrderProductionService service.BulkUpdateOrderProductionService[bulk_update.BulkUpdateOrderProductionInput],
+	updateOrderTopic cloud.TopicService,
+) *Handler {
+	return &Handler{
+		bulkUpdateOrderProductionService: bulkUpdateOrderProductionService,
+		updateOrderTopic:                 updateOrderTopic,
+	}
+}
+
+func (h *Handler) Handle(c echo.Context) error {
+	var request bulk_update.BulkUpdateOrderProductionInput
+
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+
+	results, err := h.bulkUpdateOrderProductionService.Handle(ctx, request)
+	if err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	actor := token.EventActorFromContext(ctx)
+
+	var events []interface{}
+	for _, result := range results {
+		if result.Success {
+			events = append(events, cloud.NewOrderEvent(result.Order, actor))
+		}
+	}
+
+	if len(events) > 0 {
+		messageIds, err := h.updateOrderTopic.PublishBatch(ctx, events)
+		if err != nil {
+			slog.ErrorContext(ctx, "error publishing messages to update order topic", "error", err)
+		}
+
+		slog.InfoContext(ctx, "messages published to update order topic", "count", countPublished(messageIds))
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+func countPublished(messageIds []*string) int {
+	count := 0
+	for _, id := range messageIds {
+		if id != nil {
+			count++
+		}
+	}
+	return count
+}
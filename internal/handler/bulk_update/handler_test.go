@@ -0,0 +1,186 @@
+package bulk_update
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	services_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/bulk_update"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRequest(t *testing.T, input bulk_update.BulkUpdateOrderProductionInput) *http.Request {
+	body, err := json.Marshal(input)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(echo.PATCH, "/", bytes.NewBuffer(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	return req
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should update the orders and publish the events in batch", func(t *testing.T) {
+		// Arrange
+		bulkUpdateOrderProductionService := services_mocks.NewMockBulkUpdateOrderProductionService[bulk_update.BulkUpdateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		order := order_entity.Order{Id: uuid.NewString(), State: order_entity.Delivered}
+		failedId := uuid.NewString()
+
+		bulkUpdateOrderProductionService.On("Handle", mock.Anything, mock.Anything).
+			Return([]order_entity.BulkUpdateResult{
+				order_entity.NewBulkUpdateSuccess(&order),
+				order_entity.NewBulkUpdateFailure(failedId, custom_error.ErrOrderInvalidStateTransition),
+			}, nil).
+			Once()
+
+		messageId := uuid.NewString()
+
+		updateOrderTopic.On("PublishBatch", mock.Anything, mock.MatchedBy(func(messages []interface{}) bool {
+			if len(messages) != 1 {
+				return false
+			}
+			event, ok := messages[0].(*cloud.OrderEvent)
+			return ok && event.Order.Id == order.Id
+		})).
+			Return([]*string{&messageId}, nil).
+			Once()
+
+		req := newRequest(t, bulk_update.BulkUpdateOrderProductionInput{
+			OrderIds: []string{order.Id, failedId},
+			State:    "Delivered",
+		})
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(bulkUpdateOrderProductionService, updateOrderTopic)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var results []order_entity.BulkUpdateResult
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &results))
+		assert.Len(t, results, 2)
+		assert.True(t, results[0].Success)
+		assert.False(t, results[1].Success)
+		assert.Equal(t, http.StatusBadRequest, results[1].Error.Code)
+
+		bulkUpdateOrderProductionService.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+
+	t.Run("Should not publish when no order was updated", func(t *testing.T) {
+		// Arrange
+		bulkUpdateOrderProductionService := services_mocks.NewMockBulkUpdateOrderProductionService[bulk_update.BulkUpdateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		failedId := uuid.NewString()
+
+		bulkUpdateOrderProductionService.On("Handle", mock.Anything, mock.Anything).
+			Return([]order_entity.BulkUpdateResult{
+				order_entity.NewBulkUpdateFailure(failedId, custom_error.ErrOrderNotFound),
+			}, nil).
+			Once()
+
+		req := newRequest(t, bulk_update.BulkUpdateOrderProductionInput{
+			OrderIds: []string{failedId},
+			State:    "Delivered",
+		})
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(bulkUpdateOrderProductionService, updateOrderTopic)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		bulkUpdateOrderProductionService.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error", func(t *testing.T) {
+		// Arrange
+		bulkUpdateOrderProductionService := services_mocks.NewMockBulkUpdateOrderProductionService[bulk_update.BulkUpdateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		bulkUpdateOrderProductionService.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, custom_error.ErrRequestNotValid).
+			Once()
+
+		req := newRequest(t, bulk_update.BulkUpdateOrderProductionInput{})
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(bulkUpdateOrderProductionService, updateOrderTopic)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		bulkUpdateOrderProductionService.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		bulkUpdateOrderProductionService := services_mocks.NewMockBulkUpdateOrderProductionService[bulk_update.BulkUpdateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		bulkUpdateOrderProductionService.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		req := newRequest(t, bulk_update.BulkUpdateOrderProductionInput{})
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(bulkUpdateOrderProductionService, updateOrderTopic)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		bulkUpdateOrderProductionService.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+}
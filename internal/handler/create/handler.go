@@ -0,0 +1,66 @@
+package create
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	createOrderProductionService service.CreateOrderProductionService[create.CreateOrderProductionInput]
+	updateOrderTopic             cloud.TopicService
+}
+
+func NewHandler(
+	createOrderProductionService service.CreateOrderProductionService[create.CreateOrderProductionInput],
+	updateOrderTopic cloud.TopicService,
+) *Handler {
+	return &Handler{
+		createOrderProductionService: createOrderProductionService,
+		updateOrderTopic:             updateOrderTopic,
+	}
+}
+
+func (h *Handler) Handle(c echo.Context) error {
+	var request create.CreateOrderProductionInput
+
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	userId := token.UserIdFromContext(c.Request().Context())
+
+	request.Origin = order_entity.ManualOrigin
+	request.CreatedBy = userId
+
+	ctx := c.Request().Context()
+
+	order, err := h.createOrderProductionService.Handle(ctx, request)
+	if err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	order.RefreshStateTitle()
+
+	messageId, err := h.updateOrderTopic.PublishMessage(ctx, cloud.NewOrderEvent(order, token.EventActorFromContext(ctx)))
+	if err != nil {
+		slog.ErrorContext(ctx, "error publishing message to update order topic", "error", err)
+	}
+
+	if messageId != nil {
+		slog.InfoContext(ctx, "message published to update order topic", "message_id", *messageId)
+	}
+
+	return c.JSON(http.StatusCreated, order)
+}
@@ -0,0 +1,195 @@
+package create
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	services_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRequestBody(t *testing.T) *bytes.Buffer {
+	body, err := json.Marshal(create.CreateOrderProductionInput{
+		OrderId: uuid.NewString(),
+		Items: []create.CreateOrderProductionItemInput{
+			{
+				Id:       uuid.NewString(),
+				Name:     "Hamburger",
+				Quantity: 1,
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	return bytes.NewBuffer(body)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should create a manual order and publish the event", func(t *testing.T) {
+		// Arrange
+		createOrderProductionService := services_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+		order.SetManualOrigin("user-1")
+
+		createOrderProductionService.On("Handle", mock.Anything, mock.MatchedBy(func(request create.CreateOrderProductionInput) bool {
+			return request.Origin == order_entity.ManualOrigin && request.CreatedBy == "user-1"
+		})).
+			Return(&order, nil).
+			Once()
+
+		messageId := uuid.NewString()
+
+		updateOrderTopic.On("PublishMessage", mock.Anything, mock.MatchedBy(func(event *cloud.OrderEvent) bool {
+			return event.Actor == cloud.NewUserActor("user-1")
+		})).
+			Return(&messageId, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/", newRequestBody(t))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetRequest(req.WithContext(token.WithPrincipal(req.Context(), token.Principal{Subject: "user-1"})))
+
+		handler := NewHandler(createOrderProductionService, updateOrderTopic)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Contains(t, resp.Body.String(), `"origin":"manual"`)
+		assert.Contains(t, resp.Body.String(), `"created_by":"user-1"`)
+		createOrderProductionService.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+
+	t.Run("Should return the order even when the event is not published", func(t *testing.T) {
+		// Arrange
+		createOrderProductionService := services_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+
+		createOrderProductionService.On("Handle", mock.Anything, mock.Anything).
+			Return(&order, nil).
+			Once()
+
+		updateOrderTopic.On("PublishMessage", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/", newRequestBody(t))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetRequest(req.WithContext(token.WithPrincipal(req.Context(), token.Principal{Subject: "user-1"})))
+
+		handler := NewHandler(createOrderProductionService, updateOrderTopic)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		createOrderProductionService.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+
+	t.Run("Should return conflict when the order already exists", func(t *testing.T) {
+		// Arrange
+		createOrderProductionService := services_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		createOrderProductionService.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, custom_error.ErrOrderAlreadyExists).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/", newRequestBody(t))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetRequest(req.WithContext(token.WithPrincipal(req.Context(), token.Principal{Subject: "user-1"})))
+
+		handler := NewHandler(createOrderProductionService, updateOrderTopic)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusConflict,
+			Message: "unable to create the order",
+			Details: "order already exists",
+		}, he.Message)
+
+		createOrderProductionService.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		createOrderProductionService := services_mocks.NewMockCreateOrderProductionService[create.CreateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		createOrderProductionService.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/", newRequestBody(t))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetRequest(req.WithContext(token.WithPrincipal(req.Context(), token.Principal{Subject: "user-1"})))
+
+		handler := NewHandler(createOrderProductionService, updateOrderTopic)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		createOrderProductionService.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+}
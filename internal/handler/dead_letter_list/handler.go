@@ -0,0 +1,44 @@
+package dead_letter_list
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+const defaultMaxMessages = 100
+
+type ListDeadLetterInput struct {
+	Max int `query:"max" json:"max"`
+}
+
+type Handler struct {
+	deadLetterQueue dead_letter.DeadLetterQueueService
+}
+
+func NewHandler(deadLetterQueue dead_letter.DeadLetterQueueService) *Handler {
+	return &Handler{
+		deadLetterQueue: deadLetterQueue,
+	}
+}
+
+func (h *Handler) Handle(c echo.Context) error {
+	var request ListDeadLetterInput
+
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if request.Max <= 0 {
+		request.Max = defaultMaxMessages
+	}
+
+	messages, err := h.deadLetterQueue.ListMessages(c.Request().Context(), request.Max)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	return c.JSON(http.StatusOK, messages)
+}
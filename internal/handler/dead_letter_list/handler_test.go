@@ -0,0 +1,94 @@
+package dead_letter_list
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should list the dead letter messages", func(t *testing.T) {
+		// Arrange
+		deadLetterQueue := mocks.NewMockDeadLetterQueueService(t)
+
+		deadLetterQueue.On("ListMessages", mock.Anything, 5).
+			Return([]dead_letter.DeadLetterMessage{{MessageId: "123"}}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/admin/dlq?max=5", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(deadLetterQueue)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), `"message_id":"123"`)
+		deadLetterQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should use the default maximum when not informed", func(t *testing.T) {
+		// Arrange
+		deadLetterQueue := mocks.NewMockDeadLetterQueueService(t)
+
+		deadLetterQueue.On("ListMessages", mock.Anything, defaultMaxMessages).
+			Return([]dead_letter.DeadLetterMessage{}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/admin/dlq", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(deadLetterQueue)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		deadLetterQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		deadLetterQueue := mocks.NewMockDeadLetterQueueService(t)
+
+		deadLetterQueue.On("ListMessages", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/admin/dlq", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(deadLetterQueue)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		deadLetterQueue.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,54 @@
+package dead_letter_redrive
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type RedriveDeadLetterInput struct {
+	MessageIds []string `json:"message_ids"`
+	All        bool     `json:"all"`
+}
+
+type RedriveDeadLetterOutput struct {
+	Redriven []string `json:"redriven"`
+}
+
+type Handler struct {
+	deadLetterQueue dead_letter.DeadLetterQueueService
+}
+
+func NewHandler(deadLetterQueue dead_letter.DeadLetterQueueService) *Handler {
+	return &Handler{
+		deadLetterQueue: deadLetterQueue,
+	}
+}
+
+func (h *Handler) Handle(c echo.Context) error {
+	var request RedriveDeadLetterInput
+
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	// an empty selection means every message, so it must be explicitly asked for
+	if len(request.MessageIds) == 0 && !request.All {
+		return custom_error.NewHttpAppErrorFromBusinessError(custom_error.ErrRequestNotValid)
+	}
+
+	if request.All {
+		request.MessageIds = nil
+	}
+
+	redriven, err := h.deadLetterQueue.Redrive(c.Request().Context(), request.MessageIds)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	return c.JSON(http.StatusOK, RedriveDeadLetterOutput{
+		Redriven: redriven,
+	})
+}
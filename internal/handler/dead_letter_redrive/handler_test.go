@@ -0,0 +1,122 @@
+package dead_letter_redrive
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should redrive the selected messages", func(t *testing.T) {
+		// Arrange
+		deadLetterQueue := mocks.NewMockDeadLetterQueueService(t)
+
+		deadLetterQueue.On("Redrive", mock.Anything, []string{"123"}).
+			Return([]string{"123"}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/admin/dlq/redrive", strings.NewReader(`{"message_ids":["123"]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(deadLetterQueue)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"redriven":["123"]}`, resp.Body.String())
+		deadLetterQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should redrive every message when requested", func(t *testing.T) {
+		// Arrange
+		deadLetterQueue := mocks.NewMockDeadLetterQueueService(t)
+
+		deadLetterQueue.On("Redrive", mock.Anything, []string(nil)).
+			Return([]string{"123", "456"}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/admin/dlq/redrive", strings.NewReader(`{"all":true}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(deadLetterQueue)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		deadLetterQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should return validation error when nothing is selected", func(t *testing.T) {
+		// Arrange
+		deadLetterQueue := mocks.NewMockDeadLetterQueueService(t)
+
+		req := httptest.NewRequest(echo.POST, "/admin/dlq/redrive", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(deadLetterQueue)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		deadLetterQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		deadLetterQueue := mocks.NewMockDeadLetterQueueService(t)
+
+		deadLetterQueue.On("Redrive", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/admin/dlq/redrive", strings.NewReader(`{"all":true}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(deadLetterQueue)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		deadLetterQueue.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,39 @@
+package dead_letter_replay
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type ReplayDeadLetterInput struct {
+	DryRun bool `query:"dry_run"`
+}
+
+type Handler struct {
+	deadLetterQueue dead_letter.DeadLetterQueueService
+}
+
+func NewHandler(deadLetterQueue dead_letter.DeadLetterQueueService) *Handler {
+	return &Handler{
+		deadLetterQueue: deadLetterQueue,
+	}
+}
+
+// Handle replays the JSONL request body, one order production message per line
+func (h *Handler) Handle(c echo.Context) error {
+	var request ReplayDeadLetterInput
+
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &request); err != nil {
+		return err
+	}
+
+	results, err := h.deadLetterQueue.Replay(c.Request().Context(), c.Request().Body, request.DryRun)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusBadRequest, "unable to read the messages", err)
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
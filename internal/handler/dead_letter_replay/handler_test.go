@@ -0,0 +1,70 @@
+package dead_letter_replay
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should replay the messages in dry run mode", func(t *testing.T) {
+		// Arrange
+		deadLetterQueue := mocks.NewMockDeadLetterQueueService(t)
+
+		deadLetterQueue.On("Replay", mock.Anything, mock.Anything, true).
+			Return([]dead_letter.ReplayResult{{Line: 1, Success: true}}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/admin/dlq/replay?dry_run=true", strings.NewReader(`{"order_id":"123"}`))
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(deadLetterQueue)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `[{"line":1,"success":true}]`, resp.Body.String())
+		deadLetterQueue.AssertExpectations(t)
+	})
+
+	t.Run("Should return bad request when the body cannot be read", func(t *testing.T) {
+		// Arrange
+		deadLetterQueue := mocks.NewMockDeadLetterQueueService(t)
+
+		deadLetterQueue.On("Replay", mock.Anything, mock.Anything, false).
+			Return(nil, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.POST, "/admin/dlq/replay", strings.NewReader(""))
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(deadLetterQueue)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		deadLetterQueue.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/export"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service service.ExportOrderProductionService[export.ExportOrderProductionInput]
+}
+
+func NewHandler(
+	service service.ExportOrderProductionService[export.ExportOrderProductionInput],
+) *Handler {
+	return &Handler{service: service}
+}
+
+// Handle streams the orders as an attachment, the status is only sent with the
+// first byte so the errors before it are still returned as problem details
+func (h *Handler) Handle(ctx echo.Context) error {
+	var request export.ExportOrderProductionInput
+
+	if err := ctx.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return custom_error.NewHttpAppErrorFromBusinessError(err)
+	}
+
+	format := request.GetFormat()
+
+	response := ctx.Response()
+	response.Header().Set(echo.HeaderContentType, export.ContentType(format))
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName(request, format)))
+
+	count, err := h.service.Handle(ctx.Request().Context(), request, &flushWriter{response: response})
+	if err != nil && !response.Committed {
+		response.Header().Del(echo.HeaderContentDisposition)
+
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	if err != nil {
+		// the status was already sent, the client sees a truncated file
+		slog.ErrorContext(ctx.Request().Context(), "error exporting the orders", "error", err, "exported", count)
+		return nil
+	}
+
+	slog.InfoContext(ctx.Request().Context(), "orders exported", "format", format, "exported", count)
+
+	return nil
+}
+
+func fileName(request export.ExportOrderProductionInput, format string) string {
+	return fmt.Sprintf("orders_%s_%s.%s", request.From, request.To, format)
+}
+
+// flushWriter sends every chunk to the client instead of buffering the whole
+// export in memory
+type flushWriter struct {
+	response *echo.Response
+}
+
+func (w *flushWriter) Write(p []byte) (int, error) {
+	n, err := w.response.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	w.response.Flush()
+
+	return n, nil
+}
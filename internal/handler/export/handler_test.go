@@ -0,0 +1,129 @@
+package export
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/export"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should stream the export as an attachment", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockExportOrderProductionService[export.ExportOrderProductionInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				out := args.Get(2).(io.Writer)
+				_, _ = out.Write([]byte("store_id,order_id\n"))
+			}).
+			Return(1, nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?from=2024-05-01&to=2024-05-31", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "text/csv; charset=UTF-8", resp.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="orders_2024-05-01_2024-05-31.csv"`, resp.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, "store_id,order_id\n", resp.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return the JSONL content type", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockExportOrderProductionService[export.ExportOrderProductionInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything, mock.Anything).
+			Return(0, nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?from=2024-05-01&to=2024-05-31&format=jsonl", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "application/x-ndjson", resp.Header().Get(echo.HeaderContentType))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the request is not valid", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockExportOrderProductionService[export.ExportOrderProductionInput](t)
+
+		req := httptest.NewRequest(http.MethodGet, "/?from=2024-05-31&to=2024-05-01", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the caller has no access to the store", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockExportOrderProductionService[export.ExportOrderProductionInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything, mock.Anything).
+			Return(0, custom_error.ErrStoreForbidden).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?from=2024-05-01&to=2024-05-31", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, he.Code)
+		assert.ErrorIs(t, err, custom_error.ErrStoreForbidden)
+		assert.Empty(t, resp.Header().Get(echo.HeaderContentDisposition))
+		service.AssertExpectations(t)
+	})
+}
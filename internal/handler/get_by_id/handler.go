@@ -6,6 +6,7 @@ import (
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_id"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/http_cache"
 	"github.com/labstack/echo/v4"
 )
 
@@ -37,5 +38,15 @@ func (h *Handler) Handle(ctx echo.Context) error {
 		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
 	}
 
+	validators := http_cache.Validators{
+		ETag:          http_cache.NewVersionETag(order.Version()),
+		LastModified:  order.UpdatedAt,
+		ModifiedSince: true,
+	}
+
+	if http_cache.NotModified(ctx, validators) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
 	return ctx.JSON(http.StatusOK, order)
 }
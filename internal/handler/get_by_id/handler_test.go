@@ -6,12 +6,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_id"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/http_cache"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -150,4 +152,74 @@ func TestHandle(t *testing.T) {
 
 		service.AssertExpectations(t)
 	})
+
+	t.Run("Should return the validators of the order", func(t *testing.T) {
+		// Arrange
+		order := order_entity.NewOrder(uuid.NewString(), time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+
+		service := mocks.NewMockGetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput](t)
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(order, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/production/:id")
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(order.Id)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, http_cache.NewVersionETag(order.Version()), resp.Header().Get("ETag"))
+		assert.Equal(t, "Wed, 01 May 2024 12:00:00 GMT", resp.Header().Get(echo.HeaderLastModified))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return not modified when the client has the order", func(t *testing.T) {
+		// Arrange
+		order := order_entity.NewOrder(uuid.NewString(), time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+
+		service := mocks.NewMockGetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput](t)
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(order, nil).
+			Twice()
+
+		cases := map[string]string{
+			"If-None-Match":     http_cache.NewVersionETag(order.Version()),
+			"If-Modified-Since": "Wed, 01 May 2024 12:00:00 GMT",
+		}
+
+		for header, value := range cases {
+			req := httptest.NewRequest(echo.GET, "/", nil)
+			req.Header.Set(header, value)
+			resp := httptest.NewRecorder()
+
+			e := echo.New()
+			ctx := e.NewContext(req, resp)
+			ctx.SetPath("/production/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(order.Id)
+
+			handler := NewHandler(service)
+
+			// Act
+			err := handler.Handle(ctx)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNotModified, resp.Code, header)
+			assert.Empty(t, resp.Body.String(), header)
+		}
+
+		service.AssertExpectations(t)
+	})
 }
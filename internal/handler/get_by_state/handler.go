@@ -6,6 +6,7 @@ import (
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_state"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/http_cache"
 	"github.com/labstack/echo/v4"
 )
 
@@ -37,5 +38,24 @@ func (h *Handler) Handle(ctx echo.Context) error {
 		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
 	}
 
+	versions := make([]string, 0, len(orders))
+	validators := http_cache.Validators{}
+
+	for _, order := range orders {
+		versions = append(versions, order.Version())
+
+		if order.UpdatedAt.After(validators.LastModified) {
+			validators.LastModified = order.UpdatedAt
+		}
+	}
+
+	// an order leaving the state does not change the LastModified of the list,
+	// so only the ETag is used
+	validators.ETag = http_cache.NewVersionETag(versions...)
+
+	if http_cache.NotModified(ctx, validators) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
 	return ctx.JSON(http.StatusOK, orders)
 }
@@ -6,11 +6,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_state"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/http_cache"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -141,4 +143,63 @@ func TestHandle(t *testing.T) {
 
 		service.AssertExpectations(t)
 	})
+
+	t.Run("Should return not modified when the client has the orders", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		orders := []order_entity.Order{
+			order_entity.NewOrder("order-1", now),
+			order_entity.NewOrder("order-2", now.Add(time.Minute)),
+		}
+
+		service := mocks.NewMockGetOrderProductionByStateService[get_by_state.GetOrderProductionByStateInput](t)
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(orders, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/?state=Received", nil)
+		req.Header.Set("If-None-Match", http_cache.NewVersionETag(orders[0].Version(), orders[1].Version()))
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotModified, resp.Code)
+		assert.Equal(t, "Wed, 01 May 2024 12:01:00 GMT", resp.Header().Get(echo.HeaderLastModified))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should not use If-Modified-Since for the list", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		service := mocks.NewMockGetOrderProductionByStateService[get_by_state.GetOrderProductionByStateInput](t)
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return([]order_entity.Order{order_entity.NewOrder("order-1", now)}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/?state=Received", nil)
+		req.Header.Set("If-Modified-Since", "Wed, 01 May 2024 12:00:00 GMT")
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		service.AssertExpectations(t)
+	})
 }
@@ -3,33 +3,33 @@ package health
 import (
 	"net/http"
 
-	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/database"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
 	"github.com/labstack/echo/v4"
 )
 
 type Handler struct {
-	db database.DatabaseService
+	reporter health.Reporter
+	probe    health.Probe
 }
 
-func NewHandler(db database.DatabaseService) *Handler {
+func NewHandler(reporter health.Reporter, probe health.Probe) *Handler {
 	return &Handler{
-		db: db,
+		reporter: reporter,
+		probe:    probe,
 	}
 }
 
 func (h *Handler) Handle(ctx echo.Context) error {
-	dbStatus := h.db.Health()
-
-	data := map[string]*health.HealthStatus{
-		"database": dbStatus,
-	}
+	report := h.reporter.Report(ctx.Request().Context(), h.probe)
 
 	code := http.StatusOK
 
-	if dbStatus.HasError() {
-		code = http.StatusBadRequest
+	if !report.IsHealthy() {
+		code = http.StatusServiceUnavailable
 	}
 
-	return ctx.JSON(code, data)
+	// the probes must always see the current status
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+
+	return ctx.JSON(code, report)
 }
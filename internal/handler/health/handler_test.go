@@ -1,23 +1,48 @@
 package health
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
-	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/database/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
+func newRegistry(t *testing.T, databaseErr error, secretsErr error) *health.Registry {
+	timeProvider := mocks.NewMockTimeProvider(t)
+	timeProvider.On("GetTime").Return(time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC)).Maybe()
+
+	registry := health.NewRegistry(timeProvider, time.Second, time.Minute)
+	registry.Register(health.Check{
+		Name: "database",
+		HealthCheck: health.HealthCheckFunc(func(ctx context.Context) *health.HealthStatus {
+			return health.NewHealthStatus(databaseErr)
+		}),
+		Critical: true,
+	})
+	registry.Register(health.Check{
+		Name: "secrets",
+		HealthCheck: health.HealthCheckFunc(func(ctx context.Context) *health.HealthStatus {
+			return health.NewHealthStatus(secretsErr)
+		}),
+	})
+
+	return registry
+}
+
 func TestNewHandler(t *testing.T) {
 	t.Run("Should return a new handler", func(t *testing.T) {
 		// Arrange
-		db := mocks.NewMockDatabaseService(t)
+		registry := health.NewRegistry(mocks.NewMockTimeProvider(t), time.Second, time.Minute)
 
 		// Act
-		handler := NewHandler(db)
+		handler := NewHandler(registry, health.ReadinessProbe)
 
 		// Assert
 		assert.NotNil(t, handler)
@@ -25,21 +50,40 @@ func TestNewHandler(t *testing.T) {
 }
 
 func TestHandler_Handle(t *testing.T) {
-	t.Run("Should return a map with healthy database status", func(t *testing.T) {
+	t.Run("Should return ok when every component is healthy", func(t *testing.T) {
 		// Arrange
-		db := mocks.NewMockDatabaseService(t)
+		registry := newRegistry(t, nil, nil)
+
+		req := httptest.NewRequest(echo.GET, "/health/ready", nil)
+		resp := httptest.NewRecorder()
+
+		echo := echo.New()
+		ctx := echo.NewContext(req, resp)
+
+		handler := NewHandler(registry, health.ReadinessProbe)
+
+		// Act
+		err := handler.Handle(ctx)
 
-		db.On("Health").Return(&health.HealthStatus{
-			Status: "healthy",
-		}, nil)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "no-store", resp.Header().Get("Cache-Control"))
+		assert.Contains(t, resp.Body.String(), `"status":"healthy"`)
+		assert.Contains(t, resp.Body.String(), `"database":{"status":"healthy","critical":true`)
+	})
 
-		req := httptest.NewRequest(echo.GET, "/health", nil)
+	t.Run("Should return ok when only a non critical component is unhealthy", func(t *testing.T) {
+		// Arrange
+		registry := newRegistry(t, nil, errors.New("access denied"))
+
+		req := httptest.NewRequest(echo.GET, "/health/ready", nil)
 		resp := httptest.NewRecorder()
 
 		echo := echo.New()
 		ctx := echo.NewContext(req, resp)
 
-		handler := NewHandler(db)
+		handler := NewHandler(registry, health.ReadinessProbe)
 
 		// Act
 		err := handler.Handle(ctx)
@@ -47,32 +91,50 @@ func TestHandler_Handle(t *testing.T) {
 		// Assert
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, resp.Code)
-		assert.JSONEq(t, `{"database": {"status":"healthy"}}`, resp.Body.String())
+		assert.Contains(t, resp.Body.String(), `"status":"degraded"`)
+		assert.Contains(t, resp.Body.String(), `"secrets":{"status":"unhealthy","err":"access denied","critical":false`)
 	})
 
-	t.Run("Should return a map with unhealthy database status", func(t *testing.T) {
+	t.Run("Should return service unavailable when a critical component is unhealthy", func(t *testing.T) {
 		// Arrange
-		db := mocks.NewMockDatabaseService(t)
+		registry := newRegistry(t, errors.New("connection refused"), nil)
 
-		db.On("Health").Return(&health.HealthStatus{
-			Status: "unhealthy",
-			Err:    "error",
-		}, nil)
+		req := httptest.NewRequest(echo.GET, "/health/ready", nil)
+		resp := httptest.NewRecorder()
 
-		req := httptest.NewRequest(echo.GET, "/health", nil)
+		echo := echo.New()
+		ctx := echo.NewContext(req, resp)
+
+		handler := NewHandler(registry, health.ReadinessProbe)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		assert.Contains(t, resp.Body.String(), `"status":"unhealthy"`)
+		assert.Contains(t, resp.Body.String(), `"database":{"status":"unhealthy","err":"connection refused","critical":true`)
+	})
+
+	t.Run("Should only report the components of the probe", func(t *testing.T) {
+		// Arrange
+		registry := newRegistry(t, errors.New("connection refused"), nil)
+
+		req := httptest.NewRequest(echo.GET, "/health/live", nil)
 		resp := httptest.NewRecorder()
 
 		echo := echo.New()
 		ctx := echo.NewContext(req, resp)
 
-		handler := NewHandler(db)
+		handler := NewHandler(registry, health.LivenessProbe)
 
 		// Act
 		err := handler.Handle(ctx)
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, resp.Code)
-		assert.JSONEq(t, `{"database": {"status":"unhealthy", "err": "error"}}`, resp.Body.String())
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"status":"healthy","components":{}}`, resp.Body.String())
 	})
 }
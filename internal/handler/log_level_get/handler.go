@@ -0,0 +1,27 @@
+package log_level_get
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type Response struct {
+	Level string `json:"level"`
+}
+
+type Handler struct {
+	level *slog.LevelVar
+}
+
+func NewHandler(level *slog.LevelVar) *Handler {
+	return &Handler{level: level}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, Response{
+		Level: logger.FormatLevel(h.level.Level()),
+	})
+}
@@ -0,0 +1,37 @@
+package log_level_get
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should return the level of the logs", func(t *testing.T) {
+		// Arrange
+		level := new(slog.LevelVar)
+		level.Set(slog.LevelWarn)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/log-level")
+
+		handler := NewHandler(level)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"level":"warn"}`, resp.Body.String())
+	})
+}
@@ -0,0 +1,88 @@
+package log_level_update
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type Request struct {
+	Level string `json:"level"`
+}
+
+type Response struct {
+	Level         string `json:"level"`
+	PreviousLevel string `json:"previous_level"`
+}
+
+// levelSnapshot is the level stored in the audit log before and after the
+// change
+type levelSnapshot struct {
+	Level string `json:"level"`
+}
+
+// Handler changes the level of the logs of this replica until it restarts,
+// the other replicas keep their level. The change is recorded in the audit log
+// with the replica as the resource id
+type Handler struct {
+	level    *slog.LevelVar
+	recorder audit.Recorder
+	replica  string
+}
+
+func NewHandler(level *slog.LevelVar, recorder audit.Recorder, replica string) *Handler {
+	return &Handler{
+		level:    level,
+		recorder: recorder,
+		replica:  replica,
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	var request Request
+
+	if err := ctx.Bind(&request); err != nil {
+		return err
+	}
+
+	level, err := logger.ParseLevel(request.Level)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusBadRequest, "invalid log level", err)
+	}
+
+	previous := h.level.Level()
+
+	context := ctx.Request().Context()
+
+	// the level is only changed when the change is recorded
+	if err := h.recorder.Record(context,
+		audit_entity.LogLevelChangedAction,
+		audit_entity.LogLevelResource,
+		h.replica,
+		levelSnapshot{Level: logger.FormatLevel(previous)},
+		levelSnapshot{Level: logger.FormatLevel(level)},
+	); err != nil {
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	h.level.Set(level)
+
+	// logged as a warning so the change is recorded whatever the new level
+	slog.WarnContext(context, "log level changed",
+		"previous_level", logger.FormatLevel(previous),
+		"level", logger.FormatLevel(level),
+		"user_id", token.UserIdFromContext(context),
+		"replica", h.replica,
+	)
+
+	return ctx.JSON(http.StatusOK, Response{
+		Level:         logger.FormatLevel(level),
+		PreviousLevel: logger.FormatLevel(previous),
+	})
+}
@@ -0,0 +1,122 @@
+package log_level_update
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should change the level of the logs", func(t *testing.T) {
+		// Arrange
+		level := new(slog.LevelVar)
+
+		recorder := mocks.NewMockRecorder(t)
+
+		recorder.On("Record", mock.Anything,
+			audit_entity.LogLevelChangedAction,
+			audit_entity.LogLevelResource,
+			"replica-1",
+			levelSnapshot{Level: "info"},
+			levelSnapshot{Level: "debug"},
+		).
+			Return(nil).
+			Once()
+
+		req := httptest.NewRequest(echo.PUT, "/", strings.NewReader(`{"level":"debug"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/log-level")
+
+		handler := NewHandler(level, recorder, "replica-1")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"level":"debug","previous_level":"info"}`, resp.Body.String())
+		assert.Equal(t, slog.LevelDebug, level.Level())
+		recorder.AssertExpectations(t)
+	})
+
+	t.Run("Should return bad request when the level is unknown", func(t *testing.T) {
+		// Arrange
+		level := new(slog.LevelVar)
+
+		recorder := mocks.NewMockRecorder(t)
+
+		req := httptest.NewRequest(echo.PUT, "/", strings.NewReader(`{"level":"verbose"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/log-level")
+
+		handler := NewHandler(level, recorder, "replica-1")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, "invalid log level", he.Message.(custom_error.AppError).Message)
+		assert.Equal(t, slog.LevelInfo, level.Level())
+		recorder.AssertExpectations(t)
+	})
+
+	t.Run("Should not change the level when the change cannot be recorded", func(t *testing.T) {
+		// Arrange
+		level := new(slog.LevelVar)
+
+		recorder := mocks.NewMockRecorder(t)
+
+		recorder.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("something went wrong")).
+			Once()
+
+		req := httptest.NewRequest(echo.PUT, "/", strings.NewReader(`{"level":"debug"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/admin/log-level")
+
+		handler := NewHandler(level, recorder, "replica-1")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		assert.Equal(t, slog.LevelInfo, level.Level())
+		recorder.AssertExpectations(t)
+	})
+}
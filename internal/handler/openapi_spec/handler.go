@@ -0,0 +1,41 @@
+package openapi_spec
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/openapi"
+	"github.com/labstack/echo/v4"
+)
+
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+
+	mimeYAML = "application/yaml"
+)
+
+type Handler struct {
+	format Format
+}
+
+func NewHandler(format Format) *Handler {
+	return &Handler{
+		format: format,
+	}
+}
+
+func (h *Handler) Handle(c echo.Context) error {
+	if h.format == FormatYAML {
+		return c.Blob(http.StatusOK, mimeYAML, openapi.YAML())
+	}
+
+	content, err := openapi.JSON()
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, content)
+}
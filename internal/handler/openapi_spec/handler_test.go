@@ -0,0 +1,56 @@
+package openapi_spec
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should return the specification as YAML", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(FormatYAML)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, mimeYAML, resp.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, resp.Body.String(), "openapi: 3.0.3")
+	})
+
+	t.Run("Should return the specification as JSON", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(FormatJSON)
+
+		var document map[string]interface{}
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, echo.MIMEApplicationJSON, resp.Header().Get(echo.HeaderContentType))
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &document))
+		assert.Equal(t, "3.0.3", document["openapi"])
+	})
+}
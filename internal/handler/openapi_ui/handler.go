@@ -0,0 +1,39 @@
+package openapi_ui
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+//go:embed index.html
+var indexHtml string
+
+var indexTemplate = template.Must(template.New("index").Parse(indexHtml))
+
+type pageData struct {
+	SpecUrl string
+}
+
+type Handler struct {
+	specUrl string
+}
+
+func NewHandler(specUrl string) *Handler {
+	return &Handler{
+		specUrl: specUrl,
+	}
+}
+
+func (h *Handler) Handle(c echo.Context) error {
+	var buf bytes.Buffer
+	if err := indexTemplate.Execute(&buf, pageData{SpecUrl: h.specUrl}); err != nil {
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	return c.HTMLBlob(http.StatusOK, buf.Bytes())
+}
@@ -0,0 +1,32 @@
+package openapi_ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should return the Swagger UI page", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler("/openapi.json")
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, echo.MIMETextHTMLCharsetUTF8, resp.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, resp.Body.String(), `url: "\/openapi.json"`)
+	})
+}
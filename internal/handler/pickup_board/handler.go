@@ -0,0 +1,44 @@
+package pickup_board
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_pickup_board"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/http_cache"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service service.GetPickupBoardService[get_pickup_board.GetPickupBoardInput]
+	maxAge  time.Duration
+}
+
+func NewHandler(
+	service service.GetPickupBoardService[get_pickup_board.GetPickupBoardInput],
+	maxAge time.Duration,
+) *Handler {
+	return &Handler{
+		service: service,
+		maxAge:  maxAge,
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	context := ctx.Request().Context()
+
+	board, err := h.service.Handle(context, get_pickup_board.GetPickupBoardInput{})
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	body, err := json.Marshal(board)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	return http_cache.Respond(ctx, h.maxAge, echo.MIMEApplicationJSON, body)
+}
@@ -0,0 +1,77 @@
+package pickup_board
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_pickup_board"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should return the pickup board", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetPickupBoardService[get_pickup_board.GetPickupBoardInput](t)
+
+		service.On("Handle", mock.Anything, get_pickup_board.GetPickupBoardInput{}).
+			Return(order_entity.PickupBoard{
+				Preparing: []order_entity.PickupBoardOrder{{Code: "C3FDAB"}},
+				Ready:     []order_entity.PickupBoardOrder{},
+			}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service, 5*time.Second)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "public, max-age=5", resp.Header().Get(echo.HeaderCacheControl))
+		assert.NotEmpty(t, resp.Header().Get("ETag"))
+		assert.Contains(t, resp.Body.String(), `"code":"C3FDAB"`)
+		assert.NotContains(t, resp.Body.String(), "items")
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetPickupBoardService[get_pickup_board.GetPickupBoardInput](t)
+
+		service.On("Handle", mock.Anything, get_pickup_board.GetPickupBoardInput{}).
+			Return(order_entity.PickupBoard{}, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service, 5*time.Second)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,66 @@
+package pickup_board_page
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_pickup_board"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/http_cache"
+	"github.com/labstack/echo/v4"
+)
+
+// minRefresh avoids screens reloading the page every second when the cache
+// max-age is very short
+const minRefresh = 5 * time.Second
+
+//go:embed board.html
+var boardHtml string
+
+var boardTemplate = template.Must(template.New("board").Parse(boardHtml))
+
+type pageData struct {
+	Board   order_entity.PickupBoard
+	Refresh int
+}
+
+type Handler struct {
+	service service.GetPickupBoardService[get_pickup_board.GetPickupBoardInput]
+	maxAge  time.Duration
+}
+
+func NewHandler(
+	service service.GetPickupBoardService[get_pickup_board.GetPickupBoardInput],
+	maxAge time.Duration,
+) *Handler {
+	return &Handler{
+		service: service,
+		maxAge:  maxAge,
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	context := ctx.Request().Context()
+
+	board, err := h.service.Handle(context, get_pickup_board.GetPickupBoardInput{})
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	refresh := max(h.maxAge, minRefresh)
+
+	var body bytes.Buffer
+	if err := boardTemplate.Execute(&body, pageData{
+		Board:   board,
+		Refresh: int(refresh.Seconds()),
+	}); err != nil {
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	return http_cache.Respond(ctx, h.maxAge, echo.MIMETextHTMLCharsetUTF8, body.Bytes())
+}
@@ -0,0 +1,99 @@
+package pickup_board_page
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_pickup_board"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should render the pickup board", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetPickupBoardService[get_pickup_board.GetPickupBoardInput](t)
+
+		service.On("Handle", mock.Anything, get_pickup_board.GetPickupBoardInput{}).
+			Return(order_entity.PickupBoard{
+				Preparing: []order_entity.PickupBoardOrder{{Code: "C3FDAB"}},
+				Ready:     []order_entity.PickupBoardOrder{{Code: "0A1B2C"}},
+			}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service, time.Second)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, echo.MIMETextHTMLCharsetUTF8, resp.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, resp.Body.String(), "<li>C3FDAB</li>")
+		assert.Contains(t, resp.Body.String(), "<li>0A1B2C</li>")
+		assert.Contains(t, resp.Body.String(), `content="5"`)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should render the empty board", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetPickupBoardService[get_pickup_board.GetPickupBoardInput](t)
+
+		service.On("Handle", mock.Anything, get_pickup_board.GetPickupBoardInput{}).
+			Return(order_entity.PickupBoard{}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service, 10*time.Second)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, resp.Body.String(), "No orders")
+		assert.Contains(t, resp.Body.String(), `content="10"`)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetPickupBoardService[get_pickup_board.GetPickupBoardInput](t)
+
+		service.On("Handle", mock.Anything, get_pickup_board.GetPickupBoardInput{}).
+			Return(order_entity.PickupBoard{}, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(service, time.Second)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		service.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,32 @@
+package schema_get
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/schema"
+	"github.com/labstack/echo/v4"
+)
+
+const mimeSchemaJSON = "application/schema+json"
+
+type Handler struct {
+}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+func (h *Handler) Handle(c echo.Context) error {
+	content, err := schema.Get(c.Param("name"))
+	if err != nil {
+		if errors.Is(err, schema.ErrSchemaNotFound) {
+			return custom_error.NewHttpAppError(http.StatusNotFound, "unable to find the schema", err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	return c.Blob(http.StatusOK, mimeSchemaJSON, content)
+}
@@ -0,0 +1,67 @@
+package schema_get
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/schema"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should return the schema", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/schemas/:name")
+		ctx.SetParamNames("name")
+		ctx.SetParamValues(schema.OrderProductionSchema)
+
+		handler := NewHandler()
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, mimeSchemaJSON, resp.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, resp.Body.String(), "urn:fastfood:production:order-production:v1")
+	})
+
+	t.Run("Should return not found error", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/schemas/:name")
+		ctx.SetParamNames("name")
+		ctx.SetParamValues("unknown")
+
+		handler := NewHandler()
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, custom_error.AppError{
+			Code:    http.StatusNotFound,
+			Message: "unable to find the schema",
+			Details: "schema not found",
+		}, he.Message)
+	})
+}
@@ -0,0 +1,35 @@
+package schema_list
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/schema"
+	"github.com/labstack/echo/v4"
+)
+
+type SchemaOutput struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+type Handler struct {
+}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+func (h *Handler) Handle(c echo.Context) error {
+	names := schema.Names()
+
+	schemas := make([]SchemaOutput, 0, len(names))
+	for _, name := range names {
+		schemas = append(schemas, SchemaOutput{
+			Name: name,
+			Url:  fmt.Sprintf("%s/%s", c.Request().URL.Path, name),
+		})
+	}
+
+	return c.JSON(http.StatusOK, schemas)
+}
@@ -0,0 +1,38 @@
+package schema_list
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/schema"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should list the schemas", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.GET, "/api/v1/schemas", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler()
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var schemas []SchemaOutput
+		err = json.Unmarshal(resp.Body.Bytes(), &schemas)
+		assert.NoError(t, err)
+		assert.Len(t, schemas, len(schema.Names()))
+		assert.Equal(t, "/api/v1/schemas/"+schemas[0].Name, schemas[0].Url)
+	})
+}
@@ -0,0 +1,47 @@
+package state_machine
+
+import (
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatMermaid = "mermaid"
+	FormatDot     = "dot"
+
+	MIMETextMermaid = "text/vnd.mermaid; charset=UTF-8"
+	MIMETextDot     = "text/vnd.graphviz; charset=UTF-8"
+)
+
+type Handler struct {
+	machine order_entity.StateMachine
+}
+
+func NewHandler() *Handler {
+	return &Handler{
+		machine: order_entity.NewStateMachine(),
+	}
+}
+
+// Handle describes the states of the orders, the format query selects JSON
+// (default), Mermaid or Graphviz DOT
+func (h *Handler) Handle(c echo.Context) error {
+	switch c.QueryParam("format") {
+	case "", FormatJSON:
+		return c.JSON(http.StatusOK, h.machine)
+	case FormatMermaid:
+		return c.Blob(http.StatusOK, MIMETextMermaid, []byte(h.machine.Mermaid()))
+	case FormatDot:
+		return c.Blob(http.StatusOK, MIMETextDot, []byte(h.machine.Dot()))
+	}
+
+	return custom_error.NewHttpAppErrorFromBusinessError(custom_error.NewValidationError(custom_error.Violation{
+		Field:   "format",
+		Rule:    "oneof",
+		Message: "must be one of: json, mermaid, dot",
+	}))
+}
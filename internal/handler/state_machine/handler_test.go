@@ -0,0 +1,97 @@
+package state_machine
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should return the state machine as JSON", func(t *testing.T) {
+		// Arrange
+		handler := NewHandler()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var machine order_entity.StateMachine
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &machine))
+		assert.Equal(t, order_entity.NewStateMachine(), machine)
+	})
+
+	t.Run("Should return the state machine as Mermaid", func(t *testing.T) {
+		// Arrange
+		handler := NewHandler()
+
+		req := httptest.NewRequest(http.MethodGet, "/?format=mermaid", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, MIMETextMermaid, resp.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, order_entity.NewStateMachine().Mermaid(), resp.Body.String())
+	})
+
+	t.Run("Should return the state machine as DOT", func(t *testing.T) {
+		// Arrange
+		handler := NewHandler()
+
+		req := httptest.NewRequest(http.MethodGet, "/?format=dot", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, MIMETextDot, resp.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, order_entity.NewStateMachine().Dot(), resp.Body.String())
+	})
+
+	t.Run("Should return error when the format is not supported", func(t *testing.T) {
+		// Arrange
+		handler := NewHandler()
+
+		req := httptest.NewRequest(http.MethodGet, "/?format=svg", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Len(t, custom_error.GetViolations(err), 1)
+	})
+}
@@ -0,0 +1,92 @@
+package stream_sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+const LastEventIdHeader = "Last-Event-ID"
+
+type Handler struct {
+	streamer stream.Streamer
+}
+
+func NewHandler(streamer stream.Streamer) *Handler {
+	return &Handler{streamer: streamer}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	filter, err := stream.NewFilter(ctx.QueryParam("state"), ctx.QueryParam("station"))
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusBadRequest, "invalid stream filter", err)
+	}
+
+	lastEventIdValue := ctx.Request().Header.Get(LastEventIdHeader)
+	if lastEventIdValue == "" {
+		lastEventIdValue = ctx.QueryParam("last_event_id")
+	}
+
+	lastEventId, err := stream.ParseLastEventId(lastEventIdValue)
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusBadRequest, "invalid stream filter", err)
+	}
+
+	resp := ctx.Response()
+
+	// the stream lives longer than the server write timeout
+	if err := http.NewResponseController(resp.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		slog.WarnContext(ctx.Request().Context(), "unable to clear the stream write deadline", "error", err)
+	}
+
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set(echo.HeaderCacheControl, "no-cache")
+	resp.Header().Set(echo.HeaderConnection, "keep-alive")
+	resp.Header().Set("X-Accel-Buffering", "no")
+	resp.WriteHeader(http.StatusOK)
+	resp.Flush()
+
+	context := ctx.Request().Context()
+
+	if err := h.streamer.Stream(context, filter, lastEventId, &sink{resp: resp}); err != nil {
+		slog.ErrorContext(context, "order stream closed", "error", err)
+	}
+
+	return nil
+}
+
+type sink struct {
+	resp *echo.Response
+}
+
+func (s *sink) Send(event order_entity.OrderEvent) error {
+	data, err := json.Marshal(event.Order)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(s.resp, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data); err != nil {
+		return err
+	}
+
+	s.resp.Flush()
+
+	return nil
+}
+
+func (s *sink) Ping() error {
+	if _, err := fmt.Fprint(s.resp, ": ping\n\n"); err != nil {
+		return err
+	}
+
+	s.resp.Flush()
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package stream_sse
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should stream the events", func(t *testing.T) {
+		// Arrange
+		streamer := mocks.NewMockStreamer(t)
+
+		filter := stream.Filter{
+			States:  []order_entity.OrderState{order_entity.Received},
+			Station: "grill",
+		}
+
+		streamer.On("Stream", mock.Anything, filter, int64(41), mock.Anything).
+			Run(func(args mock.Arguments) {
+				sink := args.Get(3).(stream.Sink)
+				_ = sink.Send(order_entity.OrderEvent{
+					Id:    42,
+					Type:  order_entity.OrderCreatedEvent,
+					Order: order_entity.Order{Id: "order-1", State: order_entity.Received},
+				})
+				_ = sink.Ping()
+			}).
+			Return(nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/?state=Received&station=grill", nil)
+		req.Header.Set(LastEventIdHeader, "41")
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(streamer)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "text/event-stream", resp.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, resp.Body.String(), "id: 42\nevent: created\ndata: {\"id\":\"order-1\"")
+		assert.Contains(t, resp.Body.String(), ": ping\n\n")
+		streamer.AssertExpectations(t)
+	})
+
+	t.Run("Should read the last event id from the query", func(t *testing.T) {
+		// Arrange
+		streamer := mocks.NewMockStreamer(t)
+
+		streamer.On("Stream", mock.Anything, stream.Filter{}, int64(7), mock.Anything).
+			Return(nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/?last_event_id=7", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(streamer)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		streamer.AssertExpectations(t)
+	})
+
+	t.Run("Should return bad request when the state is unknown", func(t *testing.T) {
+		// Arrange
+		streamer := mocks.NewMockStreamer(t)
+
+		req := httptest.NewRequest(echo.GET, "/?state=Baking", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(streamer)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		streamer.AssertExpectations(t)
+	})
+
+	t.Run("Should return bad request when the last event id is invalid", func(t *testing.T) {
+		// Arrange
+		streamer := mocks.NewMockStreamer(t)
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		req.Header.Set(LastEventIdHeader, "abc")
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(streamer)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		streamer.AssertExpectations(t)
+	})
+}
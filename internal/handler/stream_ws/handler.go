@@ -0,0 +1,98 @@
+package stream_ws
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+const writeTimeout = 10 * time.Second
+
+type Handler struct {
+	streamer stream.Streamer
+	upgrader websocket.Upgrader
+}
+
+func NewHandler(streamer stream.Streamer) *Handler {
+	return &Handler{
+		streamer: streamer,
+		upgrader: websocket.Upgrader{
+			// kitchen displays are served from other origins, the token
+			// middleware already authenticated the request
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+func (h *Handler) Handle(ctx echo.Context) error {
+	filter, err := stream.NewFilter(ctx.QueryParam("state"), ctx.QueryParam("station"))
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusBadRequest, "invalid stream filter", err)
+	}
+
+	lastEventId, err := stream.ParseLastEventId(ctx.QueryParam("last_event_id"))
+	if err != nil {
+		return custom_error.NewHttpAppError(http.StatusBadRequest, "invalid stream filter", err)
+	}
+
+	conn, err := h.upgrader.Upgrade(ctx.Response(), ctx.Request(), nil)
+	if err != nil {
+		// the upgrader already replied to the client
+		return nil
+	}
+	defer conn.Close()
+
+	// the read deadline of the server is still set on the hijacked connection,
+	// the heartbeat detects broken clients instead
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx.Request().Context())
+	defer cancel()
+
+	// the client does not send messages, reading is needed to process the
+	// control frames and to notice when the connection is closed
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	if err := h.streamer.Stream(streamCtx, filter, lastEventId, &sink{conn: conn}); err != nil {
+		slog.ErrorContext(streamCtx, "order stream closed", "error", err)
+	}
+
+	_ = conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(writeTimeout))
+
+	return nil
+}
+
+type sink struct {
+	conn *websocket.Conn
+}
+
+func (s *sink) Send(event order_entity.OrderEvent) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+
+	return s.conn.WriteJSON(event)
+}
+
+func (s *sink) Ping() error {
+	return s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout))
+}
@@ -0,0 +1,91 @@
+package stream_ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should stream the events", func(t *testing.T) {
+		// Arrange
+		streamer := mocks.NewMockStreamer(t)
+
+		filter := stream.Filter{Station: "grill"}
+
+		streamer.On("Stream", mock.Anything, filter, int64(3), mock.Anything).
+			Run(func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				sink := args.Get(3).(stream.Sink)
+				_ = sink.Send(order_entity.OrderEvent{
+					Id:    4,
+					Type:  order_entity.OrderUpdatedEvent,
+					Order: order_entity.Order{Id: "order-1", State: order_entity.Processing},
+				})
+				<-ctx.Done()
+			}).
+			Return(nil).
+			Once()
+
+		e := echo.New()
+		e.GET("/ws", NewHandler(streamer).Handle)
+
+		server := httptest.NewServer(e)
+		defer server.Close()
+
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?station=grill&last_event_id=3"
+
+		// Act
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		assert.NoError(t, err)
+
+		var event order_entity.OrderEvent
+		readErr := conn.ReadJSON(&event)
+
+		conn.Close()
+
+		// Assert
+		assert.NoError(t, readErr)
+		assert.Equal(t, int64(4), event.Id)
+		assert.Equal(t, "order-1", event.Order.Id)
+		assert.Eventually(t, func() bool {
+			return len(streamer.Calls) == 1
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Should return bad request when the filter is invalid", func(t *testing.T) {
+		// Arrange
+		streamer := mocks.NewMockStreamer(t)
+
+		req := httptest.NewRequest(echo.GET, "/?state=Baking", nil)
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		handler := NewHandler(streamer)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		streamer.AssertExpectations(t)
+	})
+}
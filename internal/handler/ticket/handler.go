@@ -0,0 +1,82 @@
+package ticket
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_id"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/ticket"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	FormatPDF    = "pdf"
+	FormatEscPos = "escpos"
+
+	MIMEApplicationPDF = "application/pdf"
+	// MIMEApplicationEscPos has no registered media type, the printers take
+	// the raw bytes
+	MIMEApplicationEscPos = echo.MIMEOctetStream
+)
+
+type Handler struct {
+	service service.GetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput]
+	storeId string
+	width   int
+}
+
+func NewHandler(
+	service service.GetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput],
+	storeId string,
+	width int,
+) *Handler {
+	return &Handler{
+		service: service,
+		storeId: storeId,
+		width:   width,
+	}
+}
+
+// Handle renders the kitchen ticket of the order, the format query selects
+// PDF (default) or ESC/POS for thermal printers
+func (h *Handler) Handle(ctx echo.Context) error {
+	var request get_by_id.GetOrderProductionByIdInput
+
+	if err := ctx.Bind(&request); err != nil {
+		return err
+	}
+
+	format := ctx.QueryParam("format")
+	if format == "" {
+		format = FormatPDF
+	}
+
+	if format != FormatPDF && format != FormatEscPos {
+		return custom_error.NewHttpAppErrorFromBusinessError(custom_error.NewValidationError(custom_error.Violation{
+			Field:   "format",
+			Rule:    "oneof",
+			Message: "must be one of: pdf, escpos",
+		}))
+	}
+
+	order, err := h.service.Handle(ctx.Request().Context(), request)
+	if err != nil {
+		if custom_error.IsBusinessErr(err) {
+			return custom_error.NewHttpAppErrorFromBusinessError(err)
+		}
+
+		return custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", err)
+	}
+
+	orderTicket := ticket.New(order, h.storeId)
+
+	if format == FormatEscPos {
+		ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"ticket_%s.bin\"", orderTicket.Code))
+		return ctx.Blob(http.StatusOK, MIMEApplicationEscPos, ticket.EscPos(orderTicket, h.width))
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=\"ticket_%s.pdf\"", orderTicket.Code))
+	return ctx.Blob(http.StatusOK, MIMEApplicationPDF, ticket.PDF(orderTicket))
+}
@@ -0,0 +1,154 @@
+package ticket
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_id"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newContext(target string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	resp := httptest.NewRecorder()
+
+	e := echo.New()
+	ctx := e.NewContext(req, resp)
+	ctx.SetPath("/production/:id/ticket")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("c3fdab1b-3c06-4db2-9edc-4760a2429462")
+
+	return ctx, resp
+}
+
+func TestHandle(t *testing.T) {
+	order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", time.Now())
+	order.Items = []order_entity.Item{
+		order_entity.NewItem(uuid.NewString(), "Hamburger", 1),
+	}
+
+	t.Run("Should return the ticket as PDF", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput](t)
+
+		service.On("Handle", mock.Anything, get_by_id.GetOrderProductionByIdInput{OrderId: order.Id}).
+			Return(order, nil).
+			Once()
+
+		ctx, resp := newContext("/")
+
+		handler := NewHandler(service, "store-01", 42)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, MIMEApplicationPDF, resp.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `inline; filename="ticket_C3FDAB.pdf"`, resp.Header().Get(echo.HeaderContentDisposition))
+		assert.True(t, bytes.HasPrefix(resp.Body.Bytes(), []byte("%PDF-")))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return the ticket as ESC/POS", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(order, nil).
+			Once()
+
+		ctx, resp := newContext("/?format=escpos")
+
+		handler := NewHandler(service, "store-01", 42)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, MIMEApplicationEscPos, resp.Header().Get(echo.HeaderContentType))
+		assert.True(t, bytes.HasPrefix(resp.Body.Bytes(), []byte{0x1b, '@'}))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the format is not supported", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput](t)
+
+		ctx, _ := newContext("/?format=zpl")
+
+		handler := NewHandler(service, "store-01", 42)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, "format", custom_error.GetViolations(err)[0].Field)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return not found error", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(order_entity.Order{}, custom_error.ErrOrderNotFound).
+			Once()
+
+		ctx, _ := newContext("/")
+
+		handler := NewHandler(service, "store-01", 42)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error", func(t *testing.T) {
+		// Arrange
+		service := mocks.NewMockGetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput](t)
+
+		service.On("Handle", mock.Anything, mock.Anything).
+			Return(order_entity.Order{}, assert.AnError).
+			Once()
+
+		ctx, _ := newContext("/")
+
+		handler := NewHandler(service, "store-01", 42)
+
+		// Act
+		err := handler.Handle(ctx)
+
+		// Assert
+		assert.Error(t, err)
+
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+		service.AssertExpectations(t)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/update"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
@@ -46,7 +47,7 @@ func (h *Handler) Handle(c echo.Context) error {
 
 	order.RefreshStateTitle()
 
-	messageId, err := h.updateOrderTopic.PublishMessage(ctx, cloud.NewUpdateOrderContractFromPayment(order))
+	messageId, err := h.updateOrderTopic.PublishMessage(ctx, cloud.NewOrderEvent(order, token.EventActorFromContext(ctx)))
 	if err != nil {
 		slog.ErrorContext(ctx, "error publishing message to update order topic", "error", err)
 	}
@@ -8,8 +8,11 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
 	services_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/update"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
@@ -65,6 +68,55 @@ func TestHandle(t *testing.T) {
 		updateOrderTopic.AssertExpectations(t)
 	})
 
+	t.Run("Should publish the API key as the actor of the event", func(t *testing.T) {
+		// Arrange
+		updateOrderProductionService := services_mocks.NewMockUpdateOrderProductionService[update.UpdateOrderProductionInput](t)
+		updateOrderTopic := mocks.NewMockTopicService(t)
+
+		updateOrderProductionService.On("Handle", mock.Anything, mock.Anything).
+			Return(&order_entity.Order{}, nil).
+			Once()
+
+		messageId := uuid.NewString()
+
+		updateOrderTopic.On("PublishMessage", mock.Anything, mock.MatchedBy(func(event *cloud.OrderEvent) bool {
+			return event.Actor == cloud.NewServiceActor("key-id")
+		})).
+			Return(&messageId, nil).
+			Once()
+
+		reqBody := update.UpdateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			State:   "Processing",
+		}
+
+		body, err := json.Marshal(reqBody)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(echo.POST, "/", bytes.NewBuffer(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req = req.WithContext(token.WithPrincipal(req.Context(), token.NewApiKeyPrincipal(api_key_entity.ApiKey{Id: "key-id"})))
+
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+		ctx.SetPath("/production/:id")
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(reqBody.OrderId)
+
+		handler := NewHandler(updateOrderProductionService, updateOrderTopic)
+
+		// Act
+		err = handler.Handle(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		updateOrderProductionService.AssertExpectations(t)
+		updateOrderTopic.AssertExpectations(t)
+	})
+
 	t.Run("Should return validation error", func(t *testing.T) {
 		// Arrange
 		updateOrderProductionService := services_mocks.NewMockUpdateOrderProductionService[update.UpdateOrderProductionInput](t)
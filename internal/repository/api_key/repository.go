@@ -0,0 +1,231 @@
+package api_key
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/lib/pq"
+)
+
+var apiKeyColumns = []interface{}{
+	"id",
+	"name",
+	"prefix",
+	"hash",
+	"scopes",
+	"replaced_by",
+	"expires_at",
+	"revoked_at",
+	"last_used_at",
+	"created_at",
+	"updated_at",
+}
+
+type ApiKeyRepository struct {
+	conn *sql.DB
+}
+
+func NewApiKeyRepository(conn *sql.DB) *ApiKeyRepository {
+	return &ApiKeyRepository{
+		conn: conn,
+	}
+}
+
+func (r *ApiKeyRepository) CreateApiKey(ctx context.Context, apiKey *api_key_entity.ApiKey) error {
+	sql, params, err := insertApiKey(apiKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = repository.Conn(ctx, r.conn).ExecContext(ctx, sql, params...)
+
+	return err
+}
+
+func (r *ApiKeyRepository) GetApiKeyByID(ctx context.Context, id string) (api_key_entity.ApiKey, error) {
+	return r.getApiKey(ctx, goqu.C("id").Eq(id))
+}
+
+func (r *ApiKeyRepository) GetApiKeyByPrefix(ctx context.Context, prefix string) (api_key_entity.ApiKey, error) {
+	return r.getApiKey(ctx, goqu.C("prefix").Eq(prefix))
+}
+
+func (r *ApiKeyRepository) ListApiKeys(ctx context.Context) ([]api_key_entity.ApiKey, error) {
+	return r.listApiKeys(ctx)
+}
+
+func (r *ApiKeyRepository) getApiKey(ctx context.Context, filter exp.Expression) (api_key_entity.ApiKey, error) {
+	apiKeys, err := r.listApiKeys(ctx, filter)
+	if err != nil {
+		return api_key_entity.ApiKey{}, err
+	}
+
+	if len(apiKeys) == 0 {
+		return api_key_entity.ApiKey{}, custom_error.ErrApiKeyNotFound
+	}
+
+	return apiKeys[0], nil
+}
+
+func (r *ApiKeyRepository) listApiKeys(ctx context.Context, filters ...exp.Expression) ([]api_key_entity.ApiKey, error) {
+	apiKeys := make([]api_key_entity.ApiKey, 0)
+
+	sql, params, err := goqu.
+		From("api_keys").
+		Select(apiKeyColumns...).
+		Where(filters...).
+		Order(goqu.C("created_at").Asc()).
+		ToSQL()
+	if err != nil {
+		return apiKeys, err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return apiKeys, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var apiKey api_key_entity.ApiKey
+
+		if err := rows.Scan(
+			&apiKey.Id,
+			&apiKey.Name,
+			&apiKey.Prefix,
+			&apiKey.Hash,
+			pq.Array(&apiKey.Scopes),
+			&apiKey.ReplacedBy,
+			&apiKey.ExpiresAt,
+			&apiKey.RevokedAt,
+			&apiKey.LastUsedAt,
+			&apiKey.CreatedAt,
+			&apiKey.UpdatedAt,
+		); err != nil {
+			return apiKeys, err
+		}
+
+		if apiKey.Scopes == nil {
+			apiKey.Scopes = make([]string, 0)
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return apiKeys, rows.Err()
+}
+
+func (r *ApiKeyRepository) UpdateApiKey(ctx context.Context, apiKey *api_key_entity.ApiKey) error {
+	sql, params, err := updateApiKey(apiKey)
+	if err != nil {
+		return err
+	}
+
+	result, err := repository.Conn(ctx, r.conn).ExecContext(ctx, sql, params...)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return custom_error.ErrApiKeyNotFound
+	}
+
+	return nil
+}
+
+// RotateApiKey saves the new key and the replaced one in a single
+// transaction, so a failure never leaves the client without a valid key
+func (r *ApiKeyRepository) RotateApiKey(ctx context.Context, replaced *api_key_entity.ApiKey, apiKey *api_key_entity.ApiKey) error {
+	tx, err := repository.BeginTx(ctx, r.conn)
+	if err != nil {
+		return err
+	}
+
+	sql, params, err := insertApiKey(apiKey)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return errTx
+		}
+		return err
+	}
+
+	sql, params, err = updateApiKey(replaced)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return errTx
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TouchApiKey only writes the last use, the other fields may be changed at the
+// same time by an admin
+func (r *ApiKeyRepository) TouchApiKey(ctx context.Context, id string, lastUsedAt time.Time) error {
+	sql, params, err := goqu.
+		Update("api_keys").
+		Set(goqu.Record{
+			"last_used_at": lastUsedAt,
+		}).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn.ExecContext(ctx, sql, params...)
+
+	return err
+}
+
+func insertApiKey(apiKey *api_key_entity.ApiKey) (string, []interface{}, error) {
+	return goqu.
+		Insert("api_keys").
+		Cols(apiKeyColumns...).
+		Vals(
+			goqu.Vals{
+				apiKey.Id,
+				apiKey.Name,
+				apiKey.Prefix,
+				apiKey.Hash,
+				pq.Array(apiKey.Scopes),
+				apiKey.ReplacedBy,
+				apiKey.ExpiresAt,
+				apiKey.RevokedAt,
+				apiKey.LastUsedAt,
+				apiKey.CreatedAt,
+				apiKey.UpdatedAt,
+			},
+		).
+		ToSQL()
+}
+
+func updateApiKey(apiKey *api_key_entity.ApiKey) (string, []interface{}, error) {
+	return goqu.
+		Update("api_keys").
+		Set(goqu.Record{
+			"replaced_by": apiKey.ReplacedBy,
+			"expires_at":  apiKey.ExpiresAt,
+			"revoked_at":  apiKey.RevokedAt,
+			"updated_at":  apiKey.UpdatedAt,
+		}).
+		Where(goqu.C("id").Eq(apiKey.Id)).
+		ToSQL()
+}
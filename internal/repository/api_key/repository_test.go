@@ -0,0 +1,298 @@
+package api_key
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+var apiKeyRows = []string{"id", "name", "prefix", "hash", "scopes", "replaced_by", "expires_at", "revoked_at", "last_used_at", "created_at", "updated_at"}
+
+func newApiKey(t *testing.T) api_key_entity.ApiKey {
+	apiKey, err := api_key_entity.NewApiKey(uuid.NewString(), "billing", []string{"service"}, nil, time.Now())
+	assert.NoError(t, err)
+
+	return apiKey
+}
+
+func TestCreateApiKey(t *testing.T) {
+	t.Run("Should create the API key", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("INSERT INTO (.+)?api_keys(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		repo := NewApiKeyRepository(db)
+
+		apiKey := newApiKey(t)
+
+		// Act
+		err = repo.CreateApiKey(ctx, &apiKey)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when try to create the API key", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("INSERT INTO (.+)?api_keys(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewApiKeyRepository(db)
+
+		apiKey := newApiKey(t)
+
+		// Act
+		err = repo.CreateApiKey(ctx, &apiKey)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestGetApiKeyByPrefix(t *testing.T) {
+	t.Run("Should return the API key", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+		id := uuid.NewString()
+
+		mock.ExpectQuery("SELECT (.+)?api_keys(.+)?prefix(.+)?").
+			WillReturnRows(sqlmock.NewRows(apiKeyRows).
+				AddRow(id, "billing", "abc123", "hash", "{service,kitchen}", nil, nil, nil, now, now, now))
+
+		repo := NewApiKeyRepository(db)
+
+		// Act
+		res, err := repo.GetApiKeyByPrefix(ctx, "abc123")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, id, res.Id)
+		assert.Equal(t, "hash", res.Hash)
+		assert.Equal(t, []string{"service", "kitchen"}, res.Scopes)
+		assert.Equal(t, now, *res.LastUsedAt)
+		assert.Nil(t, res.RevokedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return not found when the API key does not exist", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?api_keys(.+)?").
+			WillReturnRows(sqlmock.NewRows(apiKeyRows))
+
+		repo := NewApiKeyRepository(db)
+
+		// Act
+		_, err = repo.GetApiKeyByPrefix(ctx, "abc123")
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrApiKeyNotFound)
+	})
+}
+
+func TestListApiKeys(t *testing.T) {
+	t.Run("Should return the API keys", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("SELECT (.+)?api_keys(.+)?").
+			WillReturnRows(sqlmock.NewRows(apiKeyRows).
+				AddRow(uuid.NewString(), "billing", "abc123", "hash", "{}", nil, nil, now, nil, now, now).
+				AddRow(uuid.NewString(), "billing", "def456", "hash", "{service}", nil, nil, nil, nil, now, now))
+
+		repo := NewApiKeyRepository(db)
+
+		// Act
+		res, err := repo.ListApiKeys(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, []string{}, res[0].Scopes)
+		assert.NotNil(t, res[0].RevokedAt)
+	})
+
+	t.Run("Should return error when try to list the API keys", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?api_keys(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewApiKeyRepository(db)
+
+		// Act
+		_, err = repo.ListApiKeys(ctx)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateApiKey(t *testing.T) {
+	t.Run("Should update the API key", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?api_keys(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := NewApiKeyRepository(db)
+
+		apiKey := newApiKey(t)
+		apiKey.Revoke(time.Now())
+
+		// Act
+		err = repo.UpdateApiKey(ctx, &apiKey)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return not found when the API key does not exist", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?api_keys(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := NewApiKeyRepository(db)
+
+		apiKey := newApiKey(t)
+
+		// Act
+		err = repo.UpdateApiKey(ctx, &apiKey)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrApiKeyNotFound)
+	})
+}
+
+func TestRotateApiKey(t *testing.T) {
+	t.Run("Should save both keys in a transaction", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO (.+)?api_keys(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE (.+)?api_keys(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := NewApiKeyRepository(db)
+
+		replaced := newApiKey(t)
+		apiKey := newApiKey(t)
+		replaced.ReplaceWith(apiKey.Id, time.Hour, time.Now())
+
+		// Act
+		err = repo.RotateApiKey(ctx, &replaced, &apiKey)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should rollback when try to update the replaced key", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO (.+)?api_keys(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE (.+)?api_keys(.+)?").
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		repo := NewApiKeyRepository(db)
+
+		replaced := newApiKey(t)
+		apiKey := newApiKey(t)
+
+		// Act
+		err = repo.RotateApiKey(ctx, &replaced, &apiKey)
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTouchApiKey(t *testing.T) {
+	t.Run("Should update the last use", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?api_keys(.+)?last_used_at(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := NewApiKeyRepository(db)
+
+		// Act
+		err = repo.TouchApiKey(ctx, uuid.NewString(), time.Now())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
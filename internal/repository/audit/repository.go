@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+// AuditRepository only appends and reads the entries, the table rejects any
+// update or delete
+type AuditRepository struct {
+	conn *sql.DB
+}
+
+func NewAuditRepository(conn *sql.DB) *AuditRepository {
+	return &AuditRepository{
+		conn: conn,
+	}
+}
+
+func (r *AuditRepository) CreateEntry(ctx context.Context, entry *audit_entity.Entry) error {
+	sql, params, err := goqu.
+		Insert("audit_log").
+		Cols(
+			"action",
+			"resource_type",
+			"resource_id",
+			"actor_type",
+			"actor_id",
+			"source_type",
+			"source_message_id",
+			"request_id",
+			"before",
+			"after",
+			"created_at",
+		).
+		Vals(
+			goqu.Vals{
+				entry.Action,
+				entry.ResourceType,
+				entry.ResourceId,
+				entry.Actor.Type,
+				entry.Actor.Id,
+				entry.Source.Type,
+				entry.Source.MessageId,
+				entry.Source.RequestId,
+				nullableJSON(entry.Before),
+				nullableJSON(entry.After),
+				entry.CreatedAt,
+			},
+		).
+		Returning("id").
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	return repository.Conn(ctx, r.conn).QueryRowContext(ctx, sql, params...).Scan(&entry.Id)
+}
+
+func (r *AuditRepository) ListEntries(ctx context.Context, filter audit_entity.Filter) ([]audit_entity.Entry, error) {
+	entries := make([]audit_entity.Entry, 0)
+
+	sql, params, err := goqu.
+		From("audit_log").
+		Select(
+			"id",
+			"action",
+			"resource_type",
+			"resource_id",
+			"actor_type",
+			"actor_id",
+			"source_type",
+			"source_message_id",
+			"request_id",
+			"before",
+			"after",
+			"created_at",
+		).
+		Where(filters(filter)...).
+		Order(goqu.C("id").Desc()).
+		Limit(uint(filter.Limit)).
+		ToSQL()
+	if err != nil {
+		return entries, err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return entries, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry audit_entity.Entry
+		var before, after []byte
+
+		if err := rows.Scan(
+			&entry.Id,
+			&entry.Action,
+			&entry.ResourceType,
+			&entry.ResourceId,
+			&entry.Actor.Type,
+			&entry.Actor.Id,
+			&entry.Source.Type,
+			&entry.Source.MessageId,
+			&entry.Source.RequestId,
+			&before,
+			&after,
+			&entry.CreatedAt,
+		); err != nil {
+			return entries, err
+		}
+
+		if len(before) > 0 {
+			entry.Before = before
+		}
+
+		if len(after) > 0 {
+			entry.After = after
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func filters(filter audit_entity.Filter) []exp.Expression {
+	expressions := make([]exp.Expression, 0)
+
+	if filter.Action != "" {
+		expressions = append(expressions, goqu.C("action").Eq(filter.Action))
+	}
+
+	if filter.ResourceType != "" {
+		expressions = append(expressions, goqu.C("resource_type").Eq(filter.ResourceType))
+	}
+
+	if filter.ResourceId != "" {
+		expressions = append(expressions, goqu.C("resource_id").Eq(filter.ResourceId))
+	}
+
+	if filter.ActorId != "" {
+		expressions = append(expressions, goqu.C("actor_id").Eq(filter.ActorId))
+	}
+
+	if filter.From != nil {
+		expressions = append(expressions, goqu.C("created_at").Gte(*filter.From))
+	}
+
+	if filter.To != nil {
+		expressions = append(expressions, goqu.C("created_at").Lt(*filter.To))
+	}
+
+	if filter.BeforeId > 0 {
+		expressions = append(expressions, goqu.C("id").Lt(filter.BeforeId))
+	}
+
+	return expressions
+}
+
+func nullableJSON(value []byte) interface{} {
+	if len(value) == 0 {
+		return nil
+	}
+
+	return string(value)
+}
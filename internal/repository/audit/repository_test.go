@@ -0,0 +1,124 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var entryRows = []string{"id", "action", "resource_type", "resource_id", "actor_type", "actor_id", "source_type", "source_message_id", "request_id", "before", "after", "created_at"}
+
+func TestCreateEntry(t *testing.T) {
+	t.Run("Should append the entry and return its id", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("INSERT INTO (.+)?audit_log(.+)?RETURNING (.+)?id(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+
+		repo := NewAuditRepository(db)
+
+		entry := audit_entity.Entry{
+			Action:       audit_entity.OrderCreatedAction,
+			ResourceType: audit_entity.OrderResource,
+			ResourceId:   "order-id",
+			After:        json.RawMessage(`{"id":"order-id"}`),
+			CreatedAt:    time.Now(),
+		}
+
+		// Act
+		err = repo.CreateEntry(ctx, &entry)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), entry.Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when try to append the entry", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("INSERT INTO (.+)?audit_log(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewAuditRepository(db)
+
+		// Act
+		err = repo.CreateEntry(ctx, &audit_entity.Entry{})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestListEntries(t *testing.T) {
+	t.Run("Should return the entries of the filter", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("SELECT (.+)?audit_log(.+)?resource_id(.+)?actor_id(.+)?id(.+)?<(.+)?ORDER BY (.+)?id(.+)? DESC LIMIT 10").
+			WillReturnRows(sqlmock.NewRows(entryRows).
+				AddRow(2, "order.state_changed", "order", "order-id", "user", "user-id", "http", "", "request-id", []byte(`{"state":1}`), []byte(`{"state":2}`), now).
+				AddRow(1, "order.created", "order", "order-id", "service", "order-production-queue", "queue", "message-id", "", nil, []byte(`{"state":1}`), now))
+
+		repo := NewAuditRepository(db)
+
+		// Act
+		res, err := repo.ListEntries(ctx, audit_entity.Filter{
+			ResourceType: "order",
+			ResourceId:   "order-id",
+			ActorId:      "user-id",
+			BeforeId:     100,
+			Limit:        10,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, audit_entity.Actor{Type: "user", Id: "user-id"}, res[0].Actor)
+		assert.Equal(t, "request-id", res[0].Source.RequestId)
+		assert.JSONEq(t, `{"state":1}`, string(res[0].Before))
+		assert.Nil(t, res[1].Before)
+		assert.Equal(t, "message-id", res[1].Source.MessageId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when try to list the entries", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?audit_log(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewAuditRepository(db)
+
+		// Act
+		_, err = repo.ListEntries(ctx, audit_entity.Filter{Limit: 10})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
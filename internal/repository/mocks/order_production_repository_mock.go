@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	order_entity "github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	mock "github.com/stretchr/testify/mock"
@@ -14,6 +15,36 @@ type MockOrderProductionRepository struct {
 	mock.Mock
 }
 
+// CountByState provides a mock function with given fields: ctx
+func (_m *MockOrderProductionRepository) CountByState(ctx context.Context) (map[order_entity.OrderState]int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByState")
+	}
+
+	var r0 map[order_entity.OrderState]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[order_entity.OrderState]int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[order_entity.OrderState]int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[order_entity.OrderState]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, order
 func (_m *MockOrderProductionRepository) Create(ctx context.Context, order *order_entity.Order) error {
 	ret := _m.Called(ctx, order)
@@ -32,6 +63,24 @@ func (_m *MockOrderProductionRepository) Create(ctx context.Context, order *orde
 	return r0
 }
 
+// Export provides a mock function with given fields: ctx, filter, yield
+func (_m *MockOrderProductionRepository) Export(ctx context.Context, filter order_entity.ExportFilter, yield func(order_entity.Order) error) error {
+	ret := _m.Called(ctx, filter, yield)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, order_entity.ExportFilter, func(order_entity.Order) error) error); ok {
+		r0 = rf(ctx, filter, yield)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *MockOrderProductionRepository) GetByID(ctx context.Context, id string) (order_entity.Order, error) {
 	ret := _m.Called(ctx, id)
@@ -60,6 +109,36 @@ func (_m *MockOrderProductionRepository) GetByID(ctx context.Context, id string)
 	return r0, r1
 }
 
+// GetByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockOrderProductionRepository) GetByIDs(ctx context.Context, ids []string) ([]order_entity.Order, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []order_entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]order_entity.Order, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []order_entity.Order); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order_entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByState provides a mock function with given fields: ctx, state
 func (_m *MockOrderProductionRepository) GetByState(ctx context.Context, state order_entity.OrderState) ([]order_entity.Order, error) {
 	ret := _m.Called(ctx, state)
@@ -90,6 +169,84 @@ func (_m *MockOrderProductionRepository) GetByState(ctx context.Context, state o
 	return r0, r1
 }
 
+// GetIDsByStateUpdatedBefore provides a mock function with given fields: ctx, state, before, limit
+func (_m *MockOrderProductionRepository) GetIDsByStateUpdatedBefore(ctx context.Context, state order_entity.OrderState, before time.Time, limit int) ([]string, error) {
+	ret := _m.Called(ctx, state, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIDsByStateUpdatedBefore")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, order_entity.OrderState, time.Time, int) ([]string, error)); ok {
+		return rf(ctx, state, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, order_entity.OrderState, time.Time, int) []string); ok {
+		r0 = rf(ctx, state, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, order_entity.OrderState, time.Time, int) error); ok {
+		r1 = rf(ctx, state, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPickupBoard provides a mock function with given fields: ctx, readySince
+func (_m *MockOrderProductionRepository) GetPickupBoard(ctx context.Context, readySince time.Time) ([]order_entity.Order, error) {
+	ret := _m.Called(ctx, readySince)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPickupBoard")
+	}
+
+	var r0 []order_entity.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]order_entity.Order, error)); ok {
+		return rf(ctx, readySince)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []order_entity.Order); ok {
+		r0 = rf(ctx, readySince)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order_entity.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, readySince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reconcile provides a mock function with given fields: ctx, order
+func (_m *MockOrderProductionRepository) Reconcile(ctx context.Context, order *order_entity.Order) error {
+	ret := _m.Called(ctx, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reconcile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *order_entity.Order) error); ok {
+		r0 = rf(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, order
 func (_m *MockOrderProductionRepository) Update(ctx context.Context, order *order_entity.Order) error {
 	ret := _m.Called(ctx, order)
@@ -108,6 +265,24 @@ func (_m *MockOrderProductionRepository) Update(ctx context.Context, order *orde
 	return r0
 }
 
+// UpdateMany provides a mock function with given fields: ctx, orders
+func (_m *MockOrderProductionRepository) UpdateMany(ctx context.Context, orders []*order_entity.Order) error {
+	ret := _m.Called(ctx, orders)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMany")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*order_entity.Order) error); ok {
+		r0 = rf(ctx, orders)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockOrderProductionRepository creates a new instance of MockOrderProductionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockOrderProductionRepository(t interface {
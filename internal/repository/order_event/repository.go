@@ -0,0 +1,104 @@
+package order_event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+)
+
+type OrderEventRepository struct {
+	conn *sql.DB
+}
+
+func NewOrderEventRepository(conn *sql.DB) *OrderEventRepository {
+	return &OrderEventRepository{
+		conn: conn,
+	}
+}
+
+func (r *OrderEventRepository) GetEventByID(ctx context.Context, id int64) (order_entity.OrderEvent, error) {
+	events, err := r.listEvents(ctx, goqu.C("id").Eq(id), 1)
+	if err != nil {
+		return order_entity.OrderEvent{}, err
+	}
+
+	if len(events) == 0 {
+		return order_entity.OrderEvent{}, custom_error.ErrOrderEventNotFound
+	}
+
+	return events[0], nil
+}
+
+// ListEventsSince returns the events created since the time with an id
+// greater than afterId, in the order of their ids
+func (r *OrderEventRepository) ListEventsSince(ctx context.Context, since time.Time, afterId int64, limit int) ([]order_entity.OrderEvent, error) {
+	return r.listEvents(ctx, goqu.And(goqu.C("created_at").Gte(since), goqu.C("id").Gt(afterId)), limit)
+}
+
+// DeleteEventsBefore removes the events created before the time and returns
+// how many were removed
+func (r *OrderEventRepository) DeleteEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	sql, params, err := goqu.
+		Delete("order_events").
+		Where(goqu.C("created_at").Lt(before)).
+		ToSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.conn.ExecContext(ctx, sql, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (r *OrderEventRepository) listEvents(ctx context.Context, filter exp.Expression, limit int) ([]order_entity.OrderEvent, error) {
+	events := make([]order_entity.OrderEvent, 0)
+
+	sql, params, err := goqu.
+		From("order_events").
+		Select("id", "type", "payload", "created_at").
+		Where(filter).
+		Order(goqu.C("id").Asc()).
+		Limit(uint(limit)).
+		ToSQL()
+	if err != nil {
+		return events, err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return events, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event order_entity.OrderEvent
+		var payload string
+
+		if err := rows.Scan(
+			&event.Id,
+			&event.Type,
+			&payload,
+			&event.CreatedAt,
+		); err != nil {
+			return events, err
+		}
+
+		if err := json.Unmarshal([]byte(payload), &event.Order); err != nil {
+			return events, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
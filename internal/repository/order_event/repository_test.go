@@ -0,0 +1,172 @@
+package order_event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+var eventRows = []string{"id", "type", "payload", "created_at"}
+
+func TestGetEventByID(t *testing.T) {
+	t.Run("Should return the event", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?order_events(.+)?").
+			WillReturnRows(sqlmock.NewRows(eventRows).
+				AddRow(10, order_entity.OrderCreatedEvent, `{"id":"order-1","state":1,"state_title":"Received","items":[{"id":"item-1","name":"Hamburger","quantity":1,"station":"grill"}]}`, time.Now()))
+
+		repo := NewOrderEventRepository(db)
+
+		// Act
+		event, err := repo.GetEventByID(ctx, 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), event.Id)
+		assert.Equal(t, "order-1", event.Order.Id)
+		assert.Equal(t, order_entity.Received, event.Order.State)
+		assert.True(t, event.Order.HasStation("grill"))
+	})
+
+	t.Run("Should return not found when the event does not exist", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?order_events(.+)?").
+			WillReturnRows(sqlmock.NewRows(eventRows))
+
+		repo := NewOrderEventRepository(db)
+
+		// Act
+		_, err = repo.GetEventByID(ctx, 10)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrOrderEventNotFound)
+	})
+
+	t.Run("Should return error when the payload is not valid", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?order_events(.+)?").
+			WillReturnRows(sqlmock.NewRows(eventRows).
+				AddRow(10, order_entity.OrderCreatedEvent, `invalid`, time.Now()))
+
+		repo := NewOrderEventRepository(db)
+
+		// Act
+		_, err = repo.GetEventByID(ctx, 10)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestListEventsSince(t *testing.T) {
+	t.Run("Should return the events created since the time", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?order_events(.+)?created_at(.+)?>=(.+)?id(.+)?>(.+)?").
+			WillReturnRows(sqlmock.NewRows(eventRows).
+				AddRow(11, order_entity.OrderUpdatedEvent, `{"id":"order-1","state":2}`, time.Now()).
+				AddRow(12, order_entity.OrderCancelledEvent, `{"id":"order-2","state":5}`, time.Now()))
+
+		repo := NewOrderEventRepository(db)
+
+		// Act
+		events, err := repo.ListEventsSince(ctx, time.Now().Add(-time.Minute), 10, 100)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, int64(12), events[1].Id)
+	})
+
+	t.Run("Should return error when try to list the events", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?order_events(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewOrderEventRepository(db)
+
+		// Act
+		_, err = repo.ListEventsSince(ctx, time.Now(), 0, 100)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteEventsBefore(t *testing.T) {
+	t.Run("Should delete the events created before the time", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("DELETE FROM \"order_events\" WHERE (.+)?created_at(.+)?<").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		repo := NewOrderEventRepository(db)
+
+		// Act
+		deleted, err := repo.DeleteEventsBefore(ctx, time.Now().Add(-time.Hour))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when try to delete the events", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("DELETE FROM \"order_events\"").
+			WillReturnError(assert.AnError)
+
+		repo := NewOrderEventRepository(db)
+
+		// Act
+		_, err = repo.DeleteEventsBefore(ctx, time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,93 @@
+package order_production
+
+import (
+	"context"
+	"slices"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cache"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+// CachedOrderProductionRepository reads the orders by id through the cache,
+// every write made through it removes the orders from the cache, the writes
+// of other replicas are seen when evicted by the order events or after the
+// cache expires, so it must only serve reads that tolerate a stale order
+type CachedOrderProductionRepository struct {
+	repository.OrderProductionRepository
+
+	cache cache.Cache[order_entity.Order]
+}
+
+func NewCachedOrderProductionRepository(
+	repository repository.OrderProductionRepository,
+	cache cache.Cache[order_entity.Order],
+) *CachedOrderProductionRepository {
+	return &CachedOrderProductionRepository{
+		OrderProductionRepository: repository,
+		cache:                     cache,
+	}
+}
+
+func (r *CachedOrderProductionRepository) GetByID(ctx context.Context, id string) (order_entity.Order, error) {
+	if order, ok := r.cache.Get(ctx, id); ok {
+		return cloneOrder(order), nil
+	}
+
+	order, err := r.OrderProductionRepository.GetByID(ctx, id)
+	if err != nil {
+		return order, err
+	}
+
+	r.cache.Set(ctx, id, cloneOrder(order))
+
+	return order, nil
+}
+
+func (r *CachedOrderProductionRepository) Create(ctx context.Context, order *order_entity.Order) error {
+	defer r.cache.Delete(ctx, order.Id)
+
+	return r.OrderProductionRepository.Create(ctx, order)
+}
+
+func (r *CachedOrderProductionRepository) Reconcile(ctx context.Context, order *order_entity.Order) error {
+	defer r.cache.Delete(ctx, order.Id)
+
+	return r.OrderProductionRepository.Reconcile(ctx, order)
+}
+
+func (r *CachedOrderProductionRepository) Update(ctx context.Context, order *order_entity.Order) error {
+	defer r.cache.Delete(ctx, order.Id)
+
+	return r.OrderProductionRepository.Update(ctx, order)
+}
+
+func (r *CachedOrderProductionRepository) UpdateMany(ctx context.Context, orders []*order_entity.Order) error {
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.Id)
+	}
+
+	defer r.cache.Delete(ctx, ids...)
+
+	return r.OrderProductionRepository.UpdateMany(ctx, orders)
+}
+
+// Evict removes the order changed by any replica from the cache
+func (r *CachedOrderProductionRepository) Evict(ctx context.Context, id string) {
+	r.cache.Delete(ctx, id)
+}
+
+// cloneOrder copies the slices of the order, so the services can change the
+// order returned without changing the cached one
+func cloneOrder(order order_entity.Order) order_entity.Order {
+	order.Items = slices.Clone(order.Items)
+	order.AllowedTransitions = slices.Clone(order.AllowedTransitions)
+
+	if order.ReconciledAt != nil {
+		reconciledAt := *order.ReconciledAt
+		order.ReconciledAt = &reconciledAt
+	}
+
+	return order
+}
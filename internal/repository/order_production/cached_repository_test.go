@@ -0,0 +1,201 @@
+package order_production
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cache"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider/time_provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestCache() cache.Cache[order_entity.Order] {
+	return cache.NewLRU[order_entity.Order](10, time.Minute, time_provider.NewTimeProvider(time.Now))
+}
+
+func TestCachedGetByID(t *testing.T) {
+	t.Run("Should read the order from the repository only once", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+		order.Items = []order_entity.Item{{Id: uuid.NewString(), Name: "Burger", Quantity: 1}}
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		repository.On("GetByID", ctx, order.Id).
+			Return(order, nil).
+			Once()
+
+		cached := NewCachedOrderProductionRepository(repository, newTestCache())
+
+		// Act
+		first, errFirst := cached.GetByID(ctx, order.Id)
+		second, errSecond := cached.GetByID(ctx, order.Id)
+
+		// Assert
+		assert.NoError(t, errFirst)
+		assert.NoError(t, errSecond)
+		assert.Equal(t, order, first)
+		assert.Equal(t, order, second)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should not change the cached order when the returned order changes", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+		order.Items = []order_entity.Item{{Id: uuid.NewString(), Name: "Burger", Quantity: 1}}
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		repository.On("GetByID", ctx, order.Id).
+			Return(order, nil).
+			Once()
+
+		cached := NewCachedOrderProductionRepository(repository, newTestCache())
+
+		first, _ := cached.GetByID(ctx, order.Id)
+		first.Items[0].Name = "Changed"
+
+		// Act
+		res, err := cached.GetByID(ctx, order.Id)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Burger", res.Items[0].Name)
+	})
+
+	t.Run("Should not cache the errors", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		id := uuid.NewString()
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		repository.On("GetByID", ctx, id).
+			Return(order_entity.Order{}, custom_error.ErrOrderNotFound).
+			Twice()
+
+		cached := NewCachedOrderProductionRepository(repository, newTestCache())
+
+		// Act
+		_, errFirst := cached.GetByID(ctx, id)
+		_, errSecond := cached.GetByID(ctx, id)
+
+		// Assert
+		assert.ErrorIs(t, errFirst, custom_error.ErrOrderNotFound)
+		assert.ErrorIs(t, errSecond, custom_error.ErrOrderNotFound)
+		repository.AssertExpectations(t)
+	})
+}
+
+func TestCachedWrites(t *testing.T) {
+	t.Run("Should invalidate the order after every write", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		repository.On("GetByID", ctx, order.Id).
+			Return(order, nil).
+			Times(5)
+		repository.On("Create", ctx, mock.Anything).
+			Return(nil).
+			Once()
+		repository.On("Update", ctx, mock.Anything).
+			Return(nil).
+			Once()
+		repository.On("UpdateMany", ctx, mock.Anything).
+			Return(nil).
+			Once()
+		repository.On("Reconcile", ctx, mock.Anything).
+			Return(nil).
+			Once()
+
+		cached := NewCachedOrderProductionRepository(repository, newTestCache())
+
+		writes := []func() error{
+			func() error { return cached.Create(ctx, &order) },
+			func() error { return cached.Update(ctx, &order) },
+			func() error { return cached.UpdateMany(ctx, []*order_entity.Order{&order}) },
+			func() error { return cached.Reconcile(ctx, &order) },
+		}
+
+		_, err := cached.GetByID(ctx, order.Id)
+		assert.NoError(t, err)
+
+		for _, write := range writes {
+			// Act
+			err := write()
+
+			// Assert
+			assert.NoError(t, err)
+
+			_, err = cached.GetByID(ctx, order.Id)
+			assert.NoError(t, err)
+		}
+
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should invalidate the order when the write fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		repository.On("GetByID", ctx, order.Id).
+			Return(order, nil).
+			Twice()
+		repository.On("Update", ctx, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		cached := NewCachedOrderProductionRepository(repository, newTestCache())
+
+		_, _ = cached.GetByID(ctx, order.Id)
+
+		// Act
+		err := cached.Update(ctx, &order)
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+
+		_, err = cached.GetByID(ctx, order.Id)
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+}
+
+func TestCachedEvict(t *testing.T) {
+	t.Run("Should read the order again after it is evicted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		repository.On("GetByID", ctx, order.Id).
+			Return(order, nil).
+			Twice()
+
+		cached := NewCachedOrderProductionRepository(repository, newTestCache())
+
+		_, _ = cached.GetByID(ctx, order.Id)
+
+		// Act
+		cached.Evict(ctx, order.Id)
+
+		// Assert
+		_, err := cached.GetByID(ctx, order.Id)
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+}
@@ -3,10 +3,15 @@ package order_production
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"strconv"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/lib/pq"
 )
 
 type OrderProductionRepository struct {
@@ -19,20 +24,64 @@ func NewOrderProductionRepository(conn *sql.DB) *OrderProductionRepository {
 	}
 }
 
+// orderColumns are the columns read by scanOrder, in the same order
+var orderColumns = []interface{}{"order_id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOrder(row scanner, order *order_entity.Order) error {
+	var createdBy sql.NullString
+	var reconciledAt sql.NullTime
+
+	if err := row.Scan(
+		&order.Id,
+		&order.State,
+		&order.StateUpdatedAt,
+		&order.Origin,
+		&createdBy,
+		&reconciledAt,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	order.CreatedBy = createdBy.String
+
+	if reconciledAt.Valid {
+		order.ReconciledAt = &reconciledAt.Time
+	}
+
+	return nil
+}
+
+// modifiers never returns nil, the column does not accept NULL
+func modifiers(item order_entity.Item) []string {
+	if item.Modifiers == nil {
+		return []string{}
+	}
+
+	return item.Modifiers
+}
+
 func (r *OrderProductionRepository) Create(ctx context.Context, order *order_entity.Order) error {
-	tx, err := r.conn.BeginTx(ctx, nil)
+	tx, err := repository.BeginTx(ctx, r.conn)
 	if err != nil {
 		return err
 	}
 
 	sql, params, err := goqu.
 		Insert("orders").
-		Cols("order_id", "state", "state_updated_at", "created_at", "updated_at").
+		Cols("order_id", "state", "state_updated_at", "origin", "created_by", "created_at", "updated_at").
 		Vals(
 			goqu.Vals{
 				order.Id,
 				order.State,
 				order.StateUpdatedAt,
+				order.Origin,
+				sql.NullString{String: order.CreatedBy, Valid: order.CreatedBy != ""},
 				order.CreatedAt,
 				order.UpdatedAt,
 			},
@@ -54,13 +103,15 @@ func (r *OrderProductionRepository) Create(ctx context.Context, order *order_ent
 	for _, item := range order.Items {
 		sql, params, err := goqu.
 			Insert("order_items").
-			Cols("id", "order_id", "name", "quantity").
+			Cols("id", "order_id", "name", "quantity", "station", "modifiers").
 			Vals(
 				goqu.Vals{
 					item.Id,
 					order.Id,
 					item.Name,
 					item.Quantity,
+					item.Station,
+					pq.Array(modifiers(item)),
 				},
 			).
 			ToSQL()
@@ -78,6 +129,14 @@ func (r *OrderProductionRepository) Create(ctx context.Context, order *order_ent
 		}
 	}
 
+	if err := r.saveEvent(ctx, tx, order); err != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return errTx
+		}
+		return err
+	}
+
 	order.UpdateTimezone()
 
 	return tx.Commit()
@@ -90,7 +149,7 @@ func (r *OrderProductionRepository) GetByID(ctx context.Context, id string) (ord
 
 	sql, params, err := goqu.
 		From("orders").
-		Select("order_id", "state", "state_updated_at", "created_at", "updated_at").
+		Select(orderColumns...).
 		Where(goqu.C("order_id").Eq(id)).
 		ToSQL()
 	if err != nil {
@@ -104,13 +163,7 @@ func (r *OrderProductionRepository) GetByID(ctx context.Context, id string) (ord
 	defer statement.Close()
 
 	for statement.Next() {
-		if err := statement.Scan(
-			&order.Id,
-			&order.State,
-			&order.StateUpdatedAt,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		); err != nil {
+		if err := scanOrder(statement, &order); err != nil {
 			return order_entity.Order{}, err
 		}
 	}
@@ -123,7 +176,7 @@ func (r *OrderProductionRepository) GetByID(ctx context.Context, id string) (ord
 
 	sql, params, err = goqu.
 		From("order_items").
-		Select("id", "name", "quantity").
+		Select("id", "name", "quantity", "station", "modifiers").
 		Where(goqu.C("order_id").Eq(order.Id)).
 		ToSQL()
 	if err != nil {
@@ -143,6 +196,8 @@ func (r *OrderProductionRepository) GetByID(ctx context.Context, id string) (ord
 			&item.Id,
 			&item.Name,
 			&item.Quantity,
+			&item.Station,
+			pq.Array(&item.Modifiers),
 		); err != nil {
 			return order_entity.Order{}, err
 		}
@@ -153,12 +208,170 @@ func (r *OrderProductionRepository) GetByID(ctx context.Context, id string) (ord
 	return order, nil
 }
 
+// GetByIDs returns the orders found with their items, missing ids are ignored
+func (r *OrderProductionRepository) GetByIDs(ctx context.Context, ids []string) ([]order_entity.Order, error) {
+	orders := make([]order_entity.Order, 0, len(ids))
+
+	if len(ids) == 0 {
+		return orders, nil
+	}
+
+	sql, params, err := goqu.
+		From("orders").
+		Select(orderColumns...).
+		Where(goqu.C("order_id").In(ids)).
+		Order(goqu.C("created_at").Asc()).
+		ToSQL()
+	if err != nil {
+		return orders, err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return orders, err
+	}
+	defer rows.Close()
+
+	positions := make(map[string]int, len(ids))
+
+	for rows.Next() {
+		var order order_entity.Order
+
+		if err := scanOrder(rows, &order); err != nil {
+			return orders, err
+		}
+
+		order.Items = make([]order_entity.Item, 0)
+		order.UpdateTimezone()
+
+		positions[order.Id] = len(orders)
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return orders, err
+	}
+
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	sql, params, err = goqu.
+		From("order_items").
+		Select("order_id", "id", "name", "quantity", "station", "modifiers").
+		Where(goqu.C("order_id").In(ids)).
+		ToSQL()
+	if err != nil {
+		return orders, err
+	}
+
+	itemRows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return orders, err
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var orderId string
+		var item order_entity.Item
+
+		if err := itemRows.Scan(
+			&orderId,
+			&item.Id,
+			&item.Name,
+			&item.Quantity,
+			&item.Station,
+			pq.Array(&item.Modifiers),
+		); err != nil {
+			return orders, err
+		}
+
+		if position, ok := positions[orderId]; ok {
+			orders[position].Items = append(orders[position].Items, item)
+		}
+	}
+
+	return orders, itemRows.Err()
+}
+
+// GetIDsByStateUpdatedBefore returns the id of the orders at the state since
+// before the given time
+func (r *OrderProductionRepository) GetIDsByStateUpdatedBefore(ctx context.Context, state order_entity.OrderState, before time.Time, limit int) ([]string, error) {
+	ids := make([]string, 0)
+
+	sql, params, err := goqu.
+		From("orders").
+		Select("order_id").
+		Where(
+			goqu.C("state").Eq(state),
+			goqu.C("state_updated_at").Lt(before),
+		).
+		Order(goqu.C("state_updated_at").Asc()).
+		Limit(uint(limit)).
+		ToSQL()
+	if err != nil {
+		return ids, err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return ids, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+
+		if err := rows.Scan(&id); err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// CountByState returns the number of orders at each state, the states without
+// orders are absent
+func (r *OrderProductionRepository) CountByState(ctx context.Context) (map[order_entity.OrderState]int, error) {
+	counts := make(map[order_entity.OrderState]int)
+
+	sql, params, err := goqu.
+		From("orders").
+		Select("state", goqu.COUNT("*")).
+		GroupBy("state").
+		ToSQL()
+	if err != nil {
+		return counts, err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return counts, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var state order_entity.OrderState
+		var count int
+
+		if err := rows.Scan(&state, &count); err != nil {
+			return counts, err
+		}
+
+		counts[state] = count
+	}
+
+	return counts, rows.Err()
+}
+
 func (r *OrderProductionRepository) GetByState(ctx context.Context, state order_entity.OrderState) ([]order_entity.Order, error) {
 	var orders []order_entity.Order
 
 	sql, params, err := goqu.
 		From("orders").
-		Select("order_id", "state", "state_updated_at", "created_at", "updated_at").
+		Select(orderColumns...).
 		Where(goqu.C("state").Eq(state)).
 		Order(goqu.C("created_at").Desc()).
 		ToSQL()
@@ -175,19 +388,13 @@ func (r *OrderProductionRepository) GetByState(ctx context.Context, state order_
 	for orderStatement.Next() {
 		var order order_entity.Order
 
-		if err := orderStatement.Scan(
-			&order.Id,
-			&order.State,
-			&order.StateUpdatedAt,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		); err != nil {
+		if err := scanOrder(orderStatement, &order); err != nil {
 			return orders, err
 		}
 
 		sql, params, err := goqu.
 			From("order_items").
-			Select("id", "name", "quantity").
+			Select("id", "name", "quantity", "station", "modifiers").
 			Where(goqu.C("order_id").Eq(order.Id)).
 			ToSQL()
 		if err != nil {
@@ -209,6 +416,8 @@ func (r *OrderProductionRepository) GetByState(ctx context.Context, state order_
 				&item.Id,
 				&item.Name,
 				&item.Quantity,
+				&item.Station,
+				pq.Array(&item.Modifiers),
 			); err != nil {
 				return orders, err
 			}
@@ -225,7 +434,237 @@ func (r *OrderProductionRepository) GetByState(ctx context.Context, state order_
 	return orders, nil
 }
 
+// Export yields the orders created in the range of the filter with their
+// items, in the order they were created
+func (r *OrderProductionRepository) Export(ctx context.Context, filter order_entity.ExportFilter, yield func(order_entity.Order) error) error {
+	columns := make([]interface{}, 0, len(orderColumns)+5)
+	for _, column := range orderColumns {
+		columns = append(columns, goqu.T("orders").Col(column))
+	}
+	columns = append(columns,
+		goqu.T("order_items").Col("id"),
+		goqu.T("order_items").Col("name"),
+		goqu.T("order_items").Col("quantity"),
+		goqu.T("order_items").Col("station"),
+		goqu.T("order_items").Col("modifiers"),
+	)
+
+	dataset := goqu.
+		From("orders").
+		LeftJoin(goqu.T("order_items"), goqu.On(goqu.T("order_items").Col("order_id").Eq(goqu.T("orders").Col("order_id")))).
+		Select(columns...).
+		Where(
+			goqu.T("orders").Col("created_at").Gte(filter.From),
+			goqu.T("orders").Col("created_at").Lt(filter.To),
+		).
+		Order(
+			goqu.T("orders").Col("created_at").Asc(),
+			goqu.T("orders").Col("order_id").Asc(),
+			goqu.T("order_items").Col("name").Asc(),
+		)
+
+	if len(filter.States) > 0 {
+		dataset = dataset.Where(goqu.T("orders").Col("state").In(filter.States))
+	}
+
+	query, params, err := dataset.ToSQL()
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, query, params...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// the rows of an order are consecutive, it is yielded when the next order
+	// starts, so only one order is kept in memory
+	var current *order_entity.Order
+
+	for rows.Next() {
+		var order order_entity.Order
+		var itemId, itemName, itemStation sql.NullString
+		var itemQuantity sql.NullInt64
+		var itemModifiers []string
+
+		if err := scanOrder(exportRow{rows, []interface{}{&itemId, &itemName, &itemQuantity, &itemStation, pq.Array(&itemModifiers)}}, &order); err != nil {
+			return err
+		}
+
+		if current == nil || current.Id != order.Id {
+			if current != nil {
+				if err := yield(*current); err != nil {
+					return err
+				}
+			}
+
+			order.Items = make([]order_entity.Item, 0)
+			order.UpdateTimezone()
+			order.RefreshStateTitle()
+			current = &order
+		}
+
+		if itemId.Valid {
+			current.Items = append(current.Items, order_entity.Item{
+				Id:        itemId.String,
+				Name:      itemName.String,
+				Quantity:  int(itemQuantity.Int64),
+				Station:   itemStation.String,
+				Modifiers: itemModifiers,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if current != nil {
+		return yield(*current)
+	}
+
+	return nil
+}
+
+// exportRow scans the item columns after the order columns of the row
+type exportRow struct {
+	row   scanner
+	items []interface{}
+}
+
+func (r exportRow) Scan(dest ...interface{}) error {
+	return r.row.Scan(append(dest, r.items...)...)
+}
+
+// GetPickupBoard returns the orders being prepared and the orders completed
+// since readySince, the items are not loaded
+func (r *OrderProductionRepository) GetPickupBoard(ctx context.Context, readySince time.Time) ([]order_entity.Order, error) {
+	orders := make([]order_entity.Order, 0)
+
+	sql, params, err := goqu.
+		From("orders").
+		Select("order_id", "state", "state_updated_at", "created_at", "updated_at").
+		Where(goqu.Or(
+			goqu.C("state").Eq(order_entity.Processing),
+			goqu.And(
+				goqu.C("state").Eq(order_entity.Completed),
+				goqu.C("state_updated_at").Gte(readySince),
+			),
+		)).
+		Order(goqu.C("state_updated_at").Asc()).
+		ToSQL()
+	if err != nil {
+		return orders, err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return orders, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var order order_entity.Order
+
+		if err := rows.Scan(
+			&order.Id,
+			&order.State,
+			&order.StateUpdatedAt,
+			&order.CreatedAt,
+			&order.UpdatedAt,
+		); err != nil {
+			return orders, err
+		}
+
+		order.UpdateTimezone()
+		order.RefreshStateTitle()
+
+		orders = append(orders, order)
+	}
+
+	return orders, rows.Err()
+}
+
 func (r *OrderProductionRepository) Update(ctx context.Context, order *order_entity.Order) error {
+	return r.UpdateMany(ctx, []*order_entity.Order{order})
+}
+
+// Reconcile records that a manual order was received through the queue, the
+// event notifies the other replicas so they evict the order from their cache
+func (r *OrderProductionRepository) Reconcile(ctx context.Context, order *order_entity.Order) error {
+	sql, params, err := goqu.
+		Update("orders").
+		Set(goqu.Record{
+			"reconciled_at": order.ReconciledAt,
+			"updated_at":    order.UpdatedAt,
+		}).
+		Where(goqu.C("order_id").Eq(order.Id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	tx, err := repository.BeginTx(ctx, r.conn)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return errTx
+		}
+		return err
+	}
+
+	if err := r.saveEvent(ctx, tx, order); err != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return errTx
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.UpdateTimezone()
+
+	return nil
+}
+
+// UpdateMany saves the state of the orders in a single transaction, either
+// every order is updated or none is
+func (r *OrderProductionRepository) UpdateMany(ctx context.Context, orders []*order_entity.Order) error {
+	tx, err := repository.BeginTx(ctx, r.conn)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		if err := r.updateState(ctx, tx, order); err != nil {
+			errTx := tx.Rollback()
+			if errTx != nil {
+				return errTx
+			}
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		order.UpdateTimezone()
+	}
+
+	return nil
+}
+
+func (r *OrderProductionRepository) updateState(ctx context.Context, tx *repository.Tx, order *order_entity.Order) error {
 	sql, params, err := goqu.
 		Update("orders").
 		Set(goqu.Record{
@@ -239,12 +678,46 @@ func (r *OrderProductionRepository) Update(ctx context.Context, order *order_ent
 		return err
 	}
 
-	_, err = r.conn.ExecContext(ctx, sql, params...)
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return err
+	}
+
+	return r.saveEvent(ctx, tx, order)
+}
+
+// saveEvent records the order snapshot and notifies the other replicas, the
+// notification is only delivered when the transaction commits
+func (r *OrderProductionRepository) saveEvent(ctx context.Context, tx *repository.Tx, order *order_entity.Order) error {
+	event := order_entity.NewOrderEvent(*order, order.UpdatedAt)
+
+	payload, err := json.Marshal(event.Order)
+	if err != nil {
+		return err
+	}
+
+	sql, params, err := goqu.
+		Insert("order_events").
+		Cols("order_id", "type", "state", "payload", "created_at").
+		Vals(
+			goqu.Vals{
+				order.Id,
+				event.Type,
+				order.State,
+				string(payload),
+				event.CreatedAt,
+			},
+		).
+		Returning("id").
+		ToSQL()
 	if err != nil {
 		return err
 	}
 
-	order.UpdateTimezone()
+	if err := tx.QueryRowContext(ctx, sql, params...).Scan(&event.Id); err != nil {
+		return err
+	}
 
-	return nil
+	_, err = tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", repository.OrderEventsChannel, strconv.FormatInt(event.Id, 10))
+
+	return err
 }
@@ -2,6 +2,7 @@ package order_production
 
 import (
 	"context"
+	"strconv"
 	"testing"
 	"time"
 
@@ -29,6 +30,13 @@ func TestCreate(t *testing.T) {
 		mock.ExpectExec("INSERT INTO (.+)?order_items(.+)?").
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
+		mock.ExpectQuery("INSERT INTO (.+)?order_events(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		mock.ExpectExec("SELECT pg_notify(.+)?").
+			WithArgs("order_events", "1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
 		mock.ExpectCommit()
 
 		repo := NewOrderProductionRepository(db)
@@ -175,8 +183,8 @@ func TestGetByID(t *testing.T) {
 		)
 
 		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}).
-				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}).
+				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, order_entity.QueueOrigin, nil, nil, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
 
 		mock.ExpectQuery("SELECT (.+)?order_items(.+)?").
 			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "name", "quantity"}))
@@ -191,6 +199,41 @@ func TestGetByID(t *testing.T) {
 		assert.NotEmpty(t, order)
 	})
 
+	t.Run("Should return the origin of a manual order", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		now := time.Now()
+
+		expectedOrder := order_entity.NewOrder(
+			uuid.NewString(),
+			now,
+		)
+
+		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}).
+				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, order_entity.ManualOrigin, "user-1", now, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
+
+		mock.ExpectQuery("SELECT (.+)?order_items(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "quantity", "station", "modifiers"}))
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		order, err := repo.GetByID(ctx, expectedOrder.Id)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, order_entity.ManualOrigin, order.Origin)
+		assert.Equal(t, "user-1", order.CreatedBy)
+		assert.NotNil(t, order.ReconciledAt)
+		assert.True(t, now.Equal(*order.ReconciledAt))
+	})
+
 	t.Run("Should return order with items", func(t *testing.T) {
 		// Arrange
 		db, mock, err := sqlmock.New()
@@ -214,12 +257,12 @@ func TestGetByID(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}).
-				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}).
+				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, order_entity.QueueOrigin, nil, nil, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
 
 		mock.ExpectQuery("SELECT (.+)?order_items(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "quantity"}).
-				AddRow(orderItem.Id, orderItem.Name, orderItem.Quantity))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "quantity", "station", "modifiers"}).
+				AddRow(orderItem.Id, orderItem.Name, orderItem.Quantity, orderItem.Station, "{}"))
 
 		repo := NewOrderProductionRepository(db)
 
@@ -245,8 +288,8 @@ func TestGetByID(t *testing.T) {
 		)
 
 		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}).
-				AddRow(expectedOrder.Id, "abc", expectedOrder.StateUpdatedAt, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}).
+				AddRow(expectedOrder.Id, "abc", expectedOrder.StateUpdatedAt, order_entity.QueueOrigin, nil, nil, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
 
 		repo := NewOrderProductionRepository(db)
 
@@ -272,12 +315,12 @@ func TestGetByID(t *testing.T) {
 		)
 
 		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}).
-				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}).
+				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, order_entity.QueueOrigin, nil, nil, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
 
 		mock.ExpectQuery("SELECT (.+)?order_items(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "quantity"}).
-				AddRow("id", "name", "quantity"))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "quantity", "station", "modifiers"}).
+				AddRow("id", "name", "quantity", "station", "{}"))
 
 		repo := NewOrderProductionRepository(db)
 
@@ -312,8 +355,8 @@ func TestGetByID(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}).
-				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}).
+				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, order_entity.QueueOrigin, nil, nil, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
 
 		mock.ExpectQuery("SELECT (.+)?order_items(.+)?").
 			WillReturnError(assert.AnError)
@@ -368,7 +411,7 @@ func TestGetByID(t *testing.T) {
 		)
 
 		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}))
 
 		repo := NewOrderProductionRepository(db)
 
@@ -407,12 +450,12 @@ func TestGetByState(t *testing.T) {
 		assert.NoError(t, err)
 
 		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}).
-				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}).
+				AddRow(expectedOrder.Id, expectedOrder.State, expectedOrder.StateUpdatedAt, order_entity.QueueOrigin, nil, nil, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
 
 		mock.ExpectQuery("SELECT (.+)?order_items(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "quantity"}).
-				AddRow(orderItem.Id, orderItem.Name, orderItem.Quantity))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "quantity", "station", "modifiers"}).
+				AddRow(orderItem.Id, orderItem.Name, orderItem.Quantity, orderItem.Station, "{}"))
 
 		repo := NewOrderProductionRepository(db)
 
@@ -438,7 +481,7 @@ func TestGetByState(t *testing.T) {
 		)
 
 		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}))
 
 		repo := NewOrderProductionRepository(db)
 
@@ -490,8 +533,8 @@ func TestGetByState(t *testing.T) {
 		)
 
 		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}).
-				AddRow(expectedOrder.Id, "abc", expectedOrder.StateUpdatedAt, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}).
+				AddRow(expectedOrder.Id, "abc", expectedOrder.StateUpdatedAt, order_entity.QueueOrigin, nil, nil, expectedOrder.CreatedAt, expectedOrder.UpdatedAt))
 
 		repo := NewOrderProductionRepository(db)
 
@@ -504,6 +547,96 @@ func TestGetByState(t *testing.T) {
 	})
 }
 
+func TestReconcile(t *testing.T) {
+	t.Run("Should save the reconciliation of the order", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		now := time.Now()
+
+		order := order_entity.NewOrder(uuid.NewString(), now)
+		order.SetManualOrigin("user-1")
+		order.Reconcile(now)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?orders(.+)?reconciled_at(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("INSERT INTO (.+)?order_events(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec("SELECT pg_notify(.+)?").
+			WithArgs("order_events", "1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		err = repo.Reconcile(ctx, &order)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when the update fails", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+		order.Reconcile(time.Now())
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?orders(.+)?").
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		err = repo.Reconcile(ctx, &order)
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when the event can not be saved", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		order := order_entity.NewOrder(uuid.NewString(), time.Now())
+		order.Reconcile(time.Now())
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE (.+)?orders(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("INSERT INTO (.+)?order_events(.+)?").
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		err = repo.Reconcile(ctx, &order)
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	t.Run("Should update the order", func(t *testing.T) {
 		// Arrange
@@ -520,9 +653,20 @@ func TestUpdate(t *testing.T) {
 			now,
 		)
 
+		mock.ExpectBegin()
+
 		mock.ExpectExec("UPDATE (.+)?orders(.+)?").
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
+		mock.ExpectQuery("INSERT INTO (.+)?order_events(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		mock.ExpectExec("SELECT pg_notify(.+)?").
+			WithArgs("order_events", "1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectCommit()
+
 		repo := NewOrderProductionRepository(db)
 
 		// Act
@@ -545,9 +689,71 @@ func TestUpdate(t *testing.T) {
 			time.Now(),
 		)
 
+		mock.ExpectBegin()
+
 		mock.ExpectExec("UPDATE (.+)?orders(.+)?").
 			WillReturnError(assert.AnError)
 
+		mock.ExpectRollback()
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		err = repo.Update(ctx, &expectedOrder)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should rollback when try to save the order event", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		expectedOrder := order_entity.NewOrder(
+			uuid.NewString(),
+			time.Now(),
+		)
+
+		mock.ExpectBegin()
+
+		mock.ExpectExec("UPDATE (.+)?orders(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		mock.ExpectQuery("INSERT INTO (.+)?order_events(.+)?").
+			WillReturnError(assert.AnError)
+
+		mock.ExpectRollback()
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		err = repo.Update(ctx, &expectedOrder)
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when try to begin the transaction", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		expectedOrder := order_entity.NewOrder(
+			uuid.NewString(),
+			time.Now(),
+		)
+
+		mock.ExpectBegin().
+			WillReturnError(assert.AnError)
+
 		repo := NewOrderProductionRepository(db)
 
 		// Act
@@ -557,3 +763,391 @@ func TestUpdate(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestGetPickupBoard(t *testing.T) {
+	t.Run("Should return the orders of the pickup board", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		now := time.Now()
+
+		mock.ExpectQuery("SELECT (.+)?orders(.+)?state_updated_at(.+)?>=(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "created_at", "updated_at"}).
+				AddRow(uuid.NewString(), order_entity.Processing, now, now, now).
+				AddRow(uuid.NewString(), order_entity.Completed, now, now, now))
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		orders, err := repo.GetPickupBoard(ctx, now.Add(-15*time.Minute))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, orders, 2)
+		assert.Equal(t, "Completed", orders[1].StateTitle)
+		assert.Empty(t, orders[0].Items)
+	})
+
+	t.Run("Should return error when try to get the pickup board", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		_, err = repo.GetPickupBoard(ctx, time.Now())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateMany(t *testing.T) {
+	t.Run("Should update the orders in a single transaction", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		now := time.Now()
+
+		first := order_entity.NewOrder(uuid.NewString(), now)
+		second := order_entity.NewOrder(uuid.NewString(), now)
+
+		mock.ExpectBegin()
+
+		for i := 1; i <= 2; i++ {
+			mock.ExpectExec("UPDATE (.+)?orders(.+)?").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			mock.ExpectQuery("INSERT INTO (.+)?order_events(.+)?").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(i))
+
+			mock.ExpectExec("SELECT pg_notify(.+)?").
+				WithArgs("order_events", strconv.Itoa(i)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+
+		mock.ExpectCommit()
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		err = repo.UpdateMany(ctx, []*order_entity.Order{&first, &second})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should rollback every order when one update fails", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		now := time.Now()
+
+		first := order_entity.NewOrder(uuid.NewString(), now)
+		second := order_entity.NewOrder(uuid.NewString(), now)
+
+		mock.ExpectBegin()
+
+		mock.ExpectExec("UPDATE (.+)?orders(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		mock.ExpectQuery("INSERT INTO (.+)?order_events(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		mock.ExpectExec("SELECT pg_notify(.+)?").
+			WithArgs("order_events", "1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectExec("UPDATE (.+)?orders(.+)?").
+			WillReturnError(assert.AnError)
+
+		mock.ExpectRollback()
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		err = repo.UpdateMany(ctx, []*order_entity.Order{&first, &second})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetByIDs(t *testing.T) {
+	t.Run("Should return the orders with their items", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		now := time.Now()
+
+		firstId := uuid.NewString()
+		secondId := uuid.NewString()
+
+		mock.ExpectQuery("SELECT (.+)?orders(.+)?IN(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at"}).
+				AddRow(firstId, order_entity.Completed, now, order_entity.QueueOrigin, nil, nil, now, now).
+				AddRow(secondId, order_entity.Completed, now, order_entity.QueueOrigin, nil, nil, now, now))
+
+		mock.ExpectQuery("SELECT (.+)?order_items(.+)?IN(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"order_id", "id", "name", "quantity", "station", "modifiers"}).
+				AddRow(secondId, uuid.NewString(), "Hamburger", 1, "grill", `{"no onions","extra cheese"}`).
+				AddRow(secondId, uuid.NewString(), "Fries", 1, "fryer", "{}"))
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		orders, err := repo.GetByIDs(ctx, []string{firstId, secondId, uuid.NewString()})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, orders, 2)
+		assert.Empty(t, orders[0].Items)
+		assert.Len(t, orders[1].Items, 2)
+		assert.Equal(t, []string{"no onions", "extra cheese"}, orders[1].Items[0].Modifiers)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should not query when there are no ids", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		orders, err := repo.GetByIDs(context.Background(), nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, orders)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when try to get the orders", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		_, err = repo.GetByIDs(context.Background(), []string{uuid.NewString()})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestGetIDsByStateUpdatedBefore(t *testing.T) {
+	t.Run("Should return the ids", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		id := uuid.NewString()
+
+		mock.ExpectQuery("SELECT (.+)?order_id(.+)?orders(.+)?state_updated_at(.+)?<(.+)?LIMIT(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow(id))
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		ids, err := repo.GetIDsByStateUpdatedBefore(context.Background(), order_entity.Completed, time.Now(), 100)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{id}, ids)
+	})
+
+	t.Run("Should return error when try to get the ids", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		_, err = repo.GetIDsByStateUpdatedBefore(context.Background(), order_entity.Completed, time.Now(), 100)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestCountByState(t *testing.T) {
+	t.Run("Should return the number of orders at each state", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+)?state(.+)?COUNT(.+)?orders(.+)?GROUP BY(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"state", "count"}).
+				AddRow(order_entity.Received, 3).
+				AddRow(order_entity.Processing, 1))
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		counts, err := repo.CountByState(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, map[order_entity.OrderState]int{
+			order_entity.Received:   3,
+			order_entity.Processing: 1,
+		}, counts)
+	})
+
+	t.Run("Should return error when try to count the orders", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		_, err = repo.CountByState(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestExport(t *testing.T) {
+	exportColumns := []string{"order_id", "state", "state_updated_at", "origin", "created_by", "reconciled_at", "created_at", "updated_at", "id", "name", "quantity", "station", "modifiers"}
+
+	t.Run("Should yield every order with its items", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		now := time.Now()
+		firstId := uuid.NewString()
+		secondId := uuid.NewString()
+
+		mock.ExpectQuery(`SELECT (.+)?"orders"(.+)?LEFT JOIN "order_items"(.+)?"state" IN \(1, 3\)(.+)?ORDER BY(.+)?`).
+			WillReturnRows(sqlmock.NewRows(exportColumns).
+				AddRow(firstId, order_entity.Received, now, order_entity.QueueOrigin, nil, nil, now, now, "item-1", "Burger", 2, "grill", `{"no onions"}`).
+				AddRow(firstId, order_entity.Received, now, order_entity.QueueOrigin, nil, nil, now, now, "item-2", "Fries", 1, "", "{}").
+				AddRow(secondId, order_entity.Completed, now, order_entity.ManualOrigin, "user-1", nil, now, now, nil, nil, nil, nil, nil))
+
+		repo := NewOrderProductionRepository(db)
+
+		filter := order_entity.ExportFilter{
+			From:   now.Add(-time.Hour),
+			To:     now,
+			States: []order_entity.OrderState{order_entity.Received, order_entity.Completed},
+		}
+
+		orders := make([]order_entity.Order, 0)
+
+		// Act
+		err = repo.Export(context.Background(), filter, func(order order_entity.Order) error {
+			orders = append(orders, order)
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, orders, 2)
+		assert.Equal(t, firstId, orders[0].Id)
+		assert.Equal(t, []order_entity.Item{
+			{Id: "item-1", Name: "Burger", Quantity: 2, Station: "grill", Modifiers: []string{"no onions"}},
+			{Id: "item-2", Name: "Fries", Quantity: 1, Station: "", Modifiers: []string{}},
+		}, orders[0].Items)
+		assert.Equal(t, "Received", orders[0].StateTitle)
+		assert.Equal(t, secondId, orders[1].Id)
+		assert.Equal(t, "user-1", orders[1].CreatedBy)
+		assert.Empty(t, orders[1].Items)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should stop when yield returns error", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		now := time.Now()
+
+		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
+			WillReturnRows(sqlmock.NewRows(exportColumns).
+				AddRow(uuid.NewString(), order_entity.Received, now, order_entity.QueueOrigin, nil, nil, now, now, nil, nil, nil, nil, nil).
+				AddRow(uuid.NewString(), order_entity.Received, now, order_entity.QueueOrigin, nil, nil, now, now, nil, nil, nil, nil, nil))
+
+		repo := NewOrderProductionRepository(db)
+
+		calls := 0
+
+		// Act
+		err = repo.Export(context.Background(), order_entity.ExportFilter{From: now, To: now}, func(order order_entity.Order) error {
+			calls++
+			return assert.AnError
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Should return error when try to query the orders", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT (.+)?orders(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewOrderProductionRepository(db)
+
+		// Act
+		err = repo.Export(context.Background(), order_entity.ExportFilter{}, func(order order_entity.Order) error {
+			return nil
+		})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
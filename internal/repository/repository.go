@@ -2,13 +2,64 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
 )
 
+// OrderEventsChannel is the Postgres channel notified with the id of every
+// order event saved
+const OrderEventsChannel = "order_events"
+
 type OrderProductionRepository interface {
 	Create(ctx context.Context, order *order_entity.Order) error
 	GetByID(ctx context.Context, id string) (order_entity.Order, error)
+	GetByIDs(ctx context.Context, ids []string) ([]order_entity.Order, error)
 	GetByState(ctx context.Context, state order_entity.OrderState) ([]order_entity.Order, error)
+	CountByState(ctx context.Context) (map[order_entity.OrderState]int, error)
+	// Export calls yield with every order of the filter and its items, oldest
+	// first, without loading all of them in memory
+	Export(ctx context.Context, filter order_entity.ExportFilter, yield func(order_entity.Order) error) error
+	GetIDsByStateUpdatedBefore(ctx context.Context, state order_entity.OrderState, before time.Time, limit int) ([]string, error)
+	GetPickupBoard(ctx context.Context, readySince time.Time) ([]order_entity.Order, error)
+	Reconcile(ctx context.Context, order *order_entity.Order) error
 	Update(ctx context.Context, order *order_entity.Order) error
+	UpdateMany(ctx context.Context, orders []*order_entity.Order) error
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *webhook_entity.Subscription) error
+	GetSubscriptionByID(ctx context.Context, id string) (webhook_entity.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]webhook_entity.Subscription, error)
+	ListActiveSubscriptions(ctx context.Context) ([]webhook_entity.Subscription, error)
+	UpdateSubscription(ctx context.Context, subscription *webhook_entity.Subscription) error
+	DeleteSubscription(ctx context.Context, id string) error
+
+	CreateDelivery(ctx context.Context, delivery *webhook_entity.Delivery) error
+	ListDeliveries(ctx context.Context, subscriptionId string, limit int) ([]webhook_entity.Delivery, error)
+}
+
+type ApiKeyRepository interface {
+	CreateApiKey(ctx context.Context, apiKey *api_key_entity.ApiKey) error
+	GetApiKeyByID(ctx context.Context, id string) (api_key_entity.ApiKey, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (api_key_entity.ApiKey, error)
+	ListApiKeys(ctx context.Context) ([]api_key_entity.ApiKey, error)
+	UpdateApiKey(ctx context.Context, apiKey *api_key_entity.ApiKey) error
+	RotateApiKey(ctx context.Context, replaced *api_key_entity.ApiKey, apiKey *api_key_entity.ApiKey) error
+	TouchApiKey(ctx context.Context, id string, lastUsedAt time.Time) error
+}
+
+type OrderEventRepository interface {
+	GetEventByID(ctx context.Context, id int64) (order_entity.OrderEvent, error)
+	ListEventsSince(ctx context.Context, since time.Time, afterId int64, limit int) ([]order_entity.OrderEvent, error)
+	DeleteEventsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AuditRepository is append only, the entries are never updated nor deleted
+type AuditRepository interface {
+	CreateEntry(ctx context.Context, entry *audit_entity.Entry) error
+	ListEntries(ctx context.Context, filter audit_entity.Filter) ([]audit_entity.Entry, error)
 }
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+// Transactor runs a function in a transaction, the repositories called with
+// the context of the function join it, e.g. so a change and its audit entry
+// are either both saved or none is
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type SqlTransactor struct {
+	conn *sql.DB
+}
+
+func NewTransactor(conn *sql.DB) *SqlTransactor {
+	return &SqlTransactor{
+		conn: conn,
+	}
+}
+
+// WithinTransaction commits when fn succeeds and rolls back otherwise, fn
+// joins the transaction of the context when there is already one
+func (t *SqlTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := BeginTx(ctx, t.conn)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx.Tx)); err != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return errTx
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Tx is the transaction of a repository, when it joined the transaction of
+// the context Commit and Rollback do nothing: the owner of the transaction
+// ends it
+type Tx struct {
+	*sql.Tx
+
+	joined bool
+}
+
+// BeginTx starts a transaction, or joins the one of the context
+func BeginTx(ctx context.Context, conn *sql.DB) (*Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return &Tx{Tx: tx, joined: true}, nil
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tx{Tx: tx}, nil
+}
+
+func (tx *Tx) Commit() error {
+	if tx.joined {
+		return nil
+	}
+
+	return tx.Tx.Commit()
+}
+
+func (tx *Tx) Rollback() error {
+	if tx.joined {
+		return nil
+	}
+
+	return tx.Tx.Rollback()
+}
+
+// Executor runs the statements of the repositories, either on the connection
+// or in a transaction
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Conn returns the transaction of the context, so the single statements join
+// it, or the connection when there is none
+func Conn(ctx context.Context, conn *sql.DB) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return conn
+}
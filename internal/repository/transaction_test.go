@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithinTransaction(t *testing.T) {
+	t.Run("Should commit the statements of the repositories that joined the transaction", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE orders").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO audit_log").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		transactor := NewTransactor(db)
+
+		// Act
+		err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			tx, err := BeginTx(ctx, db)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.ExecContext(ctx, "UPDATE orders"); err != nil {
+				return err
+			}
+
+			if err := tx.Commit(); err != nil {
+				return err
+			}
+
+			_, err = Conn(ctx, db).ExecContext(ctx, "INSERT INTO audit_log")
+
+			return err
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should roll back every statement when the function fails", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE orders").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO audit_log").
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		transactor := NewTransactor(db)
+
+		// Act
+		err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := Conn(ctx, db).ExecContext(ctx, "UPDATE orders"); err != nil {
+				return err
+			}
+
+			_, err := Conn(ctx, db).ExecContext(ctx, "INSERT INTO audit_log")
+
+			return err
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when the transaction cannot be started", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin().
+			WillReturnError(assert.AnError)
+
+		transactor := NewTransactor(db)
+
+		called := false
+
+		// Act
+		err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, called)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
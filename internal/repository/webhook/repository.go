@@ -0,0 +1,273 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/lib/pq"
+)
+
+var subscriptionColumns = []interface{}{
+	"id",
+	"url",
+	"event_types",
+	"secret",
+	"active",
+	"consecutive_failures",
+	"disabled_at",
+	"created_at",
+	"updated_at",
+}
+
+type WebhookRepository struct {
+	conn *sql.DB
+}
+
+func NewWebhookRepository(conn *sql.DB) *WebhookRepository {
+	return &WebhookRepository{
+		conn: conn,
+	}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *webhook_entity.Subscription) error {
+	sql, params, err := goqu.
+		Insert("webhook_subscriptions").
+		Cols(subscriptionColumns...).
+		Vals(
+			goqu.Vals{
+				subscription.Id,
+				subscription.Url,
+				pq.Array(subscription.EventTypes),
+				subscription.Secret,
+				subscription.Active,
+				subscription.ConsecutiveFailures,
+				subscription.DisabledAt,
+				subscription.CreatedAt,
+				subscription.UpdatedAt,
+			},
+		).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = repository.Conn(ctx, r.conn).ExecContext(ctx, sql, params...)
+
+	return err
+}
+
+func (r *WebhookRepository) GetSubscriptionByID(ctx context.Context, id string) (webhook_entity.Subscription, error) {
+	subscriptions, err := r.listSubscriptions(ctx, goqu.C("id").Eq(id))
+	if err != nil {
+		return webhook_entity.Subscription{}, err
+	}
+
+	if len(subscriptions) == 0 {
+		return webhook_entity.Subscription{}, custom_error.ErrWebhookNotFound
+	}
+
+	return subscriptions[0], nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]webhook_entity.Subscription, error) {
+	return r.listSubscriptions(ctx)
+}
+
+func (r *WebhookRepository) ListActiveSubscriptions(ctx context.Context) ([]webhook_entity.Subscription, error) {
+	return r.listSubscriptions(ctx, goqu.C("active").IsTrue())
+}
+
+func (r *WebhookRepository) listSubscriptions(ctx context.Context, filters ...exp.Expression) ([]webhook_entity.Subscription, error) {
+	subscriptions := make([]webhook_entity.Subscription, 0)
+
+	sql, params, err := goqu.
+		From("webhook_subscriptions").
+		Select(subscriptionColumns...).
+		Where(filters...).
+		Order(goqu.C("created_at").Asc()).
+		ToSQL()
+	if err != nil {
+		return subscriptions, err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return subscriptions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var subscription webhook_entity.Subscription
+
+		if err := rows.Scan(
+			&subscription.Id,
+			&subscription.Url,
+			pq.Array(&subscription.EventTypes),
+			&subscription.Secret,
+			&subscription.Active,
+			&subscription.ConsecutiveFailures,
+			&subscription.DisabledAt,
+			&subscription.CreatedAt,
+			&subscription.UpdatedAt,
+		); err != nil {
+			return subscriptions, err
+		}
+
+		if subscription.EventTypes == nil {
+			subscription.EventTypes = make([]string, 0)
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, subscription *webhook_entity.Subscription) error {
+	sql, params, err := goqu.
+		Update("webhook_subscriptions").
+		Set(goqu.Record{
+			"url":                  subscription.Url,
+			"event_types":          pq.Array(subscription.EventTypes),
+			"active":               subscription.Active,
+			"consecutive_failures": subscription.ConsecutiveFailures,
+			"disabled_at":          subscription.DisabledAt,
+			"updated_at":           subscription.UpdatedAt,
+		}).
+		Where(goqu.C("id").Eq(subscription.Id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = repository.Conn(ctx, r.conn).ExecContext(ctx, sql, params...)
+
+	return err
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	tx, err := repository.BeginTx(ctx, r.conn)
+	if err != nil {
+		return err
+	}
+
+	sql, params, err := goqu.
+		Delete("webhook_deliveries").
+		Where(goqu.C("subscription_id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return errTx
+		}
+		return err
+	}
+
+	sql, params, err = goqu.
+		Delete("webhook_subscriptions").
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, sql, params...)
+	if err != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return errTx
+		}
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return errTx
+		}
+		return custom_error.ErrWebhookNotFound
+	}
+
+	return tx.Commit()
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *webhook_entity.Delivery) error {
+	sql, params, err := goqu.
+		Insert("webhook_deliveries").
+		Cols("id", "subscription_id", "event_id", "event_type", "attempt", "success", "status_code", "error", "duration_ms", "created_at").
+		Vals(
+			goqu.Vals{
+				delivery.Id,
+				delivery.SubscriptionId,
+				delivery.EventId,
+				delivery.EventType,
+				delivery.Attempt,
+				delivery.Success,
+				delivery.StatusCode,
+				delivery.Error,
+				delivery.DurationMs,
+				delivery.CreatedAt,
+			},
+		).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn.ExecContext(ctx, sql, params...)
+
+	return err
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionId string, limit int) ([]webhook_entity.Delivery, error) {
+	deliveries := make([]webhook_entity.Delivery, 0)
+
+	sql, params, err := goqu.
+		From("webhook_deliveries").
+		Select("id", "subscription_id", "event_id", "event_type", "attempt", "success", "status_code", "error", "duration_ms", "created_at").
+		Where(goqu.C("subscription_id").Eq(subscriptionId)).
+		Order(goqu.C("created_at").Desc()).
+		Limit(uint(limit)).
+		ToSQL()
+	if err != nil {
+		return deliveries, err
+	}
+
+	rows, err := r.conn.QueryContext(ctx, sql, params...)
+	if err != nil {
+		return deliveries, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var delivery webhook_entity.Delivery
+
+		if err := rows.Scan(
+			&delivery.Id,
+			&delivery.SubscriptionId,
+			&delivery.EventId,
+			&delivery.EventType,
+			&delivery.Attempt,
+			&delivery.Success,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.DurationMs,
+			&delivery.CreatedAt,
+		); err != nil {
+			return deliveries, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,308 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+var subscriptionRows = []string{"id", "url", "event_types", "secret", "active", "consecutive_failures", "disabled_at", "created_at", "updated_at"}
+
+func TestCreateSubscription(t *testing.T) {
+	t.Run("Should create a subscription", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("INSERT INTO (.+)?webhook_subscriptions(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		repo := NewWebhookRepository(db)
+
+		subscription := webhook_entity.NewSubscription(uuid.NewString(), "http://localhost", []string{"production.order.created"}, "secret", time.Now())
+
+		// Act
+		err = repo.CreateSubscription(ctx, &subscription)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return error when try to create the subscription", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("INSERT INTO (.+)?webhook_subscriptions(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewWebhookRepository(db)
+
+		subscription := webhook_entity.NewSubscription(uuid.NewString(), "http://localhost", nil, "secret", time.Now())
+
+		// Act
+		err = repo.CreateSubscription(ctx, &subscription)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestGetSubscriptionByID(t *testing.T) {
+	t.Run("Should return the subscription", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+		id := uuid.NewString()
+
+		mock.ExpectQuery("SELECT (.+)?webhook_subscriptions(.+)?").
+			WillReturnRows(sqlmock.NewRows(subscriptionRows).
+				AddRow(id, "http://localhost", "{production.order.created,production.order.cancelled}", "secret", true, 0, nil, now, now))
+
+		repo := NewWebhookRepository(db)
+
+		// Act
+		subscription, err := repo.GetSubscriptionByID(ctx, id)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, id, subscription.Id)
+		assert.Equal(t, []string{"production.order.created", "production.order.cancelled"}, subscription.EventTypes)
+		assert.True(t, subscription.Active)
+	})
+
+	t.Run("Should return not found when the subscription does not exist", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?webhook_subscriptions(.+)?").
+			WillReturnRows(sqlmock.NewRows(subscriptionRows))
+
+		repo := NewWebhookRepository(db)
+
+		// Act
+		_, err = repo.GetSubscriptionByID(ctx, uuid.NewString())
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrWebhookNotFound)
+	})
+}
+
+func TestListActiveSubscriptions(t *testing.T) {
+	t.Run("Should return the active subscriptions", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		mock.ExpectQuery("SELECT (.+)?webhook_subscriptions(.+)?active(.+)?").
+			WillReturnRows(sqlmock.NewRows(subscriptionRows).
+				AddRow(uuid.NewString(), "http://localhost", "{}", "secret", true, 0, nil, now, now))
+
+		repo := NewWebhookRepository(db)
+
+		// Act
+		subscriptions, err := repo.ListActiveSubscriptions(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, subscriptions, 1)
+		assert.Empty(t, subscriptions[0].EventTypes)
+	})
+
+	t.Run("Should return error when try to list the subscriptions", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT (.+)?webhook_subscriptions(.+)?").
+			WillReturnError(assert.AnError)
+
+		repo := NewWebhookRepository(db)
+
+		// Act
+		_, err = repo.ListActiveSubscriptions(ctx)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateSubscription(t *testing.T) {
+	t.Run("Should update the subscription", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE (.+)?webhook_subscriptions(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		repo := NewWebhookRepository(db)
+
+		subscription := webhook_entity.NewSubscription(uuid.NewString(), "http://localhost", nil, "secret", time.Now())
+		subscription.Disable(time.Now())
+
+		// Act
+		err = repo.UpdateSubscription(ctx, &subscription)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteSubscription(t *testing.T) {
+	t.Run("Should delete the subscription and its deliveries", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM (.+)?webhook_deliveries(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("DELETE FROM (.+)?webhook_subscriptions(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		repo := NewWebhookRepository(db)
+
+		// Act
+		err = repo.DeleteSubscription(ctx, uuid.NewString())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should return not found when the subscription does not exist", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM (.+)?webhook_deliveries(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM (.+)?webhook_subscriptions(.+)?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		repo := NewWebhookRepository(db)
+
+		// Act
+		err = repo.DeleteSubscription(ctx, uuid.NewString())
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrWebhookNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Should rollback when try to delete the deliveries", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM (.+)?webhook_deliveries(.+)?").
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		repo := NewWebhookRepository(db)
+
+		// Act
+		err = repo.DeleteSubscription(ctx, uuid.NewString())
+
+		// Assert
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreateDelivery(t *testing.T) {
+	t.Run("Should create a delivery", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectExec("INSERT INTO (.+)?webhook_deliveries(.+)?").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		repo := NewWebhookRepository(db)
+
+		delivery := webhook_entity.NewDelivery(uuid.NewString(), uuid.NewString(), uuid.NewString(), "production.order.created", 1, time.Now())
+
+		// Act
+		err = repo.CreateDelivery(ctx, &delivery)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListDeliveries(t *testing.T) {
+	t.Run("Should return the deliveries", func(t *testing.T) {
+		// Arrange
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		subscriptionId := uuid.NewString()
+
+		mock.ExpectQuery("SELECT (.+)?webhook_deliveries(.+)?").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "event_id", "event_type", "attempt", "success", "status_code", "error", "duration_ms", "created_at"}).
+				AddRow(uuid.NewString(), subscriptionId, uuid.NewString(), "production.order.created", 1, true, 200, "", 12, time.Now()))
+
+		repo := NewWebhookRepository(db)
+
+		// Act
+		deliveries, err := repo.ListDeliveries(ctx, subscriptionId, 10)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, 200, deliveries[0].StatusCode)
+	})
+}
@@ -1,23 +1,69 @@
 package server
 
 import (
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/provider/time_provider"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	api_key_authenticate "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/authenticate"
+	api_key_create "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/create"
+	api_key_list "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/list"
+	api_key_revoke "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/revoke"
+	api_key_rotate "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/rotate"
+	audit_list "github.com/jfelipearaujo-org/ms-production-management/internal/service/audit/list"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/bulk_update"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/export"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_id"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_state"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_pickup_board"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/update"
+	webhook_create "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/create"
+	webhook_list "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/list"
+	webhook_list_deliveries "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/list_deliveries"
+	webhook_remove "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/remove"
+	webhook_update "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/update"
 )
 
 type Dependency struct {
 	TimeProvider *time_provider.TimeProvider
 
 	OrderProductionRepository repository.OrderProductionRepository
+	// OrderProductionReadRepository reads the orders through the cache when
+	// enabled, it must not be used by the services that change the orders
+	OrderProductionReadRepository repository.OrderProductionRepository
+	WebhookRepository             repository.WebhookRepository
+	OrderEventRepository          repository.OrderEventRepository
+	ApiKeyRepository              repository.ApiKeyRepository
+	AuditRepository               repository.AuditRepository
 
+	AuditRecorder audit.Recorder
+
+	CreateOrderProduction     service.CreateOrderProductionService[create.CreateOrderProductionInput]
 	GetOrderProductionById    service.GetOrderProductionByIdService[get_by_id.GetOrderProductionByIdInput]
 	GetOrderProductionByState service.GetOrderProductionByStateService[get_by_state.GetOrderProductionByStateInput]
 	UpdateOrderProduction     service.UpdateOrderProductionService[update.UpdateOrderProductionInput]
+	BulkUpdateOrderProduction service.BulkUpdateOrderProductionService[bulk_update.BulkUpdateOrderProductionInput]
+	GetPickupBoard            service.GetPickupBoardService[get_pickup_board.GetPickupBoardInput]
+	ExportOrderProduction     service.ExportOrderProductionService[export.ExportOrderProductionInput]
+
+	CreateWebhook         service.CreateWebhookService[webhook_create.CreateWebhookInput]
+	ListWebhook           service.ListWebhookService[webhook_list.ListWebhookInput]
+	UpdateWebhook         service.UpdateWebhookService[webhook_update.UpdateWebhookInput]
+	DeleteWebhook         service.DeleteWebhookService[webhook_remove.DeleteWebhookInput]
+	ListWebhookDeliveries service.ListWebhookDeliveriesService[webhook_list_deliveries.ListWebhookDeliveriesInput]
+
+	CreateApiKey       service.CreateApiKeyService[api_key_create.CreateApiKeyInput]
+	ListApiKey         service.ListApiKeyService[api_key_list.ListApiKeyInput]
+	RotateApiKey       service.RotateApiKeyService[api_key_rotate.RotateApiKeyInput]
+	RevokeApiKey       service.RevokeApiKeyService[api_key_revoke.RevokeApiKeyInput]
+	AuthenticateApiKey service.AuthenticateApiKeyService[api_key_authenticate.AuthenticateApiKeyInput]
+
+	ListAudit service.ListAuditService[audit_list.ListAuditInput]
+
+	OrderStreamer stream.Streamer
 
 	UpdateOrderTopicService cloud.TopicService
 }
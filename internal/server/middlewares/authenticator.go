@@ -0,0 +1,68 @@
+package token
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/authenticate"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+)
+
+// HeaderApiKey is the header of the API keys of the machine clients, the gRPC
+// clients send it as the x-api-key metadata
+const HeaderApiKey = "X-Api-Key"
+
+var ErrInvalidApiKey = errors.New("Invalid API key")
+
+// Authenticator accepts a bearer token or an API key, the key is used when
+// both are sent
+type Authenticator struct {
+	verifier *Verifier
+	apiKeys  service.AuthenticateApiKeyService[authenticate.AuthenticateApiKeyInput]
+	storeId  string
+}
+
+// NewAuthenticator only accepts the bearer tokens when apiKeys is nil, the
+// API keys are created by the deployment so they have access to its store
+func NewAuthenticator(
+	verifier *Verifier,
+	apiKeys service.AuthenticateApiKeyService[authenticate.AuthenticateApiKeyInput],
+	storeId string,
+) *Authenticator {
+	return &Authenticator{
+		verifier: verifier,
+		apiKeys:  apiKeys,
+		storeId:  storeId,
+	}
+}
+
+// Authenticate returns the principal of the request, the errors other than
+// ErrTokenRequired, ErrInvalidToken and ErrInvalidApiKey are failures to
+// read the API key
+func (a *Authenticator) Authenticate(ctx context.Context, authorizationHeader string, apiKeyHeader string) (Principal, error) {
+	if apiKeyHeader == "" || a.apiKeys == nil {
+		return a.verifier.Verify(ctx, authorizationHeader)
+	}
+
+	apiKey, err := a.apiKeys.Handle(ctx, authenticate.AuthenticateApiKeyInput{Key: apiKeyHeader})
+	if err != nil {
+		if errors.Is(err, custom_error.ErrApiKeyNotValid) {
+			return Principal{}, ErrInvalidApiKey
+		}
+		return Principal{}, err
+	}
+
+	principal := NewApiKeyPrincipal(*apiKey)
+	principal.StoreId = a.storeId
+
+	return principal, nil
+}
+
+// IsUnauthenticated reports if the error is caused by the credentials of the
+// request
+func IsUnauthenticated(err error) bool {
+	return errors.Is(err, ErrTokenRequired) ||
+		errors.Is(err, ErrInvalidToken) ||
+		errors.Is(err, ErrInvalidApiKey)
+}
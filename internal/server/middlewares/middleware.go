@@ -2,42 +2,30 @@ package token
 
 import (
 	"net/http"
-	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 )
 
-func Middleware() echo.MiddlewareFunc {
+func Middleware(authenticator *Authenticator) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			tokenHeader := c.Request().Header.Get("Authorization")
-			if tokenHeader == "" {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Token is required")
-			}
+			request := c.Request()
 
-			tokenValue := tokenHeader[7:]
-			token, _, err := new(jwt.Parser).ParseUnverified(tokenValue, jwt.MapClaims{})
+			principal, err := authenticator.Authenticate(
+				request.Context(),
+				request.Header.Get(echo.HeaderAuthorization),
+				request.Header.Get(HeaderApiKey),
+			)
 			if err != nil {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
-			}
-
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if !ok {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
-			}
-
-			expiration := claims["exp"].(float64)
-			if expiration < float64(time.Now().Unix()) {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
-			}
+				if !IsUnauthenticated(err) {
+					return err
+				}
 
-			userId, ok := claims["sub"].(string)
-			if !ok {
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, bearerScheme)
+				return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 			}
 
-			c.Set("userId", userId)
+			c.SetRequest(request.WithContext(WithPrincipal(request.Context(), principal)))
 
 			return next(c)
 		}
@@ -9,9 +9,16 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
 	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/authenticate"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func generateToken(t *testing.T, userId string, expire time.Duration) string {
@@ -34,6 +41,16 @@ func generateToken(t *testing.T, userId string, expire time.Duration) string {
 	return fmt.Sprintf("Bearer %s", tokenString)
 }
 
+func newAuthenticator(apiKeys *mocks.MockAuthenticateApiKeyService[authenticate.AuthenticateApiKeyInput]) *token.Authenticator {
+	verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, nil)
+
+	if apiKeys == nil {
+		return token.NewAuthenticator(verifier, nil, "")
+	}
+
+	return token.NewAuthenticator(verifier, apiKeys, "store_1")
+}
+
 func TestMiddleware(t *testing.T) {
 	t.Run("Should authorize when token is valid", func(t *testing.T) {
 		// Arrange
@@ -44,10 +61,9 @@ func TestMiddleware(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		e := echo.New()
-		e.Use(token.Middleware())
+		e.Use(token.Middleware(newAuthenticator(nil)))
 		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+			return c.String(http.StatusOK, token.UserIdFromContext(c.Request().Context()))
 		})
 
 		// Act
@@ -65,10 +81,9 @@ func TestMiddleware(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		e := echo.New()
-		e.Use(token.Middleware())
+		e.Use(token.Middleware(newAuthenticator(nil)))
 		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+			return c.String(http.StatusOK, token.UserIdFromContext(c.Request().Context()))
 		})
 
 		// Act
@@ -76,6 +91,7 @@ func TestMiddleware(t *testing.T) {
 
 		// Assert
 		assert.Equal(t, http.StatusUnauthorized, res.Code)
+		assert.Equal(t, "Bearer", res.Header().Get("WWW-Authenticate"))
 	})
 
 	t.Run("Should not authorize when token is missing", func(t *testing.T) {
@@ -84,10 +100,9 @@ func TestMiddleware(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		e := echo.New()
-		e.Use(token.Middleware())
+		e.Use(token.Middleware(newAuthenticator(nil)))
 		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+			return c.String(http.StatusOK, token.UserIdFromContext(c.Request().Context()))
 		})
 
 		// Act
@@ -106,10 +121,9 @@ func TestMiddleware(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		e := echo.New()
-		e.Use(token.Middleware())
+		e.Use(token.Middleware(newAuthenticator(nil)))
 		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+			return c.String(http.StatusOK, token.UserIdFromContext(c.Request().Context()))
 		})
 
 		// Act
@@ -126,10 +140,9 @@ func TestMiddleware(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		e := echo.New()
-		e.Use(token.Middleware())
+		e.Use(token.Middleware(newAuthenticator(nil)))
 		e.GET("/", func(c echo.Context) error {
-			userId := c.Get("userId").(string)
-			return c.String(http.StatusOK, userId)
+			return c.String(http.StatusOK, token.UserIdFromContext(c.Request().Context()))
 		})
 
 		// Act
@@ -138,4 +151,87 @@ func TestMiddleware(t *testing.T) {
 		// Assert
 		assert.Equal(t, http.StatusUnauthorized, res.Code)
 	})
+
+	t.Run("Should authorize when API key is valid", func(t *testing.T) {
+		// Arrange
+		apiKeys := mocks.NewMockAuthenticateApiKeyService[authenticate.AuthenticateApiKeyInput](t)
+
+		apiKeys.On("Handle", mock.Anything, authenticate.AuthenticateApiKeyInput{Key: "pmk_abc_secret"}).
+			Return(&api_key_entity.ApiKey{Id: "key-id", Scopes: []string{"service"}}, nil).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		req.Header.Set("X-Api-Key", "pmk_abc_secret")
+		res := httptest.NewRecorder()
+
+		e := echo.New()
+		e.Use(token.Middleware(newAuthenticator(apiKeys)))
+		e.GET("/", func(c echo.Context) error {
+			ctx := c.Request().Context()
+			assert.True(t, authorization.HasRole(ctx, authorization.ServiceRole))
+			assert.Equal(t, "store_1", authorization.StoreFromContext(ctx))
+			return c.String(http.StatusOK, token.UserIdFromContext(ctx))
+		})
+
+		// Act
+		e.ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "apikey:key-id", res.Body.String())
+		apiKeys.AssertExpectations(t)
+	})
+
+	t.Run("Should not authorize when API key is not valid", func(t *testing.T) {
+		// Arrange
+		apiKeys := mocks.NewMockAuthenticateApiKeyService[authenticate.AuthenticateApiKeyInput](t)
+
+		apiKeys.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, custom_error.ErrApiKeyNotValid).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		req.Header.Set("Authorization", generateToken(t, uuid.NewString(), time.Minute*1))
+		req.Header.Set("X-Api-Key", "pmk_abc_secret")
+		res := httptest.NewRecorder()
+
+		e := echo.New()
+		e.Use(token.Middleware(newAuthenticator(apiKeys)))
+		e.GET("/", func(c echo.Context) error {
+			return c.String(http.StatusOK, token.UserIdFromContext(c.Request().Context()))
+		})
+
+		// Act
+		e.ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+		apiKeys.AssertExpectations(t)
+	})
+
+	t.Run("Should return internal server error when API key cannot be read", func(t *testing.T) {
+		// Arrange
+		apiKeys := mocks.NewMockAuthenticateApiKeyService[authenticate.AuthenticateApiKeyInput](t)
+
+		apiKeys.On("Handle", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		req.Header.Set("X-Api-Key", "pmk_abc_secret")
+		res := httptest.NewRecorder()
+
+		e := echo.New()
+		e.Use(token.Middleware(newAuthenticator(apiKeys)))
+		e.GET("/", func(c echo.Context) error {
+			return c.String(http.StatusOK, token.UserIdFromContext(c.Request().Context()))
+		})
+
+		// Act
+		e.ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		apiKeys.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,127 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+)
+
+// ApiKeySubjectPrefix starts the subject of the principals authenticated by
+// an API key, followed by the id of the key
+const ApiKeySubjectPrefix = "apikey:"
+
+// AuthMethod is how the principal was authenticated
+type AuthMethod string
+
+const (
+	TokenAuth  AuthMethod = "token"
+	ApiKeyAuth AuthMethod = "api_key"
+)
+
+// Principal is the authenticated user of the request
+type Principal struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	Roles     []authorization.Role
+	// StoreId is the store the principal has access to, empty when it is not
+	// scoped to a store
+	StoreId string
+	// Method is set by the authenticator, unlike the subject it can not be
+	// chosen by the issuer of a token
+	Method   AuthMethod
+	ApiKeyId string
+}
+
+func NewPrincipal(claims jwt.RegisteredClaims) Principal {
+	principal := Principal{
+		Subject:  claims.Subject,
+		Issuer:   claims.Issuer,
+		Audience: claims.Audience,
+		Method:   TokenAuth,
+	}
+
+	if claims.ExpiresAt != nil {
+		principal.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	return principal
+}
+
+// NewApiKeyPrincipal grants the scopes of the key as roles
+func NewApiKeyPrincipal(apiKey api_key_entity.ApiKey) Principal {
+	principal := Principal{
+		Subject:  ApiKeySubjectPrefix + apiKey.Id,
+		Roles:    make([]authorization.Role, 0, len(apiKey.Scopes)),
+		Method:   ApiKeyAuth,
+		ApiKeyId: apiKey.Id,
+	}
+
+	for _, scope := range apiKey.Scopes {
+		principal.Roles = append(principal.Roles, authorization.Role(scope))
+	}
+
+	if apiKey.ExpiresAt != nil {
+		principal.ExpiresAt = *apiKey.ExpiresAt
+	}
+
+	return principal
+}
+
+// AuditActor returns the principal as the author of the changes in the audit
+// log, the API keys are identified by their id
+func (p Principal) AuditActor() audit_entity.Actor {
+	if p.Method == ApiKeyAuth {
+		return audit_entity.Actor{Type: audit_entity.ApiKeyActor, Id: p.ApiKeyId}
+	}
+
+	return audit_entity.Actor{Type: audit_entity.UserActor, Id: p.Subject}
+}
+
+// EventActor returns the principal as the author of the published order
+// events, the API keys are services identified by their id
+func (p Principal) EventActor() cloud.EventActor {
+	if p.Method == ApiKeyAuth {
+		return cloud.NewServiceActor(p.ApiKeyId)
+	}
+
+	return cloud.NewUserActor(p.Subject)
+}
+
+type principalKey struct{}
+
+// WithPrincipal stores the principal in the context, along with its roles and
+// store for the authorization of the services and the actor of the audit log
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	ctx = authorization.WithRoles(ctx, principal.Roles...)
+	ctx = authorization.WithStore(ctx, principal.StoreId)
+	ctx = audit.WithActor(ctx, principal.AuditActor())
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal of the authenticated request
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// UserIdFromContext returns the subject of the principal, empty when the
+// request was not authenticated
+func UserIdFromContext(ctx context.Context) string {
+	principal, _ := PrincipalFromContext(ctx)
+	return principal.Subject
+}
+
+// EventActorFromContext returns the author of the order events published by
+// the request
+func EventActorFromContext(ctx context.Context) cloud.EventActor {
+	principal, _ := PrincipalFromContext(ctx)
+	return principal.EventActor()
+}
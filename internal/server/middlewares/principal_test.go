@@ -0,0 +1,71 @@
+package token_test
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditActor(t *testing.T) {
+	t.Run("Should return the API key as the actor when authenticated by a key", func(t *testing.T) {
+		// Arrange
+		principal := token.NewApiKeyPrincipal(api_key_entity.ApiKey{Id: "key-id"})
+
+		// Act
+		actor := principal.AuditActor()
+
+		// Assert
+		assert.Equal(t, audit_entity.Actor{Type: audit_entity.ApiKeyActor, Id: "key-id"}, actor)
+	})
+
+	t.Run("Should return the user as the actor when authenticated by a token", func(t *testing.T) {
+		// Arrange
+		principal := token.NewPrincipal(jwt.RegisteredClaims{Subject: "user-id"})
+
+		// Act
+		actor := principal.AuditActor()
+
+		// Assert
+		assert.Equal(t, audit_entity.Actor{Type: audit_entity.UserActor, Id: "user-id"}, actor)
+	})
+
+	t.Run("Should return the user as the actor when the subject of a token looks like a key", func(t *testing.T) {
+		// Arrange
+		principal := token.NewPrincipal(jwt.RegisteredClaims{Subject: token.ApiKeySubjectPrefix + "key-id"})
+
+		// Act
+		actor := principal.AuditActor()
+
+		// Assert
+		assert.Equal(t, audit_entity.Actor{Type: audit_entity.UserActor, Id: "apikey:key-id"}, actor)
+	})
+}
+
+func TestEventActor(t *testing.T) {
+	t.Run("Should return the API key as a service when authenticated by a key", func(t *testing.T) {
+		// Arrange
+		principal := token.NewApiKeyPrincipal(api_key_entity.ApiKey{Id: "key-id"})
+
+		// Act
+		actor := principal.EventActor()
+
+		// Assert
+		assert.Equal(t, cloud.EventActor{Type: cloud.ServiceActorType, Id: "key-id"}, actor)
+	})
+
+	t.Run("Should return the user when authenticated by a token", func(t *testing.T) {
+		// Arrange
+		principal := token.NewPrincipal(jwt.RegisteredClaims{Subject: token.ApiKeySubjectPrefix + "key-id"})
+
+		// Act
+		actor := principal.EventActor()
+
+		// Assert
+		assert.Equal(t, cloud.EventActor{Type: cloud.UserActorType, Id: "apikey:key-id"}, actor)
+	})
+}
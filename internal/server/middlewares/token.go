@@ -0,0 +1,196 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+)
+
+var (
+	ErrTokenRequired = errors.New("Token is required")
+	ErrInvalidToken  = errors.New("Invalid token")
+)
+
+const bearerScheme = "Bearer"
+
+var (
+	hmacMethods       = []string{"HS256", "HS384", "HS512"}
+	asymmetricMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+)
+
+// KeySet returns the public key that signed the tokens, by the key id
+type KeySet interface {
+	Key(ctx context.Context, kid string) (interface{}, error)
+}
+
+// Verifier checks the signature and the claims of the bearer tokens, it is
+// shared by the HTTP and the gRPC servers
+type Verifier struct {
+	secret     []byte
+	keySet     KeySet
+	rolesClaim string
+	storeClaim string
+	options    []jwt.ParserOption
+}
+
+// NewVerifier accepts the tokens signed with the secret (HMAC) and, when the
+// key set is not nil, the ones signed with its keys (RSA or ECDSA)
+func NewVerifier(config *environment.AuthConfig, keySet KeySet) *Verifier {
+	methods := make([]string, 0, len(hmacMethods)+len(asymmetricMethods))
+
+	if config.Secret != "" {
+		methods = append(methods, hmacMethods...)
+	}
+
+	if keySet != nil {
+		methods = append(methods, asymmetricMethods...)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+	}
+
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &Verifier{
+		secret:     []byte(config.Secret),
+		keySet:     keySet,
+		rolesClaim: config.RolesClaim,
+		storeClaim: config.StoreClaim,
+		options:    options,
+	}
+}
+
+// Verify returns the principal of the bearer token in the authorization header
+func (v *Verifier) Verify(ctx context.Context, header string) (Principal, error) {
+	if header == "" {
+		return Principal{}, ErrTokenRequired
+	}
+
+	tokenValue, err := parseBearer(header)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	var claims tokenClaims
+
+	_, err = jwt.ParseWithClaims(tokenValue, &claims, func(token *jwt.Token) (interface{}, error) {
+		return v.key(ctx, token)
+	}, v.options...)
+	if err != nil {
+		return Principal{}, ErrInvalidToken
+	}
+
+	if claims.Subject == "" {
+		return Principal{}, ErrInvalidToken
+	}
+
+	principal := NewPrincipal(claims.RegisteredClaims)
+	principal.Roles = claims.roles(v.rolesClaim)
+	principal.StoreId = claims.store(v.storeClaim)
+
+	return principal, nil
+}
+
+func (v *Verifier) key(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		return v.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	return v.keySet.Key(ctx, kid)
+}
+
+// parseBearer returns the token of the header, the scheme is case insensitive
+// and must be followed by a single space and the token
+func parseBearer(header string) (string, error) {
+	scheme, tokenValue, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) {
+		return "", ErrInvalidToken
+	}
+
+	if tokenValue == "" || strings.ContainsAny(tokenValue, " \t") {
+		return "", ErrInvalidToken
+	}
+
+	return tokenValue, nil
+}
+
+// tokenClaims keeps every claim of the token besides the registered ones, so
+// the roles and the store can be read from the configured claims
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	raw map[string]interface{}
+}
+
+func (c *tokenClaims) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &c.RegisteredClaims); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, &c.raw)
+}
+
+// value reads the claim, which may be nested with a dotted path, nil when
+// the token does not have it
+func (c *tokenClaims) value(claim string) interface{} {
+	if claim == "" {
+		return nil
+	}
+
+	var value interface{} = c.raw
+	for _, key := range strings.Split(claim, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+
+	return value
+}
+
+// store reads the claim as a string, empty when the user is not scoped to a
+// store
+func (c *tokenClaims) store(claim string) string {
+	storeId, _ := c.value(claim).(string)
+	return storeId
+}
+
+// roles reads the claim as a list of strings or as a string with the roles
+// separated by spaces
+func (c *tokenClaims) roles(claim string) []authorization.Role {
+	var names []string
+
+	switch value := c.value(claim).(type) {
+	case string:
+		names = strings.Fields(value)
+	case []interface{}:
+		for _, item := range value {
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	roles := make([]authorization.Role, 0, len(names))
+	for _, name := range names {
+		roles = append(roles, authorization.Role(name))
+	}
+
+	return roles
+}
@@ -0,0 +1,388 @@
+package token_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeKeySet map[string]interface{}
+
+func (f fakeKeySet) Key(ctx context.Context, kid string) (interface{}, error) {
+	key, ok := f[kid]
+	if !ok {
+		return nil, errors.New("key not found")
+	}
+
+	return key, nil
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	jwtToken := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		jwtToken.Header["kid"] = kid
+	}
+
+	tokenString, err := jwtToken.SignedString(key)
+	assert.NoError(t, err)
+
+	return fmt.Sprintf("Bearer %s", tokenString)
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	keySet := fakeKeySet{
+		"rsa-1": &rsaKey.PublicKey,
+		"ec-1":  &ecKey.PublicKey,
+	}
+
+	t.Run("Should return the principal of a token signed with the secret", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, nil)
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", validClaims())
+
+		// Act
+		principal, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+		assert.False(t, principal.ExpiresAt.IsZero())
+	})
+
+	t.Run("Should return the principal of a token signed with a RSA key of the key set", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{}, keySet)
+		header := sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", validClaims())
+
+		// Act
+		principal, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+	})
+
+	t.Run("Should return the principal of a token signed with an ECDSA key of the key set", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{}, keySet)
+		header := sign(t, jwt.SigningMethodES256, ecKey, "ec-1", validClaims())
+
+		// Act
+		principal, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+	})
+
+	t.Run("Should return error when the key is not in the key set", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{}, keySet)
+		header := sign(t, jwt.SigningMethodRS256, rsaKey, "unknown", validClaims())
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should return error when the token is signed with RSA and there is no key set", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, nil)
+		header := sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", validClaims())
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should return error when the token is signed with HMAC and there is no secret", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{}, keySet)
+		header := sign(t, jwt.SigningMethodHS256, []byte(""), "", validClaims())
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should return error when the token is not signed", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, keySet)
+		header := sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims())
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should return error when the secret is wrong", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, nil)
+		header := sign(t, jwt.SigningMethodHS256, []byte("other-secret"), "", validClaims())
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should return error when the token has no expiration", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, nil)
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{"sub": "user-1"})
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should return error when the token has no subject", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, nil)
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should accept an expired token within the leeway", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret", Leeway: time.Minute}, nil)
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub": "user-1",
+			"exp": time.Now().Add(-10 * time.Second).Unix(),
+		})
+
+		// Act
+		principal, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+	})
+
+	t.Run("Should return error when the token expired beyond the leeway", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret", Leeway: time.Second}, nil)
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", jwt.MapClaims{
+			"sub": "user-1",
+			"exp": time.Now().Add(-time.Minute).Unix(),
+		})
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should validate the issuer and the audience when configured", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{
+			Secret:   "my-secret",
+			Issuer:   "https://auth.example.com",
+			Audience: "production",
+		}, nil)
+
+		claims := validClaims()
+		claims["iss"] = "https://auth.example.com"
+		claims["aud"] = "production"
+
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", claims)
+
+		// Act
+		principal, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "https://auth.example.com", principal.Issuer)
+		assert.Equal(t, []string{"production"}, principal.Audience)
+	})
+
+	t.Run("Should return error when the issuer does not match", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret", Issuer: "https://auth.example.com"}, nil)
+
+		claims := validClaims()
+		claims["iss"] = "https://other.example.com"
+
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", claims)
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should return error when the audience does not match", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret", Audience: "production"}, nil)
+
+		claims := validClaims()
+		claims["aud"] = "billing"
+
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", claims)
+
+		// Act
+		_, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrInvalidToken)
+	})
+
+	t.Run("Should return the roles of the roles claim", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret", RolesClaim: "roles"}, nil)
+
+		claims := validClaims()
+		claims["roles"] = []string{"kitchen", "counter"}
+
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", claims)
+
+		// Act
+		principal, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []authorization.Role{authorization.KitchenRole, authorization.CounterRole}, principal.Roles)
+	})
+
+	t.Run("Should return the roles of a nested claim separated by spaces", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret", RolesClaim: "realm_access.roles"}, nil)
+
+		claims := validClaims()
+		claims["realm_access"] = map[string]interface{}{"roles": "manager service"}
+
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", claims)
+
+		// Act
+		principal, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []authorization.Role{authorization.ManagerRole, authorization.ServiceRole}, principal.Roles)
+	})
+
+	t.Run("Should return no roles when the token does not have the claim", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret", RolesClaim: "roles"}, nil)
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", validClaims())
+
+		// Act
+		principal, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, principal.Roles)
+	})
+
+	t.Run("Should return the store of the store claim", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret", StoreClaim: "store_id"}, nil)
+
+		claims := validClaims()
+		claims["store_id"] = "store_1"
+
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", claims)
+
+		// Act
+		principal, err := verifier.Verify(ctx, header)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "store_1", principal.StoreId)
+	})
+
+	t.Run("Should return error when the header is empty", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, nil)
+
+		// Act
+		_, err := verifier.Verify(ctx, "")
+
+		// Assert
+		assert.ErrorIs(t, err, token.ErrTokenRequired)
+	})
+
+	t.Run("Should accept the scheme in any case", func(t *testing.T) {
+		// Arrange
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, nil)
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", validClaims())
+
+		// Act
+		principal, err := verifier.Verify(ctx, "bearer"+header[len("Bearer"):])
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+	})
+
+	t.Run("Should return error when the header is malformed", func(t *testing.T) {
+		verifier := token.NewVerifier(&environment.AuthConfig{Secret: "my-secret"}, nil)
+		header := sign(t, jwt.SigningMethodHS256, []byte("my-secret"), "", validClaims())
+		tokenValue := header[len("Bearer "):]
+
+		malformed := []string{
+			"Bearer",
+			"Bearer ",
+			"Bearer  " + tokenValue,
+			"Bearer " + tokenValue + " extra",
+			"Basic " + tokenValue,
+			tokenValue,
+		}
+
+		for _, header := range malformed {
+			// Arrange
+			// Act
+			_, err := verifier.Verify(ctx, header)
+
+			// Assert
+			assert.ErrorIs(t, err, token.ErrInvalidToken, header)
+		}
+	})
+}
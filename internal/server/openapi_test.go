@@ -0,0 +1,82 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var routeParam = regexp.MustCompile(`:([^/]+)`)
+
+// undocumentedRoutes are served by the server but are not part of the API
+var undocumentedRoutes = map[string]bool{
+	"/openapi.yaml": true,
+	"/openapi.json": true,
+	"/docs":         true,
+}
+
+func TestOpenApiSpecification(t *testing.T) {
+	t.Run("Should document every registered route and nothing else", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port:       8080,
+				ApiVersion: "v1",
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig: &environment.CloudConfig{
+				OrderProductionQueue: "order-production-queue",
+				UpdateOrderTopic:     "update-order-topic",
+			},
+			WebhookConfig:     &environment.WebhookConfig{},
+			PickupBoardConfig: &environment.PickupBoardConfig{},
+			OrderCacheConfig:  &environment.OrderCacheConfig{},
+			OrderStreamConfig: &environment.OrderStreamConfig{},
+			PrinterConfig:     &environment.PrinterConfig{},
+			AuthConfig:        &environment.AuthConfig{Secret: "my-secret"},
+			ApiKeyConfig:      &environment.ApiKeyConfig{},
+			HealthConfig:      &environment.HealthConfig{},
+			LogConfig:         &environment.LogConfig{},
+		}
+
+		server := NewServer(config)
+		server.DeadLetterQueueService = mocks.NewMockDeadLetterQueueService(t)
+
+		e := server.RegisterRoutes().(*echo.Echo)
+
+		documented, err := openapi.Operations()
+		assert.NoError(t, err)
+
+		// Act
+		registered := make([]openapi.Operation, 0)
+		for _, route := range e.Routes() {
+			if route.Method == echo.RouteNotFound || undocumentedRoutes[route.Path] {
+				continue
+			}
+			if route.Method == http.MethodHead || route.Method == http.MethodOptions {
+				continue
+			}
+
+			registered = append(registered, openapi.Operation{
+				Method: route.Method,
+				Path:   routeParam.ReplaceAllString(route.Path, "{$1}"),
+			})
+		}
+
+		// Assert
+		for _, operation := range registered {
+			assert.Contains(t, documented, operation, "route %s is not documented in the OpenAPI specification", operation)
+		}
+		for _, operation := range documented {
+			assert.Contains(t, registered, operation, "operation %s of the OpenAPI specification has no route", operation)
+		}
+	})
+}
@@ -4,27 +4,95 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cache"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud/dead_letter"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/database"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/jwks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/metrics"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/printer"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/stream"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/tracing"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/webhook"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/grpc_server"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/api_key_create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/api_key_list"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/api_key_revoke"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/api_key_rotate"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/audit_list"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/bulk_update"
+	create_handler "github.com/jfelipearaujo-org/ms-production-management/internal/handler/create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/dead_letter_list"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/dead_letter_redrive"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/dead_letter_replay"
+	export_handler "github.com/jfelipearaujo-org/ms-production-management/internal/handler/export"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/get_by_id"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/get_by_state"
-	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/health"
+	health_handler "github.com/jfelipearaujo-org/ms-production-management/internal/handler/health"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/log_level_get"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/log_level_update"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/openapi_spec"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/openapi_ui"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/pickup_board"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/pickup_board_page"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/schema_get"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/schema_list"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/state_machine"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/stream_sse"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/stream_ws"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/ticket"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/update"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/webhook_create"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/webhook_delete"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/webhook_deliveries"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/webhook_list"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/handler/webhook_update"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/provider/time_provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	api_key_repository "github.com/jfelipearaujo-org/ms-production-management/internal/repository/api_key"
+	audit_repository "github.com/jfelipearaujo-org/ms-production-management/internal/repository/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/order_event"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/order_production"
+	webhook_repository "github.com/jfelipearaujo-org/ms-production-management/internal/repository/webhook"
 	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service"
+	api_key_authenticate_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/authenticate"
+	api_key_create_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/create"
+	api_key_list_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/list"
+	api_key_revoke_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/revoke"
+	api_key_rotate_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/rotate"
+	audit_list_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/audit/list"
+	bulk_update_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/bulk_update"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/create"
+	export_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/export"
 	get_by_id_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_id"
 	get_by_state_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_by_state"
+	get_pickup_board_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/get_pickup_board"
 	update_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/order_production/update"
+	webhook_create_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/create"
+	webhook_list_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/list"
+	webhook_list_deliveries_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/list_deliveries"
+	webhook_remove_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/remove"
+	webhook_update_service "github.com/jfelipearaujo-org/ms-production-management/internal/service/webhook/update"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/health"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/logger"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/problem"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"google.golang.org/grpc"
+	grpc_health "google.golang.org/grpc/health"
 )
 
 type Server struct {
@@ -32,6 +100,17 @@ type Server struct {
 	DatabaseService         database.DatabaseService
 	QueueService            cloud.QueueService
 	UpdateOrderTopicService cloud.TopicService
+	DeadLetterQueueService  dead_letter.DeadLetterQueueService
+	WebhookDispatcher       webhook.Dispatcher
+	OrderStreamHub          *stream.Hub
+	OrderStreamListener     stream.Listener
+	OrderEventPruner        stream.Pruner
+	GrpcHealthServer        *grpc_health.Server
+	AutoPrintService        *printer.AutoPrintService
+	Authenticator           *token.Authenticator
+	Policy                  *authorization.Policy
+	MetricsRegistry         *prometheus.Registry
+	HealthRegistry          *health.Registry
 
 	Dependency Dependency
 }
@@ -50,31 +129,177 @@ func NewServer(config *environment.Config) *Server {
 
 	databaseService := database.NewDatabase(config)
 
+	policy, err := authorization.LoadPolicy(config.AuthConfig.PolicyFile)
+	if err != nil {
+		panic(err)
+	}
+
 	timeProvider := time_provider.NewTimeProvider(time.Now)
 	orderProductionRepository := order_production.NewOrderProductionRepository(databaseService.GetInstance())
 
-	createOrderProductionService := create.NewService(orderProductionRepository, timeProvider)
+	// only the reads by id of the API go through the cache, the services that
+	// change the orders always read the current state, so a stale order is
+	// never overwritten. The orders changed by any replica are evicted by the
+	// order stream listener
+	var orderProductionReadRepository repository.OrderProductionRepository = orderProductionRepository
+	var orderCacheEvicter stream.Evicter
+	if config.OrderCacheConfig.Enabled {
+		cachedRepository := order_production.NewCachedOrderProductionRepository(
+			orderProductionRepository,
+			cache.NewLRU[order_entity.Order](config.OrderCacheConfig.Size, config.OrderCacheConfig.Ttl, timeProvider),
+		)
+		orderProductionReadRepository = cachedRepository
+		orderCacheEvicter = cachedRepository
+	}
+	webhookRepository := webhook_repository.NewWebhookRepository(databaseService.GetInstance())
+	orderEventRepository := order_event.NewOrderEventRepository(databaseService.GetInstance())
+	apiKeyRepository := api_key_repository.NewApiKeyRepository(databaseService.GetInstance())
+	auditRepository := audit_repository.NewAuditRepository(databaseService.GetInstance())
 
-	updateOrderTopicService := cloud.NewUpdateOrderTopicService(config.CloudConfig.UpdateOrderTopic, cloudConfig)
+	// the changes and their audit entries are saved in the same transaction
+	transactor := repository.NewTransactor(databaseService.GetInstance())
+	recorder := audit.NewRecorder(auditRepository, timeProvider)
 
-	return &Server{
-		Config:          config,
-		DatabaseService: databaseService,
-		QueueService: cloud.NewQueueService(
+	orderStreamHub := stream.NewHub()
+
+	createOrderProductionService := create.NewService(orderProductionRepository, transactor, timeProvider, policy, recorder)
+
+	webhookDispatcher := webhook.NewDispatcher(webhookRepository, timeProvider, config.WebhookConfig)
+
+	updateOrderTopicService := webhook.NewTopicService(
+		cloud.NewUpdateOrderTopicService(
+			config.CloudConfig.UpdateOrderTopic,
+			config.CloudConfig.UpdateOrderTopicFifo,
+			config.CloudConfig.UpdateOrderEventFormat,
+			config.ApiConfig.StoreId,
+			cloudConfig,
+		),
+		webhookDispatcher,
+	)
+
+	// only the orders received through the queue are printed, the queue
+	// consumer creates them through the auto print service when enabled
+	queueOrderProductionService := service.CreateOrderProductionService[create.CreateOrderProductionInput](createOrderProductionService)
+
+	var autoPrintService *printer.AutoPrintService
+
+	if config.PrinterConfig.IsAutoPrintEnabled() {
+		autoPrintService = printer.NewAutoPrintService(
+			createOrderProductionService,
+			printer.NewNetworkPrinter(config.PrinterConfig.Address, config.PrinterConfig.Timeout),
+			config.ApiConfig.StoreId,
+			config.PrinterConfig.Width,
+		)
+		queueOrderProductionService = autoPrintService
+	}
+
+	var keySet token.KeySet
+
+	if config.AuthConfig.IsJwksSet() {
+		keySet = jwks.NewKeySet(
+			config.AuthConfig.JwksUrl,
+			config.AuthConfig.JwksRefreshInterval,
+			timeProvider,
+		)
+	}
+
+	authenticateApiKeyService := api_key_authenticate_service.NewService(apiKeyRepository, timeProvider, config.ApiKeyConfig.LastUsedPrecision)
+
+	var deadLetterQueueService dead_letter.DeadLetterQueueService
+
+	if config.CloudConfig.IsDeadLetterQueueSet() {
+		deadLetterQueueService = dead_letter.NewDeadLetterQueueService(
+			config.CloudConfig.OrderProductionDLQ,
 			config.CloudConfig.OrderProductionQueue,
 			cloudConfig,
 			createOrderProductionService,
 			updateOrderTopicService,
-		),
+			recorder,
+		)
+	}
+
+	queueService := cloud.NewQueueService(
+		config.CloudConfig.OrderProductionQueue,
+		cloudConfig,
+		queueOrderProductionService,
+		updateOrderTopicService,
+		deadLetterQueueService,
+	)
+
+	healthRegistry := health.NewRegistry(timeProvider, config.HealthConfig.Timeout, config.HealthConfig.CacheTtl)
+	healthRegistry.Register(health.Check{Name: "database", HealthCheck: databaseService, Critical: true})
+	healthRegistry.Register(health.Check{Name: "queue", HealthCheck: queueService, Critical: true})
+	healthRegistry.Register(health.Check{Name: "topic", HealthCheck: updateOrderTopicService, Critical: true})
+	// the secret is only read at the startup, the service keeps working
+	// without access to it
+	healthRegistry.Register(health.Check{
+		Name:        "secrets",
+		HealthCheck: cloud.NewSecretService(cloudConfig).HealthCheck(config.DbConfig.UrlSecretName),
+	})
+	healthRegistry.Register(health.Check{
+		Name:        "queue_consumer",
+		HealthCheck: cloud.NewConsumerHealthCheck(queueService, timeProvider, config.HealthConfig.ConsumerStallTimeout),
+		Critical:    true,
+		Probes:      []health.Probe{health.LivenessProbe},
+	})
+
+	metricsRegistry := metrics.NewRegistry(
+		collectors.NewDBStatsCollector(databaseService.GetInstance(), "orders"),
+		metrics.NewOrderStateCollector(orderProductionRepository),
+		metrics.NewQueueDepthCollector(queueService),
+	)
+
+	return &Server{
+		Config:                  config,
+		DatabaseService:         databaseService,
+		QueueService:            queueService,
 		UpdateOrderTopicService: updateOrderTopicService,
+		DeadLetterQueueService:  deadLetterQueueService,
+		WebhookDispatcher:       webhookDispatcher,
+		OrderStreamHub:          orderStreamHub,
+		OrderStreamListener:     stream.NewPostgresListener(config.DbConfig.Url, orderEventRepository, orderStreamHub, orderCacheEvicter),
+		OrderEventPruner:        stream.NewEventPruner(orderEventRepository, config.OrderStreamConfig.Retention, config.OrderStreamConfig.PruneInterval),
+		GrpcHealthServer:        grpc_health.NewServer(),
+		AutoPrintService:        autoPrintService,
+		Authenticator:           token.NewAuthenticator(token.NewVerifier(config.AuthConfig, keySet), authenticateApiKeyService, config.ApiConfig.StoreId),
+		Policy:                  policy,
+		MetricsRegistry:         metricsRegistry,
+		HealthRegistry:          healthRegistry,
 		Dependency: Dependency{
 			TimeProvider: timeProvider,
 
-			OrderProductionRepository: orderProductionRepository,
+			OrderProductionRepository:     orderProductionRepository,
+			OrderProductionReadRepository: orderProductionReadRepository,
+			WebhookRepository:             webhookRepository,
+			OrderEventRepository:          orderEventRepository,
+			ApiKeyRepository:              apiKeyRepository,
+			AuditRepository:               auditRepository,
 
-			GetOrderProductionById:    get_by_id_service.NewService(orderProductionRepository),
+			AuditRecorder: recorder,
+
+			CreateOrderProduction:     createOrderProductionService,
+			GetOrderProductionById:    get_by_id_service.NewService(orderProductionReadRepository),
 			GetOrderProductionByState: get_by_state_service.NewService(orderProductionRepository),
-			UpdateOrderProduction:     update_service.NewService(orderProductionRepository, timeProvider),
+			UpdateOrderProduction:     update_service.NewService(orderProductionRepository, transactor, timeProvider, policy, recorder),
+			BulkUpdateOrderProduction: bulk_update_service.NewService(orderProductionRepository, transactor, timeProvider, policy, recorder),
+			GetPickupBoard:            get_pickup_board_service.NewService(orderProductionRepository, timeProvider, config.PickupBoardConfig.ReadyTtl),
+			ExportOrderProduction:     export_service.NewService(orderProductionRepository, config.ApiConfig.StoreId),
+
+			CreateWebhook:         webhook_create_service.NewService(webhookRepository, transactor, timeProvider, recorder),
+			ListWebhook:           webhook_list_service.NewService(webhookRepository),
+			UpdateWebhook:         webhook_update_service.NewService(webhookRepository, transactor, timeProvider, recorder),
+			DeleteWebhook:         webhook_remove_service.NewService(webhookRepository, transactor, recorder),
+			ListWebhookDeliveries: webhook_list_deliveries_service.NewService(webhookRepository),
+
+			CreateApiKey:       api_key_create_service.NewService(apiKeyRepository, transactor, timeProvider, recorder),
+			ListApiKey:         api_key_list_service.NewService(apiKeyRepository),
+			RotateApiKey:       api_key_rotate_service.NewService(apiKeyRepository, transactor, timeProvider, config.ApiKeyConfig.RotationOverlap, recorder),
+			RevokeApiKey:       api_key_revoke_service.NewService(apiKeyRepository, transactor, timeProvider, recorder),
+			AuthenticateApiKey: authenticateApiKeyService,
+
+			ListAudit: audit_list_service.NewService(auditRepository),
+
+			OrderStreamer: stream.NewOrderStreamer(orderEventRepository, orderStreamHub),
 
 			UpdateOrderTopicService: updateOrderTopicService,
 		},
@@ -82,42 +307,194 @@ func NewServer(config *environment.Config) *Server {
 }
 
 func (s *Server) GetHttpServer() *http.Server {
-	return &http.Server{
+	httpServer := &http.Server{
 		Addr:         fmt.Sprintf(":%d", s.Config.ApiConfig.Port),
 		Handler:      s.RegisterRoutes(),
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
+
+	if s.OrderStreamHub != nil {
+		httpServer.RegisterOnShutdown(s.OrderStreamHub.Close)
+	}
+
+	return httpServer
+}
+
+// GetGrpcServer returns the gRPC server, it reuses the services of the REST API
+// so both apply the same business rules
+func (s *Server) GetGrpcServer() *grpc.Server {
+	productionServer := grpc_server.NewServer(
+		s.Dependency.GetOrderProductionById,
+		s.Dependency.GetOrderProductionByState,
+		s.Dependency.UpdateOrderProduction,
+		s.Dependency.UpdateOrderTopicService,
+		s.Dependency.OrderStreamer,
+	)
+
+	return grpc_server.NewGrpcServer(productionServer, s.GrpcHealthServer, s.Authenticator, s.Policy)
 }
 
 func (s *Server) RegisterRoutes() http.Handler {
 	e := echo.New()
-	e.Use(logger.Middleware())
+	e.HTTPErrorHandler = problem.ErrorHandler(s.Config.ApiConfig.IsDevelopment())
+	e.Use(metrics.Middleware())
+	e.Use(tracing.Middleware())
+	e.Use(correlation.Middleware())
+	e.Use(logger.Middleware(s.Config.LogConfig))
+	e.Use(audit.Middleware())
 	e.Use(middleware.Recover())
 
 	s.registerHealthCheck(e)
+	s.registerMetricsHandler(e)
+	s.registerDocsHandlers(e)
 
-	group := e.Group(fmt.Sprintf("/api/%s", s.Config.ApiConfig.ApiVersion))
+	// the versioned routes share the authentication and the authorization,
+	// the subgroups must not add them again
+	group := e.Group(s.apiPrefix(), token.Middleware(s.Authenticator), authorization.Middleware(s.Policy, s.apiPrefix()))
 
+	s.registerSchemaHandlers(e)
+	s.registerPickupBoardHandlers(e)
 	s.registerOrderProductionHandlers(group)
+	s.registerAdminHandlers(group)
 
 	return e
 }
 
+// apiPrefix is the prefix of the versioned routes, the authorization policy
+// refers to the routes without it
+func (s *Server) apiPrefix() string {
+	return fmt.Sprintf("/api/%s", s.Config.ApiConfig.ApiVersion)
+}
+
+// registerHealthCheck exposes the probes without authentication, /health is
+// kept as an alias of the readiness for the existing clients
 func (server *Server) registerHealthCheck(e *echo.Echo) {
-	healthHandler := health.NewHandler(server.DatabaseService)
+	livenessHandler := health_handler.NewHandler(server.HealthRegistry, health.LivenessProbe)
+	readinessHandler := health_handler.NewHandler(server.HealthRegistry, health.ReadinessProbe)
+
+	e.GET("/health", readinessHandler.Handle)
+	e.GET("/health/live", livenessHandler.Handle)
+	e.GET("/health/ready", readinessHandler.Handle)
+}
+
+// registerMetricsHandler exposes the metrics to Prometheus without
+// authentication, like the health check
+func (s *Server) registerMetricsHandler(e *echo.Echo) {
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler(s.MetricsRegistry)))
+}
+
+// registerDocsHandlers exposes the OpenAPI specification and the Swagger UI
+// without authentication, the protected endpoints still require the token
+func (s *Server) registerDocsHandlers(e *echo.Echo) {
+	specYamlHandler := openapi_spec.NewHandler(openapi_spec.FormatYAML)
+	specJsonHandler := openapi_spec.NewHandler(openapi_spec.FormatJSON)
+	uiHandler := openapi_ui.NewHandler("/openapi.json")
+
+	e.GET("/openapi.yaml", specYamlHandler.Handle)
+	e.GET("/openapi.json", specJsonHandler.Handle)
+	e.GET("/docs", uiHandler.Handle)
+}
+
+// registerSchemaHandlers exposes the message schemas without authentication so
+// partner teams can generate their clients
+func (s *Server) registerSchemaHandlers(e *echo.Echo) {
+	listSchemaHandler := schema_list.NewHandler()
+	getSchemaHandler := schema_get.NewHandler()
+
+	schemas := e.Group(fmt.Sprintf("/api/%s/schemas", s.Config.ApiConfig.ApiVersion))
+	schemas.GET("", listSchemaHandler.Handle)
+	schemas.GET("/:name", getSchemaHandler.Handle)
+}
 
-	e.GET("/health", healthHandler.Handle)
+// registerPickupBoardHandlers exposes the order codes being prepared and ready
+// without authentication, for the screens at the counter
+func (s *Server) registerPickupBoardHandlers(e *echo.Echo) {
+	pickupBoardHandler := pickup_board.NewHandler(s.Dependency.GetPickupBoard, s.Config.PickupBoardConfig.MaxAge)
+	pickupBoardPageHandler := pickup_board_page.NewHandler(s.Dependency.GetPickupBoard, s.Config.PickupBoardConfig.MaxAge)
+
+	e.GET(fmt.Sprintf("/api/%s/pickup-board", s.Config.ApiConfig.ApiVersion), pickupBoardHandler.Handle)
+	e.GET("/pickup-board", pickupBoardPageHandler.Handle)
 }
 
 func (s *Server) registerOrderProductionHandlers(e *echo.Group) {
+	createOrderProductionHandler := create_handler.NewHandler(s.Dependency.CreateOrderProduction, s.Dependency.UpdateOrderTopicService)
 	getOrderProductionByIdHandler := get_by_id.NewHandler(s.Dependency.GetOrderProductionById)
 	getOrderProductionByStateHandler := get_by_state.NewHandler(s.Dependency.GetOrderProductionByState)
 	updateOrderProductionHandler := update.NewHandler(s.Dependency.UpdateOrderProduction, s.Dependency.UpdateOrderTopicService)
+	bulkUpdateOrderProductionHandler := bulk_update.NewHandler(s.Dependency.BulkUpdateOrderProduction, s.Dependency.UpdateOrderTopicService)
+	streamSseHandler := stream_sse.NewHandler(s.Dependency.OrderStreamer)
+	streamWsHandler := stream_ws.NewHandler(s.Dependency.OrderStreamer)
+	stateMachineHandler := state_machine.NewHandler()
+	exportOrderProductionHandler := export_handler.NewHandler(s.Dependency.ExportOrderProduction)
+	ticketHandler := ticket.NewHandler(s.Dependency.GetOrderProductionById, s.Config.ApiConfig.StoreId, s.Config.PrinterConfig.Width)
 
-	e.Use(token.Middleware())
+	e.GET("/production/states", stateMachineHandler.Handle)
+	e.GET("/production/export", exportOrderProductionHandler.Handle)
+	e.GET("/production/stream", streamSseHandler.Handle)
+	e.GET("/production/ws", streamWsHandler.Handle)
 	e.GET("/production/:id", getOrderProductionByIdHandler.Handle)
+	e.GET("/production/:id/ticket", ticketHandler.Handle)
 	e.GET("/production", getOrderProductionByStateHandler.Handle)
+	e.POST("/production", createOrderProductionHandler.Handle)
+	e.PATCH("/production", bulkUpdateOrderProductionHandler.Handle)
 	e.PATCH("/production/:id", updateOrderProductionHandler.Handle)
 }
+
+func (s *Server) registerAdminHandlers(e *echo.Group) {
+	admin := e.Group("/admin")
+
+	s.registerWebhookHandlers(admin)
+	s.registerApiKeyHandlers(admin)
+
+	// the hostname is the name of the pod, the log level is only changed in
+	// the replica serving the request
+	replica, _ := os.Hostname()
+
+	listAuditHandler := audit_list.NewHandler(s.Dependency.ListAudit)
+	getLogLevelHandler := log_level_get.NewHandler(logger.Level())
+	updateLogLevelHandler := log_level_update.NewHandler(logger.Level(), s.Dependency.AuditRecorder, replica)
+
+	admin.GET("/audit", listAuditHandler.Handle)
+	admin.GET("/log-level", getLogLevelHandler.Handle)
+	admin.PUT("/log-level", updateLogLevelHandler.Handle)
+
+	if s.DeadLetterQueueService == nil {
+		return
+	}
+
+	listDeadLetterHandler := dead_letter_list.NewHandler(s.DeadLetterQueueService)
+	redriveDeadLetterHandler := dead_letter_redrive.NewHandler(s.DeadLetterQueueService)
+	replayDeadLetterHandler := dead_letter_replay.NewHandler(s.DeadLetterQueueService)
+
+	admin.GET("/dlq", listDeadLetterHandler.Handle)
+	admin.POST("/dlq/redrive", redriveDeadLetterHandler.Handle)
+	admin.POST("/dlq/replay", replayDeadLetterHandler.Handle)
+}
+
+func (s *Server) registerWebhookHandlers(admin *echo.Group) {
+	createWebhookHandler := webhook_create.NewHandler(s.Dependency.CreateWebhook)
+	listWebhookHandler := webhook_list.NewHandler(s.Dependency.ListWebhook)
+	updateWebhookHandler := webhook_update.NewHandler(s.Dependency.UpdateWebhook)
+	deleteWebhookHandler := webhook_delete.NewHandler(s.Dependency.DeleteWebhook)
+	listWebhookDeliveriesHandler := webhook_deliveries.NewHandler(s.Dependency.ListWebhookDeliveries)
+
+	admin.POST("/webhooks", createWebhookHandler.Handle)
+	admin.GET("/webhooks", listWebhookHandler.Handle)
+	admin.PATCH("/webhooks/:id", updateWebhookHandler.Handle)
+	admin.DELETE("/webhooks/:id", deleteWebhookHandler.Handle)
+	admin.GET("/webhooks/:id/deliveries", listWebhookDeliveriesHandler.Handle)
+}
+
+func (s *Server) registerApiKeyHandlers(admin *echo.Group) {
+	createApiKeyHandler := api_key_create.NewHandler(s.Dependency.CreateApiKey)
+	listApiKeyHandler := api_key_list.NewHandler(s.Dependency.ListApiKey)
+	rotateApiKeyHandler := api_key_rotate.NewHandler(s.Dependency.RotateApiKey)
+	revokeApiKeyHandler := api_key_revoke.NewHandler(s.Dependency.RevokeApiKey)
+
+	admin.POST("/api-keys", createApiKeyHandler.Handle)
+	admin.GET("/api-keys", listApiKeyHandler.Handle)
+	admin.POST("/api-keys/:id/rotate", rotateApiKeyHandler.Handle)
+	admin.DELETE("/api-keys/:id", revokeApiKeyHandler.Handle)
+}
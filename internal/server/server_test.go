@@ -1,10 +1,20 @@
 package server
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/cloud"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/order_production"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/api_key/authenticate"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/service/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewServer(t *testing.T) {
@@ -21,6 +31,15 @@ func TestNewServer(t *testing.T) {
 				OrderProductionQueue: "order-production-queue",
 				UpdateOrderTopic:     "update-order-topic",
 			},
+			WebhookConfig:     &environment.WebhookConfig{},
+			PickupBoardConfig: &environment.PickupBoardConfig{},
+			OrderCacheConfig:  &environment.OrderCacheConfig{},
+			OrderStreamConfig: &environment.OrderStreamConfig{},
+			PrinterConfig:     &environment.PrinterConfig{},
+			AuthConfig:        &environment.AuthConfig{Secret: "my-secret"},
+			ApiKeyConfig:      &environment.ApiKeyConfig{},
+			HealthConfig:      &environment.HealthConfig{},
+			LogConfig:         &environment.LogConfig{},
 		}
 
 		// Act
@@ -30,6 +49,78 @@ func TestNewServer(t *testing.T) {
 		assert.NotNil(t, server)
 	})
 
+	t.Run("Should read the orders through the cache when enabled", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port: 8080,
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig: &environment.CloudConfig{
+				OrderProductionQueue: "order-production-queue",
+				UpdateOrderTopic:     "update-order-topic",
+			},
+			WebhookConfig:     &environment.WebhookConfig{},
+			PickupBoardConfig: &environment.PickupBoardConfig{},
+			OrderCacheConfig: &environment.OrderCacheConfig{
+				Enabled: true,
+				Size:    10,
+				Ttl:     time.Second,
+			},
+			OrderStreamConfig: &environment.OrderStreamConfig{},
+			PrinterConfig:     &environment.PrinterConfig{},
+			AuthConfig:        &environment.AuthConfig{Secret: "my-secret"},
+			ApiKeyConfig:      &environment.ApiKeyConfig{},
+			HealthConfig:      &environment.HealthConfig{},
+			LogConfig:         &environment.LogConfig{},
+		}
+
+		// Act
+		server := NewServer(config)
+
+		// Assert
+		assert.IsType(t, &order_production.CachedOrderProductionRepository{}, server.Dependency.OrderProductionReadRepository)
+		assert.IsType(t, &order_production.OrderProductionRepository{}, server.Dependency.OrderProductionRepository)
+	})
+
+	t.Run("Should print the orders of the queue when the printer is set", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port: 8080,
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig: &environment.CloudConfig{
+				OrderProductionQueue: "order-production-queue",
+				UpdateOrderTopic:     "update-order-topic",
+			},
+			WebhookConfig:     &environment.WebhookConfig{},
+			PickupBoardConfig: &environment.PickupBoardConfig{},
+			OrderCacheConfig:  &environment.OrderCacheConfig{},
+			OrderStreamConfig: &environment.OrderStreamConfig{},
+			PrinterConfig: &environment.PrinterConfig{
+				Address: "127.0.0.1:9100",
+				Timeout: time.Second,
+				Width:   42,
+			},
+			AuthConfig:   &environment.AuthConfig{Secret: "my-secret"},
+			ApiKeyConfig: &environment.ApiKeyConfig{},
+			HealthConfig: &environment.HealthConfig{},
+			LogConfig:    &environment.LogConfig{},
+		}
+
+		// Act
+		server := NewServer(config)
+
+		// Assert
+		assert.NotNil(t, server.AutoPrintService)
+		assert.Equal(t, server.AutoPrintService, server.QueueService.(*cloud.AwsSqsService).MessageProcessor)
+	})
+
 	t.Run("Should return a new server with base endpoint", func(t *testing.T) {
 		// Arrange
 		config := &environment.Config{
@@ -44,6 +135,15 @@ func TestNewServer(t *testing.T) {
 				UpdateOrderTopic:     "update-order-topic",
 				BaseEndpoint:         "http://localhost:8080",
 			},
+			WebhookConfig:     &environment.WebhookConfig{},
+			PickupBoardConfig: &environment.PickupBoardConfig{},
+			OrderCacheConfig:  &environment.OrderCacheConfig{},
+			OrderStreamConfig: &environment.OrderStreamConfig{},
+			PrinterConfig:     &environment.PrinterConfig{},
+			AuthConfig:        &environment.AuthConfig{Secret: "my-secret"},
+			ApiKeyConfig:      &environment.ApiKeyConfig{},
+			HealthConfig:      &environment.HealthConfig{},
+			LogConfig:         &environment.LogConfig{},
 		}
 
 		// Act
@@ -66,6 +166,15 @@ func TestNewServer(t *testing.T) {
 				OrderProductionQueue: "order-production-queue",
 				UpdateOrderTopic:     "update-order-topic",
 			},
+			WebhookConfig:     &environment.WebhookConfig{},
+			PickupBoardConfig: &environment.PickupBoardConfig{},
+			OrderCacheConfig:  &environment.OrderCacheConfig{},
+			OrderStreamConfig: &environment.OrderStreamConfig{},
+			PrinterConfig:     &environment.PrinterConfig{},
+			AuthConfig:        &environment.AuthConfig{Secret: "my-secret"},
+			ApiKeyConfig:      &environment.ApiKeyConfig{},
+			HealthConfig:      &environment.HealthConfig{},
+			LogConfig:         &environment.LogConfig{},
 		}
 
 		server := NewServer(config)
@@ -77,4 +186,89 @@ func TestNewServer(t *testing.T) {
 		assert.NotNil(t, httpServer)
 		assert.Equal(t, ":8080", httpServer.Addr)
 	})
+	t.Run("Should create a grpc server", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port: 8080,
+			},
+			GrpcConfig: &environment.GrpcConfig{
+				Port: 9090,
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig: &environment.CloudConfig{
+				OrderProductionQueue: "order-production-queue",
+				UpdateOrderTopic:     "update-order-topic",
+			},
+			WebhookConfig:     &environment.WebhookConfig{},
+			PickupBoardConfig: &environment.PickupBoardConfig{},
+			OrderCacheConfig:  &environment.OrderCacheConfig{},
+			OrderStreamConfig: &environment.OrderStreamConfig{},
+			PrinterConfig:     &environment.PrinterConfig{},
+			AuthConfig:        &environment.AuthConfig{Secret: "my-secret"},
+			ApiKeyConfig:      &environment.ApiKeyConfig{},
+			HealthConfig:      &environment.HealthConfig{},
+			LogConfig:         &environment.LogConfig{},
+		}
+
+		server := NewServer(config)
+
+		// Act
+		grpcServer := server.GetGrpcServer()
+
+		// Assert
+		assert.NotNil(t, grpcServer)
+		assert.Contains(t, grpcServer.GetServiceInfo(), "production.v1.ProductionService")
+		assert.Contains(t, grpcServer.GetServiceInfo(), "grpc.health.v1.Health")
+	})
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("Should authenticate the admin requests only once", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				Port:       8080,
+				ApiVersion: "v1",
+			},
+			DbConfig: &environment.DatabaseConfig{
+				Url: "postgres://host:1234",
+			},
+			CloudConfig: &environment.CloudConfig{
+				OrderProductionQueue: "order-production-queue",
+				UpdateOrderTopic:     "update-order-topic",
+			},
+			WebhookConfig:     &environment.WebhookConfig{},
+			PickupBoardConfig: &environment.PickupBoardConfig{},
+			OrderCacheConfig:  &environment.OrderCacheConfig{},
+			OrderStreamConfig: &environment.OrderStreamConfig{},
+			PrinterConfig:     &environment.PrinterConfig{},
+			AuthConfig:        &environment.AuthConfig{Secret: "my-secret"},
+			ApiKeyConfig:      &environment.ApiKeyConfig{},
+			HealthConfig:      &environment.HealthConfig{},
+			LogConfig:         &environment.LogConfig{},
+		}
+
+		apiKeys := mocks.NewMockAuthenticateApiKeyService[authenticate.AuthenticateApiKeyInput](t)
+
+		apiKeys.On("Handle", mock.Anything, authenticate.AuthenticateApiKeyInput{Key: "pmk_abc_secret"}).
+			Return(&api_key_entity.ApiKey{Id: "key-id", Scopes: []string{"manager"}}, nil).
+			Once()
+
+		server := NewServer(config)
+		server.Authenticator = token.NewAuthenticator(token.NewVerifier(config.AuthConfig, nil), apiKeys, config.ApiConfig.StoreId)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/log-level", nil)
+		req.Header.Set(token.HeaderApiKey, "pmk_abc_secret")
+		res := httptest.NewRecorder()
+
+		// Act
+		server.RegisterRoutes().ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		apiKeys.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,9 @@
+package authenticate
+
+type AuthenticateApiKeyInput struct {
+	Key string
+}
+
+func (input *AuthenticateApiKeyInput) Validate() error {
+	return nil
+}
@@ -0,0 +1,64 @@
+package authenticate
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+)
+
+type Service struct {
+	repository        repository.ApiKeyRepository
+	timeProvider      provider.TimeProvider
+	lastUsedPrecision time.Duration
+}
+
+func NewService(
+	repository repository.ApiKeyRepository,
+	timeProvider provider.TimeProvider,
+	lastUsedPrecision time.Duration,
+) *Service {
+	return &Service{
+		repository:        repository,
+		timeProvider:      timeProvider,
+		lastUsedPrecision: lastUsedPrecision,
+	}
+}
+
+// Handle returns the API key of the request, every failure is reported as
+// ErrApiKeyNotValid so the clients cannot tell a wrong key from a revoked one.
+// The last use is saved at most once per lastUsedPrecision
+func (s *Service) Handle(ctx context.Context, request AuthenticateApiKeyInput) (*api_key_entity.ApiKey, error) {
+	prefix, ok := api_key_entity.ParsePrefix(request.Key)
+	if !ok {
+		return nil, custom_error.ErrApiKeyNotValid
+	}
+
+	apiKey, err := s.repository.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if err == custom_error.ErrApiKeyNotFound {
+			return nil, custom_error.ErrApiKeyNotValid
+		}
+		return nil, err
+	}
+
+	now := s.timeProvider.GetTime()
+
+	if !apiKey.Matches(request.Key) || !apiKey.IsActive(now) {
+		return nil, custom_error.ErrApiKeyNotValid
+	}
+
+	if apiKey.NeedsTouch(s.lastUsedPrecision, now) {
+		apiKey.Touch(now)
+
+		if err := s.repository.TouchApiKey(ctx, apiKey.Id, now); err != nil {
+			slog.ErrorContext(ctx, "error saving the last use of the API key", "api_key_id", apiKey.Id, "error", err)
+		}
+	}
+
+	return &apiKey, nil
+}
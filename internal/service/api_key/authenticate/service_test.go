@@ -0,0 +1,214 @@
+package authenticate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+func newApiKey(t *testing.T, now time.Time) api_key_entity.ApiKey {
+	apiKey, err := api_key_entity.NewApiKey(uuid.NewString(), "billing", []string{"service"}, nil, now)
+	assert.NoError(t, err)
+
+	return apiKey
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should return the API key and save the last use", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+		apiKey := newApiKey(t, now)
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		repository.On("GetApiKeyByPrefix", ctx, apiKey.Prefix).
+			Return(apiKey, nil).
+			Once()
+
+		repository.On("TouchApiKey", ctx, apiKey.Id, now).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, timeProvider, time.Minute)
+
+		// Act
+		res, err := service.Handle(ctx, AuthenticateApiKeyInput{Key: apiKey.Key})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, apiKey.Id, res.Id)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should not save the last use within the precision", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+		apiKey := newApiKey(t, now)
+		apiKey.Touch(now.Add(-time.Second))
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		repository.On("GetApiKeyByPrefix", ctx, apiKey.Prefix).
+			Return(apiKey, nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, timeProvider, time.Minute)
+
+		// Act
+		_, err := service.Handle(ctx, AuthenticateApiKeyInput{Key: apiKey.Key})
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should authenticate even when the last use is not saved", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+		apiKey := newApiKey(t, now)
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		repository.On("GetApiKeyByPrefix", ctx, apiKey.Prefix).
+			Return(apiKey, nil).
+			Once()
+
+		repository.On("TouchApiKey", ctx, apiKey.Id, now).
+			Return(assert.AnError).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, timeProvider, time.Minute)
+
+		// Act
+		_, err := service.Handle(ctx, AuthenticateApiKeyInput{Key: apiKey.Key})
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return not valid when the key has another format", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		service := NewService(repository, timeProvider, time.Minute)
+
+		// Act
+		_, err := service.Handle(ctx, AuthenticateApiKeyInput{Key: "my-key"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrApiKeyNotValid)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return not valid when the key does not exist", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		repository.On("GetApiKeyByPrefix", ctx, "abc").
+			Return(api_key_entity.ApiKey{}, custom_error.ErrApiKeyNotFound).
+			Once()
+
+		service := NewService(repository, timeProvider, time.Minute)
+
+		// Act
+		_, err := service.Handle(ctx, AuthenticateApiKeyInput{Key: "pmk_abc_secret"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrApiKeyNotValid)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return not valid when the secret does not match", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+		apiKey := newApiKey(t, now)
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		repository.On("GetApiKeyByPrefix", ctx, apiKey.Prefix).
+			Return(apiKey, nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, timeProvider, time.Minute)
+
+		// Act
+		_, err := service.Handle(ctx, AuthenticateApiKeyInput{Key: "pmk_" + apiKey.Prefix + "_secret"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrApiKeyNotValid)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return not valid when the key is revoked", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+		apiKey := newApiKey(t, now)
+		apiKey.Revoke(now)
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+
+		repository.On("GetApiKeyByPrefix", ctx, apiKey.Prefix).
+			Return(apiKey, nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, timeProvider, time.Minute)
+
+		// Act
+		_, err := service.Handle(ctx, AuthenticateApiKeyInput{Key: apiKey.Key})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrApiKeyNotValid)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,22 @@
+package create
+
+import (
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+type CreateApiKeyInput struct {
+	Name string `json:"name" validate:"required,max=255"`
+	// Scopes are the roles of the authorization policy granted to the key
+	Scopes    []string   `json:"scopes" validate:"required,min=1,max=20,dive,required,max=64"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (input *CreateApiKeyInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package create
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Service struct {
+	repository   repository.ApiKeyRepository
+	transactor   repository.Transactor
+	timeProvider provider.TimeProvider
+	recorder     audit.Recorder
+}
+
+func NewService(
+	repository repository.ApiKeyRepository,
+	transactor repository.Transactor,
+	timeProvider provider.TimeProvider,
+	recorder audit.Recorder,
+) *Service {
+	return &Service{
+		repository:   repository,
+		transactor:   transactor,
+		timeProvider: timeProvider,
+		recorder:     recorder,
+	}
+}
+
+// Handle returns the API key with the key, it is the only moment the key is
+// exposed
+func (s *Service) Handle(ctx context.Context, request CreateApiKeyInput) (*api_key_entity.ApiKey, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	apiKey, err := api_key_entity.NewApiKey(
+		uuid.NewString(),
+		request.Name,
+		request.Scopes,
+		request.ExpiresAt,
+		s.timeProvider.GetTime(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.CreateApiKey(ctx, &apiKey); err != nil {
+			return err
+		}
+
+		return s.recorder.Record(ctx, audit_entity.ApiKeyCreatedAction, audit_entity.ApiKeyResource, apiKey.Id, nil, apiKey.Snapshot())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
@@ -0,0 +1,134 @@
+package create
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withinTransaction runs the function as the transactor does, the transaction
+// itself is tested with the repositories
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should create the API key and return the key", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("CreateApiKey", ctx, mock.Anything).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.ApiKeyCreatedAction, audit_entity.ApiKeyResource, mock.Anything, nil, mock.MatchedBy(func(snapshot api_key_entity.ApiKey) bool {
+			return snapshot.Key == ""
+		})).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		req := CreateApiKeyInput{
+			Name:   "billing",
+			Scopes: []string{"service"},
+		}
+
+		// Act
+		apiKey, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, apiKey)
+		assert.NotEmpty(t, apiKey.Key)
+		assert.Equal(t, api_key_entity.HashKey(apiKey.Key), apiKey.Hash)
+		assert.Equal(t, []string{"service"}, apiKey.Scopes)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when request is invalid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		req := CreateApiKeyInput{
+			Name: "billing",
+		}
+
+		// Act
+		apiKey, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, apiKey)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to create the API key", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("CreateApiKey", ctx, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		req := CreateApiKeyInput{
+			Name:   "billing",
+			Scopes: []string{"service"},
+		}
+
+		// Act
+		apiKey, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, apiKey)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,7 @@
+package list
+
+type ListApiKeyInput struct{}
+
+func (input *ListApiKeyInput) Validate() error {
+	return nil
+}
@@ -0,0 +1,28 @@
+package list
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Service struct {
+	repository repository.ApiKeyRepository
+}
+
+func NewService(repository repository.ApiKeyRepository) *Service {
+	return &Service{
+		repository: repository,
+	}
+}
+
+// Handle returns every API key, including the revoked and expired ones, the
+// keys themselves are never returned as only their hash is stored
+func (s *Service) Handle(ctx context.Context, request ListApiKeyInput) ([]api_key_entity.ApiKey, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListApiKeys(ctx)
+}
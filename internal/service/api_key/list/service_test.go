@@ -0,0 +1,53 @@
+package list
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should return the API keys", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+
+		repository.On("ListApiKeys", ctx).
+			Return([]api_key_entity.ApiKey{{Name: "billing"}}, nil).
+			Once()
+
+		service := NewService(repository)
+
+		// Act
+		apiKeys, err := service.Handle(ctx, ListApiKeyInput{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, apiKeys, 1)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to list the API keys", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+
+		repository.On("ListApiKeys", ctx).
+			Return(nil, assert.AnError).
+			Once()
+
+		service := NewService(repository)
+
+		// Act
+		_, err := service.Handle(ctx, ListApiKeyInput{})
+
+		// Assert
+		assert.Error(t, err)
+		repository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,17 @@
+package revoke
+
+import (
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+type RevokeApiKeyInput struct {
+	Id string `param:"id" json:"id" validate:"required,uuid4"`
+}
+
+func (input *RevokeApiKeyInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	return nil
+}
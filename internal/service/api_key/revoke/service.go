@@ -0,0 +1,60 @@
+package revoke
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Service struct {
+	repository   repository.ApiKeyRepository
+	transactor   repository.Transactor
+	timeProvider provider.TimeProvider
+	recorder     audit.Recorder
+}
+
+func NewService(
+	repository repository.ApiKeyRepository,
+	transactor repository.Transactor,
+	timeProvider provider.TimeProvider,
+	recorder audit.Recorder,
+) *Service {
+	return &Service{
+		repository:   repository,
+		transactor:   transactor,
+		timeProvider: timeProvider,
+		recorder:     recorder,
+	}
+}
+
+// Handle revokes the key immediately, the key is kept to identify the author
+// of the past changes. Revoking a revoked key does nothing
+func (s *Service) Handle(ctx context.Context, request RevokeApiKeyInput) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	apiKey, err := s.repository.GetApiKeyByID(ctx, request.Id)
+	if err != nil {
+		return err
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil
+	}
+
+	before := apiKey.Snapshot()
+
+	apiKey.Revoke(s.timeProvider.GetTime())
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.UpdateApiKey(ctx, &apiKey); err != nil {
+			return err
+		}
+
+		return s.recorder.Record(ctx, audit_entity.ApiKeyRevokedAction, audit_entity.ApiKeyResource, apiKey.Id, before, apiKey.Snapshot())
+	})
+}
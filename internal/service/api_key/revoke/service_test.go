@@ -0,0 +1,141 @@
+package revoke
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withinTransaction runs the function as the transactor does, the transaction
+// itself is tested with the repositories
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should revoke the API key", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		id := uuid.NewString()
+		now := time.Now()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetApiKeyByID", ctx, id).
+			Return(api_key_entity.ApiKey{Id: id}, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("UpdateApiKey", ctx, mock.MatchedBy(func(apiKey *api_key_entity.ApiKey) bool {
+			return apiKey.RevokedAt != nil && apiKey.RevokedAt.Equal(now)
+		})).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.ApiKeyRevokedAction, audit_entity.ApiKeyResource, id, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		// Act
+		err := service.Handle(ctx, RevokeApiKeyInput{Id: id})
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should do nothing when the API key is already revoked", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		id := uuid.NewString()
+		revokedAt := time.Now()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetApiKeyByID", ctx, id).
+			Return(api_key_entity.ApiKey{Id: id, RevokedAt: &revokedAt}, nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		// Act
+		err := service.Handle(ctx, RevokeApiKeyInput{Id: id})
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the API key does not exist", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		id := uuid.NewString()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetApiKeyByID", ctx, id).
+			Return(api_key_entity.ApiKey{}, custom_error.ErrApiKeyNotFound).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		// Act
+		err := service.Handle(ctx, RevokeApiKeyInput{Id: id})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrApiKeyNotFound)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when request is invalid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		// Act
+		err := service.Handle(ctx, RevokeApiKeyInput{Id: "123"})
+
+		// Assert
+		assert.Error(t, err)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,35 @@
+package rotate
+
+import (
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+// MaxOverlapMinutes is the longest time both keys can be valid, 30 days
+const MaxOverlapMinutes = 30 * 24 * 60
+
+type RotateApiKeyInput struct {
+	Id string `param:"id" json:"id" validate:"required,uuid4"`
+
+	// OverlapMinutes is how long the replaced key is still accepted, the
+	// configured overlap is used when not informed
+	OverlapMinutes *int       `json:"overlap_minutes" validate:"omitempty,gte=0,lte=43200"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+func (input *RotateApiKeyInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (input *RotateApiKeyInput) GetOverlap(defaultOverlap time.Duration) time.Duration {
+	if input.OverlapMinutes == nil {
+		return defaultOverlap
+	}
+
+	return time.Duration(*input.OverlapMinutes) * time.Minute
+}
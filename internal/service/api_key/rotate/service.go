@@ -0,0 +1,84 @@
+package rotate
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+)
+
+type Service struct {
+	repository     repository.ApiKeyRepository
+	transactor     repository.Transactor
+	timeProvider   provider.TimeProvider
+	defaultOverlap time.Duration
+	recorder       audit.Recorder
+}
+
+func NewService(
+	repository repository.ApiKeyRepository,
+	transactor repository.Transactor,
+	timeProvider provider.TimeProvider,
+	defaultOverlap time.Duration,
+	recorder audit.Recorder,
+) *Service {
+	return &Service{
+		repository:     repository,
+		transactor:     transactor,
+		timeProvider:   timeProvider,
+		defaultOverlap: defaultOverlap,
+		recorder:       recorder,
+	}
+}
+
+// Handle creates a new key with the name and scopes of the rotated one, which
+// is still accepted until the end of the overlap. The new key is returned with
+// the key, it is the only moment the key is exposed
+func (s *Service) Handle(ctx context.Context, request RotateApiKeyInput) (*api_key_entity.ApiKey, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	replaced, err := s.repository.GetApiKeyByID(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.timeProvider.GetTime()
+
+	if !replaced.IsActive(now) {
+		return nil, custom_error.ErrApiKeyRevoked
+	}
+
+	apiKey, err := api_key_entity.NewApiKey(uuid.NewString(), replaced.Name, replaced.Scopes, request.ExpiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	before := replaced.Snapshot()
+
+	replaced.ReplaceWith(apiKey.Id, request.GetOverlap(s.defaultOverlap), now)
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.RotateApiKey(ctx, &replaced, &apiKey); err != nil {
+			return err
+		}
+
+		if err := s.recorder.Record(ctx, audit_entity.ApiKeyRotatedAction, audit_entity.ApiKeyResource, replaced.Id, before, replaced.Snapshot()); err != nil {
+			return err
+		}
+
+		return s.recorder.Record(ctx, audit_entity.ApiKeyCreatedAction, audit_entity.ApiKeyResource, apiKey.Id, nil, apiKey.Snapshot())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
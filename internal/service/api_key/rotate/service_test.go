@@ -0,0 +1,185 @@
+package rotate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withinTransaction runs the function as the transactor does, the transaction
+// itself is tested with the repositories
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should create a new key and keep the old one during the overlap", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		id := uuid.NewString()
+		now := time.Now()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetApiKeyByID", ctx, id).
+			Return(api_key_entity.ApiKey{Id: id, Name: "billing", Scopes: []string{"service"}}, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("RotateApiKey", ctx,
+			mock.MatchedBy(func(replaced *api_key_entity.ApiKey) bool {
+				return replaced.Id == id && replaced.ExpiresAt.Equal(now.Add(time.Hour))
+			}),
+			mock.MatchedBy(func(apiKey *api_key_entity.ApiKey) bool {
+				return apiKey.Name == "billing" && apiKey.Key != ""
+			})).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.ApiKeyRotatedAction, audit_entity.ApiKeyResource, id, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.ApiKeyCreatedAction, audit_entity.ApiKeyResource, mock.Anything, nil, mock.Anything).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, time.Hour, recorder)
+
+		// Act
+		apiKey, err := service.Handle(ctx, RotateApiKeyInput{Id: id})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, apiKey)
+		assert.NotEqual(t, id, apiKey.Id)
+		assert.Equal(t, []string{"service"}, apiKey.Scopes)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should use the informed overlap", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		id := uuid.NewString()
+		now := time.Now()
+		overlap := 0
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetApiKeyByID", ctx, id).
+			Return(api_key_entity.ApiKey{Id: id, Name: "billing"}, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("RotateApiKey", ctx,
+			mock.MatchedBy(func(replaced *api_key_entity.ApiKey) bool {
+				return replaced.ExpiresAt.Equal(now)
+			}),
+			mock.Anything).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.ApiKeyRotatedAction, audit_entity.ApiKeyResource, id, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.ApiKeyCreatedAction, audit_entity.ApiKeyResource, mock.Anything, nil, mock.Anything).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, time.Hour, recorder)
+
+		// Act
+		_, err := service.Handle(ctx, RotateApiKeyInput{Id: id, OverlapMinutes: &overlap})
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the API key is revoked", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		id := uuid.NewString()
+		now := time.Now()
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetApiKeyByID", ctx, id).
+			Return(api_key_entity.ApiKey{Id: id, RevokedAt: &now}, nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, time.Hour, recorder)
+
+		// Act
+		apiKey, err := service.Handle(ctx, RotateApiKeyInput{Id: id})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrApiKeyRevoked)
+		assert.Nil(t, apiKey)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the overlap is too long", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		overlap := MaxOverlapMinutes + 1
+
+		repository := repository_mocks.NewMockApiKeyRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		service := NewService(repository, transactor, timeProvider, time.Hour, recorder)
+
+		// Act
+		_, err := service.Handle(ctx, RotateApiKeyInput{Id: uuid.NewString(), OverlapMinutes: &overlap})
+
+		// Assert
+		assert.Error(t, err)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+}
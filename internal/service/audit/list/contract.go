@@ -0,0 +1,101 @@
+package list
+
+import (
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+const DefaultLimit = 100
+
+// ListAuditInput selects the entries of the audit log, the newest first. The
+// entries older than BeforeId are the next page of a previous query
+type ListAuditInput struct {
+	Action       string `query:"action" json:"action"`
+	ResourceType string `query:"resource_type" json:"resource_type"`
+	ResourceId   string `query:"resource_id" json:"resource_id"`
+	ActorId      string `query:"actor_id" json:"actor_id"`
+
+	// From and To are RFC 3339 times, To is exclusive
+	From string `query:"from" json:"from"`
+	To   string `query:"to" json:"to"`
+
+	BeforeId int64 `query:"before_id" json:"before_id" validate:"gte=0"`
+	Limit    int   `query:"limit" json:"limit" validate:"gte=0,lte=1000"`
+}
+
+func (input *ListAuditInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	_, err := input.Filter()
+	return err
+}
+
+// Filter converts the input to the filter of the repository
+func (input *ListAuditInput) Filter() (audit_entity.Filter, error) {
+	violations := make([]custom_error.Violation, 0)
+
+	from, ok := parseTime(input.From)
+	if !ok {
+		violations = append(violations, timeViolation("from"))
+	}
+
+	to, ok := parseTime(input.To)
+	if !ok {
+		violations = append(violations, timeViolation("to"))
+	}
+
+	if len(violations) == 0 && from != nil && to != nil && !to.After(*from) {
+		violations = append(violations, custom_error.Violation{
+			Field:   "to",
+			Rule:    "gtfield",
+			Message: "must be after from",
+		})
+	}
+
+	if len(violations) > 0 {
+		return audit_entity.Filter{}, custom_error.NewValidationError(violations...)
+	}
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	return audit_entity.Filter{
+		Action:       input.Action,
+		ResourceType: input.ResourceType,
+		ResourceId:   input.ResourceId,
+		ActorId:      input.ActorId,
+		From:         from,
+		To:           to,
+		BeforeId:     input.BeforeId,
+		Limit:        limit,
+	}, nil
+}
+
+// parseTime returns nil when the time is not informed
+func parseTime(value string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	moment, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, false
+	}
+
+	return &moment, true
+}
+
+func timeViolation(field string) custom_error.Violation {
+	return custom_error.Violation{
+		Field:   field,
+		Rule:    "datetime",
+		Message: "must be a RFC 3339 time",
+	}
+}
@@ -0,0 +1,105 @@
+package list
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Should return nil when valid", func(t *testing.T) {
+		// Arrange
+		input := ListAuditInput{
+			Action: audit_entity.OrderCancelledAction,
+			From:   "2024-05-01T00:00:00Z",
+			To:     "2024-05-02T00:00:00Z",
+			Limit:  1000,
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return error when the limit is too high", func(t *testing.T) {
+		// Arrange
+		input := ListAuditInput{Limit: 1001}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, "limit", custom_error.GetViolations(err)[0].Field)
+	})
+
+	t.Run("Should return error when the times are not valid", func(t *testing.T) {
+		// Arrange
+		input := ListAuditInput{
+			From: "2024-05-01",
+			To:   "yesterday",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Len(t, custom_error.GetViolations(err), 2)
+	})
+
+	t.Run("Should return error when to is not after from", func(t *testing.T) {
+		// Arrange
+		input := ListAuditInput{
+			From: "2024-05-02T00:00:00Z",
+			To:   "2024-05-01T00:00:00Z",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, "to", custom_error.GetViolations(err)[0].Field)
+	})
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("Should use the default limit and keep the times empty", func(t *testing.T) {
+		// Arrange
+		input := ListAuditInput{ResourceId: "c3fdab1b-3c06-4db2-9edc-4760a2429462"}
+
+		// Act
+		filter, err := input.Filter()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultLimit, filter.Limit)
+		assert.Equal(t, input.ResourceId, filter.ResourceId)
+		assert.Nil(t, filter.From)
+		assert.Nil(t, filter.To)
+	})
+
+	t.Run("Should parse the times", func(t *testing.T) {
+		// Arrange
+		input := ListAuditInput{
+			From:     "2024-05-01T00:00:00-03:00",
+			BeforeId: 42,
+			Limit:    10,
+		}
+
+		// Act
+		filter, err := input.Filter()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, filter.From.Equal(time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)))
+		assert.Equal(t, int64(42), filter.BeforeId)
+		assert.Equal(t, 10, filter.Limit)
+	})
+}
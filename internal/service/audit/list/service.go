@@ -0,0 +1,31 @@
+package list
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Service struct {
+	repository repository.AuditRepository
+}
+
+func NewService(repository repository.AuditRepository) *Service {
+	return &Service{
+		repository: repository,
+	}
+}
+
+func (s *Service) Handle(ctx context.Context, request ListAuditInput) ([]audit_entity.Entry, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter, err := request.Filter()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListEntries(ctx, filter)
+}
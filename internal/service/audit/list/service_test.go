@@ -0,0 +1,80 @@
+package list
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should list the entries selected by the filter", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockAuditRepository(t)
+
+		repository.On("ListEntries", ctx, audit_entity.Filter{
+			ResourceType: audit_entity.OrderResource,
+			ActorId:      "user-1",
+			Limit:        DefaultLimit,
+		}).
+			Return([]audit_entity.Entry{{Id: 1, Action: audit_entity.OrderCreatedAction}}, nil).
+			Once()
+
+		service := NewService(repository)
+
+		// Act
+		entries, err := service.Handle(ctx, ListAuditInput{
+			ResourceType: audit_entity.OrderResource,
+			ActorId:      "user-1",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the request is invalid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockAuditRepository(t)
+
+		service := NewService(repository)
+
+		// Act
+		entries, err := service.Handle(ctx, ListAuditInput{From: "today"})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Nil(t, entries)
+		repository.AssertNotCalled(t, "ListEntries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when try to list the entries", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockAuditRepository(t)
+
+		repository.On("ListEntries", ctx, mock.Anything).
+			Return(nil, assert.AnError).
+			Once()
+
+		service := NewService(repository)
+
+		// Act
+		entries, err := service.Handle(ctx, ListAuditInput{})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, entries)
+		repository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,41 @@
+package bulk_update
+
+import (
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+// MaxOrders is the maximum number of orders changed by a single request
+const MaxOrders = 500
+
+// BulkUpdateOrderProductionInput changes the state of the listed orders or of
+// the orders selected by the filter, exactly one of them must be informed
+type BulkUpdateOrderProductionInput struct {
+	OrderIds []string               `json:"order_ids" validate:"required_without=Filter,excluded_with=Filter,max=500,dive,uuid4"`
+	Filter   *BulkUpdateOrderFilter `json:"filter" validate:"required_without=OrderIds"`
+	State    string                 `json:"state" validate:"required"`
+}
+
+// BulkUpdateOrderFilter selects the orders at the state for more than the
+// given minutes
+type BulkUpdateOrderFilter struct {
+	State            string `json:"state" validate:"required"`
+	OlderThanMinutes int    `json:"older_than_minutes" validate:"min=0"`
+}
+
+func (input *BulkUpdateOrderProductionInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	if order_entity.NewOrderState(input.State) == order_entity.None {
+		return custom_error.NewValidationError(order_entity.NewStateViolation("state"))
+	}
+
+	if input.Filter != nil && order_entity.NewOrderState(input.Filter.State) == order_entity.None {
+		return custom_error.NewValidationError(order_entity.NewStateViolation("filter.state"))
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package bulk_update
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Should return nil when the order ids are valid", func(t *testing.T) {
+		// Arrange
+		input := BulkUpdateOrderProductionInput{
+			OrderIds: []string{uuid.NewString(), uuid.NewString()},
+			State:    "Delivered",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return nil when the filter is valid", func(t *testing.T) {
+		// Arrange
+		input := BulkUpdateOrderProductionInput{
+			Filter: &BulkUpdateOrderFilter{
+				State:            "Completed",
+				OlderThanMinutes: 30,
+			},
+			State: "Delivered",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return error when neither order ids nor filter are informed", func(t *testing.T) {
+		// Arrange
+		input := BulkUpdateOrderProductionInput{
+			State: "Delivered",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when both order ids and filter are informed", func(t *testing.T) {
+		// Arrange
+		input := BulkUpdateOrderProductionInput{
+			OrderIds: []string{uuid.NewString()},
+			Filter: &BulkUpdateOrderFilter{
+				State: "Completed",
+			},
+			State: "Delivered",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when an order id is invalid", func(t *testing.T) {
+		// Arrange
+		input := BulkUpdateOrderProductionInput{
+			OrderIds: []string{"123"},
+			State:    "Delivered",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when there are too many order ids", func(t *testing.T) {
+		// Arrange
+		ids := make([]string, MaxOrders+1)
+		for i := range ids {
+			ids[i] = uuid.NewString()
+		}
+
+		input := BulkUpdateOrderProductionInput{
+			OrderIds: ids,
+			State:    "Delivered",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when the state is invalid", func(t *testing.T) {
+		// Arrange
+		input := BulkUpdateOrderProductionInput{
+			OrderIds: []string{uuid.NewString()},
+			State:    "invalid",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when the filter state is invalid", func(t *testing.T) {
+		// Arrange
+		input := BulkUpdateOrderProductionInput{
+			Filter: &BulkUpdateOrderFilter{
+				State: "invalid",
+			},
+			State: "Delivered",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,152 @@
+package bulk_update
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/metrics"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+)
+
+type Service struct {
+	repository   repository.OrderProductionRepository
+	transactor   repository.Transactor
+	timeProvider provider.TimeProvider
+	policy       *authorization.Policy
+	recorder     audit.Recorder
+}
+
+func NewService(
+	repository repository.OrderProductionRepository,
+	transactor repository.Transactor,
+	timeProvider provider.TimeProvider,
+	policy *authorization.Policy,
+	recorder audit.Recorder,
+) *Service {
+	return &Service{
+		repository:   repository,
+		transactor:   transactor,
+		timeProvider: timeProvider,
+		policy:       policy,
+		recorder:     recorder,
+	}
+}
+
+// Handle applies the transition to every order and saves the valid ones in a
+// single transaction, the orders that cannot transition or whose transition
+// is not allowed to the user are reported with their business error and do
+// not prevent the others from being updated
+func (s *Service) Handle(ctx context.Context, request BulkUpdateOrderProductionInput) ([]order_entity.BulkUpdateResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := s.timeProvider.GetTime()
+
+	ids, err := s.selectIds(ctx, request, now)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.repository.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	ordersById := make(map[string]*order_entity.Order, len(orders))
+	for i := range orders {
+		ordersById[orders[i].Id] = &orders[i]
+	}
+
+	newState := order_entity.NewOrderState(request.State)
+
+	results := make([]order_entity.BulkUpdateResult, 0, len(ids))
+	toUpdate := make([]*order_entity.Order, 0, len(ids))
+	before := make(map[string]order_entity.Order, len(ids))
+
+	for _, id := range ids {
+		order, ok := ordersById[id]
+		if !ok {
+			results = append(results, order_entity.NewBulkUpdateFailure(id, custom_error.ErrOrderNotFound))
+			continue
+		}
+
+		previous := *order
+
+		if err := order.UpdateState(newState, now); err != nil {
+			results = append(results, order_entity.NewBulkUpdateFailure(id, err))
+			continue
+		}
+
+		if err := s.policy.AuthorizeTransition(ctx, order.PreviousState, order.State); err != nil {
+			results = append(results, order_entity.NewBulkUpdateFailure(id, err))
+			continue
+		}
+
+		order.RefreshStateTitle()
+
+		before[order.Id] = previous
+		toUpdate = append(toUpdate, order)
+		results = append(results, order_entity.NewBulkUpdateSuccess(order))
+	}
+
+	if len(toUpdate) == 0 {
+		return results, nil
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.UpdateMany(ctx, toUpdate); err != nil {
+			return err
+		}
+
+		for _, order := range toUpdate {
+			if err := s.recorder.Record(ctx, audit_entity.OrderUpdateAction(order.State), audit_entity.OrderResource, order.Id, before[order.Id], order); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, order := range toUpdate {
+		previous := before[order.Id]
+
+		metrics.ObserveStateDuration(previous.State.String(), previous.StateUpdatedAt, order.StateUpdatedAt)
+	}
+
+	return results, nil
+}
+
+func (s *Service) selectIds(ctx context.Context, request BulkUpdateOrderProductionInput, now time.Time) ([]string, error) {
+	if request.Filter == nil {
+		return unique(request.OrderIds), nil
+	}
+
+	before := now.Add(-time.Duration(request.Filter.OlderThanMinutes) * time.Minute)
+
+	return s.repository.GetIDsByStateUpdatedBefore(ctx, order_entity.NewOrderState(request.Filter.State), before, MaxOrders)
+}
+
+func unique(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	res := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+	}
+
+	return res
+}
@@ -0,0 +1,294 @@
+package bulk_update
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withinTransaction runs the function as the transactor does, the transaction
+// itself is tested with the repositories
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should update the orders and report the failures", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+		now := time.Now()
+
+		completedId := uuid.NewString()
+		receivedId := uuid.NewString()
+		missingId := uuid.NewString()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		transactor := mocks.NewMockTransactor(t)
+		repository.On("GetByIDs", ctx, []string{completedId, receivedId, missingId}).
+			Return([]order_entity.Order{
+				{Id: completedId, State: order_entity.Completed},
+				{Id: receivedId, State: order_entity.Received},
+			}, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("UpdateMany", ctx, mock.MatchedBy(func(orders []*order_entity.Order) bool {
+			return len(orders) == 1 && orders[0].Id == completedId && orders[0].State == order_entity.Delivered
+		})).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.OrderStateChangedAction, audit_entity.OrderResource, completedId, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		// Act
+		res, err := service.Handle(ctx, BulkUpdateOrderProductionInput{
+			OrderIds: []string{completedId, receivedId, missingId, completedId},
+			State:    "Delivered",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+
+		assert.True(t, res[0].Success)
+		assert.Equal(t, "Delivered", res[0].Order.StateTitle)
+
+		assert.False(t, res[1].Success)
+		assert.Equal(t, custom_error.ErrOrderInvalidStateTransition.Error(), res[1].Error.Details)
+
+		assert.False(t, res[2].Success)
+		assert.Equal(t, custom_error.ErrOrderNotFound.Error(), res[2].Error.Details)
+
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should update the orders selected by the filter", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+		now := time.Now()
+
+		id := uuid.NewString()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		transactor := mocks.NewMockTransactor(t)
+		repository.On("GetIDsByStateUpdatedBefore", ctx, order_entity.Completed, now.Add(-30*time.Minute), MaxOrders).
+			Return([]string{id}, nil).
+			Once()
+
+		repository.On("GetByIDs", ctx, []string{id}).
+			Return([]order_entity.Order{{Id: id, State: order_entity.Completed}}, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("UpdateMany", ctx, mock.Anything).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.OrderStateChangedAction, audit_entity.OrderResource, id, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		// Act
+		res, err := service.Handle(ctx, BulkUpdateOrderProductionInput{
+			Filter: &BulkUpdateOrderFilter{
+				State:            "Completed",
+				OlderThanMinutes: 30,
+			},
+			State: "Delivered",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.True(t, res[0].Success)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should not update when no order can transition", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+
+		id := uuid.NewString()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		transactor := mocks.NewMockTransactor(t)
+		repository.On("GetByIDs", ctx, []string{id}).
+			Return([]order_entity.Order{{Id: id, State: order_entity.Delivered}}, nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		// Act
+		res, err := service.Handle(ctx, BulkUpdateOrderProductionInput{
+			OrderIds: []string{id},
+			State:    "Delivered",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, res[0].Success)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the transaction fails", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+
+		id := uuid.NewString()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		transactor := mocks.NewMockTransactor(t)
+		repository.On("GetByIDs", ctx, []string{id}).
+			Return([]order_entity.Order{{Id: id, State: order_entity.Completed}}, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("UpdateMany", ctx, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		// Act
+		_, err := service.Handle(ctx, BulkUpdateOrderProductionInput{
+			OrderIds: []string{id},
+			State:    "Delivered",
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the request is invalid", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.ManagerRole)
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+		repository := mocks.NewMockOrderProductionRepository(t)
+		transactor := mocks.NewMockTransactor(t)
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		// Act
+		_, err := service.Handle(ctx, BulkUpdateOrderProductionInput{})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should report the orders whose transition is not allowed to the roles", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+		now := time.Now()
+
+		receivedId := uuid.NewString()
+		processingId := uuid.NewString()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		transactor := mocks.NewMockTransactor(t)
+		repository.On("GetByIDs", ctx, []string{receivedId, processingId}).
+			Return([]order_entity.Order{
+				{Id: receivedId, State: order_entity.Received},
+				{Id: processingId, State: order_entity.Processing},
+			}, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("UpdateMany", ctx, mock.MatchedBy(func(orders []*order_entity.Order) bool {
+			return len(orders) == 1 && orders[0].Id == receivedId && orders[0].State == order_entity.Cancelled
+		})).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.OrderCancelledAction, audit_entity.OrderResource, receivedId, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		// Act
+		res, err := service.Handle(ctx, BulkUpdateOrderProductionInput{
+			OrderIds: []string{receivedId, processingId},
+			State:    "Cancelled",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+
+		assert.True(t, res[0].Success)
+
+		assert.False(t, res[1].Success)
+		assert.Equal(t, custom_error.ErrOrderTransitionForbidden.Error(), res[1].Error.Details)
+
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+}
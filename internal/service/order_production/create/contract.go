@@ -1,26 +1,40 @@
 package create
 
 import (
-	"github.com/go-playground/validator/v10"
-	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
 )
 
 type CreateOrderProductionItemInput struct {
 	Id       string `json:"id" validate:"required,uuid4"`
 	Name     string `json:"name" validate:"required"`
 	Quantity int    `json:"quantity" validate:"required,gte=1"`
+	Station  string `json:"station"`
+
+	Modifiers []string `json:"modifiers" validate:"max=20,dive,required,max=255"`
 }
 
 type CreateOrderProductionInput struct {
 	OrderId string `json:"order_id" validate:"required,uuid4"`
 
 	Items []CreateOrderProductionItemInput `json:"items" validate:"required,dive"`
+
+	// DryRun runs the whole creation flow without persisting the order
+	DryRun bool `json:"-"`
+
+	// Origin is empty for the orders received through the queue, manual
+	// orders also carry the user that created them
+	Origin    order_entity.OrderOrigin `json:"-" validate:"omitempty,oneof=queue manual"`
+	CreatedBy string                   `json:"-" validate:"required_if=Origin manual"`
+}
+
+func (input *CreateOrderProductionInput) IsManual() bool {
+	return input.Origin == order_entity.ManualOrigin
 }
 
 func (input *CreateOrderProductionInput) Validate() error {
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return custom_error.ErrRequestNotValid
+	if err := validation.Struct(input); err != nil {
+		return err
 	}
 
 	return nil
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -47,4 +49,70 @@ func TestValidate(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 	})
+	t.Run("Should return error when a manual order has no user", func(t *testing.T) {
+		// Arrange
+		input := CreateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:       uuid.NewString(),
+					Name:     "Test",
+					Quantity: 1,
+				},
+			},
+			Origin: order_entity.ManualOrigin,
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return the fields that are not valid", func(t *testing.T) {
+		// Arrange
+		input := CreateOrderProductionInput{
+			OrderId: "123",
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:       uuid.NewString(),
+					Name:     "Burger",
+					Quantity: 0,
+				},
+			},
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, []custom_error.Violation{
+			{Field: "order_id", Rule: "uuid4", Message: "must be a valid UUID v4"},
+			{Field: "items[0].quantity", Rule: "required", Message: "is required"},
+		}, custom_error.GetViolations(err))
+	})
+
+	t.Run("Should return error when a modifier is empty", func(t *testing.T) {
+		// Arrange
+		input := CreateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:        uuid.NewString(),
+					Name:      "Burger",
+					Quantity:  1,
+					Modifiers: []string{"no onions", ""},
+				},
+			},
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, "items[0].modifiers[1]", custom_error.GetViolations(err)[0].Field)
+	})
 }
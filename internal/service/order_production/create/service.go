@@ -3,54 +3,112 @@ package create
 import (
 	"context"
 
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
 )
 
 type Service struct {
 	repository   repository.OrderProductionRepository
+	transactor   repository.Transactor
 	timeProvider provider.TimeProvider
+	policy       *authorization.Policy
+	recorder     audit.Recorder
 }
 
 func NewService(
 	repository repository.OrderProductionRepository,
+	transactor repository.Transactor,
 	timeProvider provider.TimeProvider,
+	policy *authorization.Policy,
+	recorder audit.Recorder,
 ) *Service {
 	return &Service{
 		repository:   repository,
+		transactor:   transactor,
 		timeProvider: timeProvider,
+		policy:       policy,
+		recorder:     recorder,
 	}
 }
 
+// Handle creates the order. When the queue delivers an order that was created
+// manually, the order is reconciled instead and no order is returned, as the
+// created event was already published by the manual creation
 func (s *Service) Handle(ctx context.Context, request CreateOrderProductionInput) (*order_entity.Order, error) {
 	if err := request.Validate(); err != nil {
 		return nil, err
 	}
 
+	if err := s.policy.AuthorizeTransition(ctx, order_entity.None, order_entity.Received); err != nil {
+		return nil, err
+	}
+
 	exists, err := s.repository.GetByID(ctx, request.OrderId)
 	if err != nil && err != custom_error.ErrOrderNotFound {
 		return nil, err
 	}
 
 	if exists.Exists() {
+		if !request.IsManual() && exists.NeedsReconciliation() {
+			return nil, s.reconcile(ctx, &exists, request.DryRun)
+		}
+
 		return nil, custom_error.ErrOrderAlreadyExists
 	}
 
 	order := order_entity.NewOrder(request.OrderId, s.timeProvider.GetTime())
 
+	if request.IsManual() {
+		order.SetManualOrigin(request.CreatedBy)
+	}
+
 	for _, item := range request.Items {
 		orderItem := order_entity.NewItem(item.Id, item.Name, item.Quantity)
+		orderItem.Station = item.Station
+		orderItem.Modifiers = item.Modifiers
 
 		if err := order.AddItem(orderItem, s.timeProvider.GetTime()); err != nil {
 			return nil, err
 		}
 	}
 
-	if err := s.repository.Create(ctx, &order); err != nil {
+	if request.DryRun {
+		return &order, nil
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.Create(ctx, &order); err != nil {
+			return err
+		}
+
+		return s.recorder.Record(ctx, audit_entity.OrderCreatedAction, audit_entity.OrderResource, order.Id, nil, order)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &order, nil
 }
+
+func (s *Service) reconcile(ctx context.Context, order *order_entity.Order, dryRun bool) error {
+	before := *order
+
+	order.Reconcile(s.timeProvider.GetTime())
+
+	if dryRun {
+		return nil
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.Reconcile(ctx, order); err != nil {
+			return err
+		}
+
+		return s.recorder.Record(ctx, audit_entity.OrderReconciledAction, audit_entity.OrderResource, order.Id, before, order)
+	})
+}
@@ -6,36 +6,56 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
 	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// withinTransaction runs the function as the transactor does, the transaction
+// itself is tested with the repositories
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
 func TestHandle(t *testing.T) {
 	t.Run("Should create order", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
 
 		now := time.Now()
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
 		repository.On("GetByID", ctx, mock.Anything).
 			Return(order_entity.Order{}, nil).
 			Once()
 
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
 		repository.On("Create", ctx, mock.Anything).
 			Return(nil).
 			Once()
 
+		recorder.On("Record", ctx, audit_entity.OrderCreatedAction, audit_entity.OrderResource, mock.Anything, nil, mock.Anything).
+			Return(nil).
+			Once()
+
 		timeProvider.On("GetTime").
 			Return(now).
 			Times(2)
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := CreateOrderProductionInput{
 			OrderId: uuid.NewString(),
@@ -55,17 +75,64 @@ func TestHandle(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, order)
 		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should not persist the order when running in dry run mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+
+		now := time.Now()
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetByID", ctx, mock.Anything).
+			Return(order_entity.Order{}, nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Times(2)
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := CreateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:       uuid.NewString(),
+					Name:     "Test",
+					Quantity: 1,
+				},
+			},
+			DryRun: true,
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, order)
+		repository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		repository.AssertExpectations(t)
 		timeProvider.AssertExpectations(t)
 	})
 
 	t.Run("Should return error when request is invalid", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := CreateOrderProductionInput{
 			OrderId: "order-id",
@@ -90,17 +157,23 @@ func TestHandle(t *testing.T) {
 
 	t.Run("Should return error when repository fails", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
 
 		now := time.Now()
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
 		repository.On("GetByID", ctx, mock.Anything).
 			Return(order_entity.Order{}, nil).
 			Once()
 
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
 		repository.On("Create", ctx, mock.Anything).
 			Return(assert.AnError).
 			Once()
@@ -109,7 +182,7 @@ func TestHandle(t *testing.T) {
 			Return(now).
 			Times(2)
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := CreateOrderProductionInput{
 			OrderId: uuid.NewString(),
@@ -134,12 +207,14 @@ func TestHandle(t *testing.T) {
 
 	t.Run("Should return error when try to add item fails", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
 
 		now := time.Now()
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
 		repository.On("GetByID", ctx, mock.Anything).
 			Return(order_entity.Order{}, nil).
@@ -149,7 +224,7 @@ func TestHandle(t *testing.T) {
 			Return(now).
 			Times(3)
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		itemId := uuid.NewString()
 
@@ -181,10 +256,12 @@ func TestHandle(t *testing.T) {
 
 	t.Run("Should not create order when order already exists", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
 		repository.On("GetByID", ctx, mock.Anything).
 			Return(order_entity.Order{
@@ -192,7 +269,7 @@ func TestHandle(t *testing.T) {
 			}, nil).
 			Once()
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := CreateOrderProductionInput{
 			OrderId: uuid.NewString(),
@@ -217,16 +294,18 @@ func TestHandle(t *testing.T) {
 
 	t.Run("Should return error when could not get order by ID", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
 		repository.On("GetByID", ctx, mock.Anything).
 			Return(order_entity.Order{}, assert.AnError).
 			Once()
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := CreateOrderProductionInput{
 			OrderId: uuid.NewString(),
@@ -248,4 +327,291 @@ func TestHandle(t *testing.T) {
 		repository.AssertExpectations(t)
 		timeProvider.AssertExpectations(t)
 	})
+	t.Run("Should create a manual order with the user", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+
+		now := time.Now()
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetByID", ctx, mock.Anything).
+			Return(order_entity.Order{}, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("Create", ctx, mock.MatchedBy(func(order *order_entity.Order) bool {
+			return order.Origin == order_entity.ManualOrigin && order.CreatedBy == "user-1"
+		})).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.OrderCreatedAction, audit_entity.OrderResource, mock.Anything, nil, mock.Anything).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Times(2)
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := CreateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:       uuid.NewString(),
+					Name:     "Test",
+					Quantity: 1,
+				},
+			},
+			Origin:    order_entity.ManualOrigin,
+			CreatedBy: "user-1",
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, order_entity.ManualOrigin, order.Origin)
+		assert.Equal(t, "user-1", order.CreatedBy)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should reconcile a manual order received through the queue", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+
+		now := time.Now()
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		existing := order_entity.NewOrder(uuid.NewString(), now.Add(-time.Minute))
+		existing.SetManualOrigin("user-1")
+
+		repository.On("GetByID", ctx, existing.Id).
+			Return(existing, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("Reconcile", ctx, mock.MatchedBy(func(order *order_entity.Order) bool {
+			return order.Id == existing.Id && order.ReconciledAt != nil && order.ReconciledAt.Equal(now)
+		})).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.OrderReconciledAction, audit_entity.OrderResource, existing.Id, existing, mock.Anything).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := CreateOrderProductionInput{
+			OrderId: existing.Id,
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:       uuid.NewString(),
+					Name:     "Test",
+					Quantity: 1,
+				},
+			},
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, order)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should not persist the reconciliation when running in dry run mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		existing := order_entity.NewOrder(uuid.NewString(), time.Now())
+		existing.SetManualOrigin("user-1")
+
+		repository.On("GetByID", ctx, existing.Id).
+			Return(existing, nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := CreateOrderProductionInput{
+			OrderId: existing.Id,
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:       uuid.NewString(),
+					Name:     "Test",
+					Quantity: 1,
+				},
+			},
+			DryRun: true,
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, order)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the reconciliation fails", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		existing := order_entity.NewOrder(uuid.NewString(), time.Now())
+		existing.SetManualOrigin("user-1")
+
+		repository.On("GetByID", ctx, existing.Id).
+			Return(existing, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("Reconcile", ctx, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := CreateOrderProductionInput{
+			OrderId: existing.Id,
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:       uuid.NewString(),
+					Name:     "Test",
+					Quantity: 1,
+				},
+			},
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, order)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should not create a manual order when the order already exists", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.CounterRole)
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		existing := order_entity.NewOrder(uuid.NewString(), time.Now())
+		existing.SetManualOrigin("user-1")
+
+		repository.On("GetByID", ctx, existing.Id).
+			Return(existing, nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := CreateOrderProductionInput{
+			OrderId: existing.Id,
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:       uuid.NewString(),
+					Name:     "Test",
+					Quantity: 1,
+				},
+			},
+			Origin:    order_entity.ManualOrigin,
+			CreatedBy: "user-2",
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrOrderAlreadyExists)
+		assert.Nil(t, order)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the roles do not allow creating orders", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.KitchenRole)
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := CreateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			Items: []CreateOrderProductionItemInput{
+				{
+					Id:       uuid.NewString(),
+					Name:     "Test",
+					Quantity: 1,
+				},
+			},
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrOrderTransitionForbidden)
+		assert.Nil(t, order)
+		repository.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		repository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
 }
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+
+	dateLayout = "2006-01-02"
+)
+
+// ExportOrderProductionInput selects the orders created between From and To,
+// both are dates in the configured timezone or RFC 3339 times. A date in To
+// includes the whole day
+type ExportOrderProductionInput struct {
+	From string `query:"from" json:"from" validate:"required"`
+	To   string `query:"to" json:"to" validate:"required"`
+
+	// States is a comma separated list of state titles, every state when empty
+	States string `query:"state" json:"state"`
+	Format string `query:"format" json:"format" validate:"omitempty,oneof=csv jsonl"`
+}
+
+func (input *ExportOrderProductionInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	_, err := input.Filter()
+	return err
+}
+
+// GetFormat returns the format of the export, CSV when not informed
+func (input *ExportOrderProductionInput) GetFormat() string {
+	if input.Format == "" {
+		return FormatCSV
+	}
+
+	return input.Format
+}
+
+// Filter converts the input to the filter of the repository
+func (input *ExportOrderProductionInput) Filter() (order_entity.ExportFilter, error) {
+	violations := make([]custom_error.Violation, 0)
+
+	from, _, ok := parseTime(input.From)
+	if !ok {
+		violations = append(violations, timeViolation("from"))
+	}
+
+	to, isDate, ok := parseTime(input.To)
+	if !ok {
+		violations = append(violations, timeViolation("to"))
+	}
+
+	if isDate {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	if len(violations) == 0 && !to.After(from) {
+		violations = append(violations, custom_error.Violation{
+			Field:   "to",
+			Rule:    "gtfield",
+			Message: "must be after from",
+		})
+	}
+
+	states := make([]order_entity.OrderState, 0)
+
+	for _, title := range strings.Split(input.States, ",") {
+		title = strings.TrimSpace(title)
+		if title == "" {
+			continue
+		}
+
+		state, ok := parseState(title)
+		if !ok {
+			violations = append(violations, custom_error.Violation{
+				Field:   "state",
+				Rule:    "state",
+				Message: "must be a list of: Received, Processing, Completed, Delivered, Cancelled",
+			})
+			break
+		}
+
+		states = append(states, state)
+	}
+
+	if len(violations) > 0 {
+		return order_entity.ExportFilter{}, custom_error.NewValidationError(violations...)
+	}
+
+	return order_entity.ExportFilter{
+		From:   from,
+		To:     to,
+		States: states,
+	}, nil
+}
+
+// parseTime accepts a date in the timezone of the orders or a RFC 3339 time
+func parseTime(value string) (time.Time, bool, bool) {
+	if date, err := time.ParseInLocation(dateLayout, value, order_entity.Location()); err == nil {
+		return date, true, true
+	}
+
+	if moment, err := time.Parse(time.RFC3339, value); err == nil {
+		return moment, false, true
+	}
+
+	return time.Time{}, false, false
+}
+
+// parseState accepts every state, including the cancelled orders
+func parseState(title string) (order_entity.OrderState, bool) {
+	for state := order_entity.Received; state <= order_entity.Cancelled; state++ {
+		if state.String() == title {
+			return state, true
+		}
+	}
+
+	return order_entity.None, false
+}
+
+func timeViolation(field string) custom_error.Violation {
+	return custom_error.Violation{
+		Field:   field,
+		Rule:    "datetime",
+		Message: "must be a date (2006-01-02) or a RFC 3339 time",
+	}
+}
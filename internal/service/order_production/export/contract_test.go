@@ -0,0 +1,143 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Should return nil when valid", func(t *testing.T) {
+		// Arrange
+		input := ExportOrderProductionInput{
+			From:   "2024-05-01",
+			To:     "2024-05-31",
+			States: "Delivered,Cancelled",
+			Format: FormatJSONL,
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return error when the dates are missing", func(t *testing.T) {
+		// Arrange
+		input := ExportOrderProductionInput{}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Len(t, custom_error.GetViolations(err), 2)
+	})
+
+	t.Run("Should return error when the format is not supported", func(t *testing.T) {
+		// Arrange
+		input := ExportOrderProductionInput{
+			From:   "2024-05-01",
+			To:     "2024-05-31",
+			Format: "xlsx",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, "format", custom_error.GetViolations(err)[0].Field)
+	})
+
+	t.Run("Should return error when the dates are not valid", func(t *testing.T) {
+		// Arrange
+		input := ExportOrderProductionInput{
+			From: "01/05/2024",
+			To:   "yesterday",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+
+		violations := custom_error.GetViolations(err)
+		assert.Len(t, violations, 2)
+		assert.Equal(t, "from", violations[0].Field)
+		assert.Equal(t, "to", violations[1].Field)
+	})
+
+	t.Run("Should return error when to is before from", func(t *testing.T) {
+		// Arrange
+		input := ExportOrderProductionInput{
+			From: "2024-05-31",
+			To:   "2024-05-01",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, "to", custom_error.GetViolations(err)[0].Field)
+	})
+
+	t.Run("Should return error when the state is not valid", func(t *testing.T) {
+		// Arrange
+		input := ExportOrderProductionInput{
+			From:   "2024-05-01",
+			To:     "2024-05-31",
+			States: "Delivered,Eaten",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, "state", custom_error.GetViolations(err)[0].Field)
+	})
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("Should include the whole day when to is a date", func(t *testing.T) {
+		// Arrange
+		input := ExportOrderProductionInput{
+			From:   "2024-05-01",
+			To:     "2024-05-01",
+			States: "Delivered, Cancelled",
+		}
+
+		// Act
+		filter, err := input.Filter()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, order_entity.Location()), filter.From)
+		assert.Equal(t, time.Date(2024, 5, 2, 0, 0, 0, 0, order_entity.Location()), filter.To)
+		assert.Equal(t, []order_entity.OrderState{order_entity.Delivered, order_entity.Cancelled}, filter.States)
+	})
+
+	t.Run("Should accept RFC 3339 times", func(t *testing.T) {
+		// Arrange
+		input := ExportOrderProductionInput{
+			From: "2024-05-01T10:00:00Z",
+			To:   "2024-05-01T12:00:00Z",
+		}
+
+		// Act
+		filter, err := input.Filter()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, filter.From.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)))
+		assert.True(t, filter.To.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)))
+		assert.Empty(t, filter.States)
+	})
+}
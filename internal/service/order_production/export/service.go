@@ -0,0 +1,56 @@
+package export
+
+import (
+	"context"
+	"io"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+)
+
+type Service struct {
+	repository repository.OrderProductionRepository
+	storeId    string
+}
+
+func NewService(repository repository.OrderProductionRepository, storeId string) *Service {
+	return &Service{
+		repository: repository,
+		storeId:    storeId,
+	}
+}
+
+// Handle writes the orders of the request to out while they are read from the
+// database and returns how many were written. The deployment serves a single
+// store, its id is written in every order and only the callers with access
+// to it can export the orders
+func (s *Service) Handle(ctx context.Context, request ExportOrderProductionInput, out io.Writer) (int, error) {
+	if err := request.Validate(); err != nil {
+		return 0, err
+	}
+
+	if authorization.StoreFromContext(ctx) != s.storeId {
+		return 0, custom_error.ErrStoreForbidden
+	}
+
+	filter, err := request.Filter()
+	if err != nil {
+		return 0, err
+	}
+
+	writer := newOrderWriter(request.GetFormat(), out, s.storeId)
+
+	count := 0
+
+	err = s.repository.Export(ctx, filter, func(order order_entity.Order) error {
+		count++
+		return writer.Write(order)
+	})
+	if err != nil {
+		return count, err
+	}
+
+	return count, writer.Flush()
+}
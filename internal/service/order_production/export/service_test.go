@@ -0,0 +1,194 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func yieldOrders(orders ...order_entity.Order) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		yield := args.Get(2).(func(order_entity.Order) error)
+
+		for _, order := range orders {
+			if err := yield(order); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	order := order_entity.NewOrder("order_id", now)
+	order.Items = []order_entity.Item{
+		{Id: "item_1", Name: "Hamburger", Quantity: 2, Station: "grill", Modifiers: []string{"no onions", "extra cheese"}},
+		{Id: "item_2", Name: "Soda", Quantity: 1, Station: "drinks"},
+	}
+
+	emptyOrder := order_entity.NewOrder("empty_id", now)
+
+	request := ExportOrderProductionInput{
+		From: "2024-05-01",
+		To:   "2024-05-01",
+	}
+
+	t.Run("Should write a CSV row per item", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithStore(context.Background(), "store_1")
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+
+		repository.On("Export", ctx, mock.Anything, mock.Anything).
+			Run(yieldOrders(order, emptyOrder)).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, "store_1")
+
+		out := &bytes.Buffer{}
+
+		// Act
+		count, err := service.Handle(ctx, request, out)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, 4)
+		assert.Equal(t, strings.Join(csvHeader, ","), lines[0])
+		assert.Equal(t, "store_1,order_id,Received,queue,,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z,,item_1,Hamburger,2,grill,no onions|extra cheese", lines[1])
+		assert.Equal(t, "store_1,order_id,Received,queue,,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z,,item_2,Soda,1,drinks,", lines[2])
+		assert.Equal(t, "store_1,empty_id,Received,queue,,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z,,,,,,", lines[3])
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should write the CSV header when there are no orders", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithStore(context.Background(), "store_1")
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+
+		repository.On("Export", ctx, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, "store_1")
+
+		out := &bytes.Buffer{}
+
+		// Act
+		count, err := service.Handle(ctx, request, out)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+		assert.Equal(t, strings.Join(csvHeader, ",")+"\n", out.String())
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should write an order per line as JSONL", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithStore(context.Background(), "store_1")
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+
+		repository.On("Export", ctx, mock.Anything, mock.Anything).
+			Run(yieldOrders(order, emptyOrder)).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, "store_1")
+
+		jsonl := request
+		jsonl.Format = FormatJSONL
+
+		out := &bytes.Buffer{}
+
+		// Act
+		count, err := service.Handle(ctx, jsonl, out)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, 2)
+
+		var exported map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &exported))
+		assert.Equal(t, "store_1", exported["store_id"])
+		assert.Equal(t, "order_id", exported["id"])
+		assert.Len(t, exported["items"], 2)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the request is not valid", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithStore(context.Background(), "store_1")
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+
+		service := NewService(repository, "store_1")
+
+		// Act
+		count, err := service.Handle(ctx, ExportOrderProductionInput{}, &bytes.Buffer{})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, 0, count)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the caller has no access to the store", func(t *testing.T) {
+		// Arrange
+		repository := mocks.NewMockOrderProductionRepository(t)
+
+		service := NewService(repository, "store_1")
+
+		for _, ctx := range []context.Context{
+			authorization.WithStore(context.Background(), "store_2"),
+			context.Background(),
+		} {
+			// Act
+			count, err := service.Handle(ctx, request, &bytes.Buffer{})
+
+			// Assert
+			assert.ErrorIs(t, err, custom_error.ErrStoreForbidden)
+			assert.Equal(t, 0, count)
+		}
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when repository fails", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithStore(context.Background(), "store_1")
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+
+		repository.On("Export", ctx, mock.Anything, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		service := NewService(repository, "store_1")
+
+		// Act
+		_, err := service.Handle(ctx, request, &bytes.Buffer{})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		repository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,149 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+)
+
+var csvHeader = []string{
+	"store_id",
+	"order_id",
+	"state",
+	"origin",
+	"created_by",
+	"state_updated_at",
+	"created_at",
+	"updated_at",
+	"reconciled_at",
+	"item_id",
+	"item_name",
+	"item_quantity",
+	"item_station",
+	"item_modifiers",
+}
+
+// ContentType returns the media type of the format
+func ContentType(format string) string {
+	if format == FormatJSONL {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv; charset=UTF-8"
+}
+
+type orderWriter interface {
+	Write(order order_entity.Order) error
+	Flush() error
+}
+
+func newOrderWriter(format string, out io.Writer, storeId string) orderWriter {
+	if format == FormatJSONL {
+		buffer := bufio.NewWriter(out)
+		return &jsonlWriter{
+			buffer:  buffer,
+			encoder: json.NewEncoder(buffer),
+			storeId: storeId,
+		}
+	}
+
+	return &csvWriter{
+		writer:  csv.NewWriter(out),
+		storeId: storeId,
+	}
+}
+
+// csvWriter writes a row per item, the orders without items have a single
+// row with the item columns empty. The modifiers are joined by a pipe
+type csvWriter struct {
+	writer        *csv.Writer
+	storeId       string
+	headerWritten bool
+}
+
+func (w *csvWriter) Write(order order_entity.Order) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	reconciledAt := ""
+	if order.ReconciledAt != nil {
+		reconciledAt = formatTime(*order.ReconciledAt)
+	}
+
+	row := []string{
+		w.storeId,
+		order.Id,
+		order.State.String(),
+		string(order.Origin),
+		order.CreatedBy,
+		formatTime(order.StateUpdatedAt),
+		formatTime(order.CreatedAt),
+		formatTime(order.UpdatedAt),
+		reconciledAt,
+	}
+
+	if len(order.Items) == 0 {
+		return w.writer.Write(append(row, "", "", "", "", ""))
+	}
+
+	for _, item := range order.Items {
+		itemRow := append(row[:len(row):len(row)], item.Id, item.Name, strconv.Itoa(item.Quantity), item.Station, strings.Join(item.Modifiers, "|"))
+		if err := w.writer.Write(itemRow); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *csvWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+
+	w.headerWritten = true
+	return w.writer.Write(csvHeader)
+}
+
+type exportedOrder struct {
+	StoreId string `json:"store_id,omitempty"`
+	order_entity.Order
+}
+
+// jsonlWriter writes an order with its items per line
+type jsonlWriter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+	storeId string
+}
+
+func (w *jsonlWriter) Write(order order_entity.Order) error {
+	return w.encoder.Encode(exportedOrder{
+		StoreId: w.storeId,
+		Order:   order,
+	})
+}
+
+func (w *jsonlWriter) Flush() error {
+	return w.buffer.Flush()
+}
+
+func formatTime(value time.Time) string {
+	return value.Format(time.RFC3339)
+}
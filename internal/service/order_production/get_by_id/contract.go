@@ -1,8 +1,7 @@
 package get_by_id
 
 import (
-	"github.com/go-playground/validator/v10"
-	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
 )
 
 type GetOrderProductionByIdInput struct {
@@ -10,9 +9,8 @@ type GetOrderProductionByIdInput struct {
 }
 
 func (input *GetOrderProductionByIdInput) Validate() error {
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return custom_error.ErrRequestNotValid
+	if err := validation.Struct(input); err != nil {
+		return err
 	}
 
 	return nil
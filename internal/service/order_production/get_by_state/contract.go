@@ -1,9 +1,9 @@
 package get_by_state
 
 import (
-	"github.com/go-playground/validator/v10"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
 )
 
 type GetOrderProductionByStateInput struct {
@@ -11,13 +11,12 @@ type GetOrderProductionByStateInput struct {
 }
 
 func (input *GetOrderProductionByStateInput) Validate() error {
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return custom_error.ErrRequestNotValid
+	if err := validation.Struct(input); err != nil {
+		return err
 	}
 
 	if order_entity.NewOrderState(input.State) == order_entity.None {
-		return custom_error.ErrRequestNotValid
+		return custom_error.NewValidationError(order_entity.NewStateViolation("state"))
 	}
 
 	return nil
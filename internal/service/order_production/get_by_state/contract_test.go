@@ -3,6 +3,7 @@ package get_by_state
 import (
 	"testing"
 
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,4 +46,18 @@ func TestValidate(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 	})
+
+	t.Run("Should return the state field when the state is missing", func(t *testing.T) {
+		// Arrange
+		input := GetOrderProductionByStateInput{}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, []custom_error.Violation{
+			{Field: "state", Rule: "required", Message: "is required"},
+		}, custom_error.GetViolations(err))
+	})
 }
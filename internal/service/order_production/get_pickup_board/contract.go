@@ -0,0 +1,4 @@
+package get_pickup_board
+
+// GetPickupBoardInput has no fields, the board is the same for every customer
+type GetPickupBoardInput struct{}
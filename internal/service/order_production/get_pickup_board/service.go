@@ -0,0 +1,39 @@
+package get_pickup_board
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Service struct {
+	repository   repository.OrderProductionRepository
+	timeProvider provider.TimeProvider
+	readyTtl     time.Duration
+}
+
+func NewService(
+	repository repository.OrderProductionRepository,
+	timeProvider provider.TimeProvider,
+	readyTtl time.Duration,
+) *Service {
+	return &Service{
+		repository:   repository,
+		timeProvider: timeProvider,
+		readyTtl:     readyTtl,
+	}
+}
+
+func (s *Service) Handle(ctx context.Context, request GetPickupBoardInput) (order_entity.PickupBoard, error) {
+	now := s.timeProvider.GetTime()
+
+	orders, err := s.repository.GetPickupBoard(ctx, now.Add(-s.readyTtl))
+	if err != nil {
+		return order_entity.PickupBoard{}, err
+	}
+
+	return order_entity.NewPickupBoard(orders), nil
+}
@@ -0,0 +1,72 @@
+package get_pickup_board
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should return the pickup board", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		repository.On("GetPickupBoard", ctx, now.Add(-15*time.Minute)).
+			Return([]order_entity.Order{
+				{Id: "c3fdab1b-3c06", State: order_entity.Processing, StateUpdatedAt: now},
+				{Id: "0a1b2c3d-3c06", State: order_entity.Completed, StateUpdatedAt: now},
+			}, nil).
+			Once()
+
+		service := NewService(repository, timeProvider, 15*time.Minute)
+
+		// Act
+		res, err := service.Handle(ctx, GetPickupBoardInput{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, res.Preparing, 1)
+		assert.Len(t, res.Ready, 1)
+		assert.Equal(t, "C3FDAB", res.Preparing[0].Code)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the repository fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		repository := mocks.NewMockOrderProductionRepository(t)
+		repository.On("GetPickupBoard", ctx, now.Add(-15*time.Minute)).
+			Return(nil, assert.AnError).
+			Once()
+
+		service := NewService(repository, timeProvider, 15*time.Minute)
+
+		// Act
+		_, err := service.Handle(ctx, GetPickupBoardInput{})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+}
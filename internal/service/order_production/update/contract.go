@@ -1,9 +1,9 @@
 package update
 
 import (
-	"github.com/go-playground/validator/v10"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
 )
 
 type UpdateOrderProductionInput struct {
@@ -13,13 +13,12 @@ type UpdateOrderProductionInput struct {
 }
 
 func (input *UpdateOrderProductionInput) Validate() error {
-	validator := validator.New()
-	if err := validator.Struct(input); err != nil {
-		return custom_error.ErrRequestNotValid
+	if err := validation.Struct(input); err != nil {
+		return err
 	}
 
 	if order_entity.NewOrderState(input.State) == order_entity.None {
-		return custom_error.ErrRequestNotValid
+		return custom_error.NewValidationError(order_entity.NewStateViolation("state"))
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,4 +50,21 @@ func TestValidate(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 	})
+
+	t.Run("Should return the state field when the state is invalid", func(t *testing.T) {
+		// Arrange
+		input := UpdateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			State:   "invalid",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, []custom_error.Violation{
+			{Field: "state", Rule: "state", Message: "must be one of: Received, Processing, Completed, Delivered, Cancelled"},
+		}, custom_error.GetViolations(err))
+	})
 }
@@ -3,23 +3,36 @@ package update
 import (
 	"context"
 
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/metrics"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
 )
 
 type Service struct {
 	repository   repository.OrderProductionRepository
+	transactor   repository.Transactor
 	timeProvider provider.TimeProvider
+	policy       *authorization.Policy
+	recorder     audit.Recorder
 }
 
 func NewService(
 	repository repository.OrderProductionRepository,
+	transactor repository.Transactor,
 	timeProvider provider.TimeProvider,
+	policy *authorization.Policy,
+	recorder audit.Recorder,
 ) *Service {
 	return &Service{
 		repository:   repository,
+		transactor:   transactor,
 		timeProvider: timeProvider,
+		policy:       policy,
+		recorder:     recorder,
 	}
 }
 
@@ -33,15 +46,30 @@ func (s *Service) Handle(ctx context.Context, request UpdateOrderProductionInput
 		return nil, err
 	}
 
+	before := order
+
 	newState := order_entity.NewOrderState(request.State)
 
 	if err := order.UpdateState(newState, s.timeProvider.GetTime()); err != nil {
 		return nil, err
 	}
 
-	if err := s.repository.Update(ctx, &order); err != nil {
+	if err := s.policy.AuthorizeTransition(ctx, order.PreviousState, order.State); err != nil {
 		return nil, err
 	}
 
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.Update(ctx, &order); err != nil {
+			return err
+		}
+
+		return s.recorder.Record(ctx, audit_entity.OrderUpdateAction(order.State), audit_entity.OrderResource, order.Id, before, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObserveStateDuration(before.State.String(), before.StateUpdatedAt, order.StateUpdatedAt)
+
 	return &order, nil
 }
@@ -6,36 +6,56 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
 	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
 	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/authorization"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// withinTransaction runs the function as the transactor does, the transaction
+// itself is tested with the repositories
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
 func TestHandle(t *testing.T) {
 	t.Run("Should update order", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.ManagerRole)
 
 		now := time.Now()
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
 		repository.On("GetByID", ctx, mock.Anything).
 			Return(order_entity.Order{}, nil).
 			Once()
 
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
 		repository.On("Update", ctx, mock.Anything).
 			Return(nil).
 			Once()
 
+		recorder.On("Record", ctx, audit_entity.OrderStateChangedAction, audit_entity.OrderResource, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
 		timeProvider.On("GetTime").
 			Return(now).
 			Once()
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := UpdateOrderProductionInput{
 			OrderId: uuid.NewString(),
@@ -49,17 +69,66 @@ func TestHandle(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, order)
 		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
 		timeProvider.AssertExpectations(t)
 	})
 
+	t.Run("Should return error when the audit entry cannot be saved", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.ManagerRole)
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetByID", ctx, mock.Anything).
+			Return(order_entity.Order{}, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("Update", ctx, mock.Anything).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.OrderStateChangedAction, audit_entity.OrderResource, mock.Anything, mock.Anything, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := UpdateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			State:   "Received",
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, order)
+		transactor.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+	})
+
 	t.Run("Should return error when request is invalid", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.ManagerRole)
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := UpdateOrderProductionInput{
 			OrderId: "order-id",
@@ -78,16 +147,18 @@ func TestHandle(t *testing.T) {
 
 	t.Run("Should return error when try to get the order", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.ManagerRole)
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
 		repository.On("GetByID", ctx, mock.Anything).
 			Return(order_entity.Order{}, assert.AnError).
 			Once()
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := UpdateOrderProductionInput{
 			OrderId: uuid.NewString(),
@@ -106,12 +177,14 @@ func TestHandle(t *testing.T) {
 
 	t.Run("Should return error when try to update the order state", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.ManagerRole)
 
 		now := time.Now()
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
 		repository.On("GetByID", ctx, mock.Anything).
 			Return(order_entity.Order{
@@ -123,7 +196,7 @@ func TestHandle(t *testing.T) {
 			Return(now).
 			Once()
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := UpdateOrderProductionInput{
 			OrderId: uuid.NewString(),
@@ -142,17 +215,23 @@ func TestHandle(t *testing.T) {
 
 	t.Run("Should update order", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorization.WithRoles(context.Background(), authorization.ManagerRole)
 
 		now := time.Now()
 
 		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
 		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
 
 		repository.On("GetByID", ctx, mock.Anything).
 			Return(order_entity.Order{}, nil).
 			Once()
 
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
 		repository.On("Update", ctx, mock.Anything).
 			Return(assert.AnError).
 			Once()
@@ -161,7 +240,7 @@ func TestHandle(t *testing.T) {
 			Return(now).
 			Once()
 
-		service := NewService(repository, timeProvider)
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
 
 		req := UpdateOrderProductionInput{
 			OrderId: uuid.NewString(),
@@ -177,4 +256,77 @@ func TestHandle(t *testing.T) {
 		repository.AssertExpectations(t)
 		timeProvider.AssertExpectations(t)
 	})
+
+	t.Run("Should return error when the roles do not allow the transition", func(t *testing.T) {
+		// Arrange
+		ctx := authorization.WithRoles(context.Background(), authorization.KitchenRole)
+
+		now := time.Now()
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetByID", ctx, mock.Anything).
+			Return(order_entity.Order{State: order_entity.Processing}, nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := UpdateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			State:   "Cancelled",
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrOrderTransitionForbidden)
+		assert.Nil(t, order)
+		repository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when the context has no roles", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		now := time.Now()
+
+		repository := repository_mocks.NewMockOrderProductionRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetByID", ctx, mock.Anything).
+			Return(order_entity.Order{State: order_entity.Received}, nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, authorization.DefaultPolicy(), recorder)
+
+		req := UpdateOrderProductionInput{
+			OrderId: uuid.NewString(),
+			State:   "Processing",
+		}
+
+		// Act
+		order, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrOrderTransitionForbidden)
+		assert.Nil(t, order)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
 }
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"io"
 
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/api_key_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
 	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
 )
 
 type CreateOrderProductionService[T any] interface {
@@ -18,6 +22,62 @@ type GetOrderProductionByStateService[T any] interface {
 	Handle(ctx context.Context, request T) ([]order_entity.Order, error)
 }
 
+type ExportOrderProductionService[T any] interface {
+	Handle(ctx context.Context, request T, out io.Writer) (int, error)
+}
+
+type BulkUpdateOrderProductionService[T any] interface {
+	Handle(ctx context.Context, request T) ([]order_entity.BulkUpdateResult, error)
+}
+
+type GetPickupBoardService[T any] interface {
+	Handle(ctx context.Context, request T) (order_entity.PickupBoard, error)
+}
+
 type UpdateOrderProductionService[T any] interface {
 	Handle(ctx context.Context, request T) (*order_entity.Order, error)
 }
+
+type CreateWebhookService[T any] interface {
+	Handle(ctx context.Context, request T) (*webhook_entity.Subscription, error)
+}
+
+type ListWebhookService[T any] interface {
+	Handle(ctx context.Context, request T) ([]webhook_entity.Subscription, error)
+}
+
+type UpdateWebhookService[T any] interface {
+	Handle(ctx context.Context, request T) (*webhook_entity.Subscription, error)
+}
+
+type DeleteWebhookService[T any] interface {
+	Handle(ctx context.Context, request T) error
+}
+
+type ListWebhookDeliveriesService[T any] interface {
+	Handle(ctx context.Context, request T) ([]webhook_entity.Delivery, error)
+}
+
+type CreateApiKeyService[T any] interface {
+	Handle(ctx context.Context, request T) (*api_key_entity.ApiKey, error)
+}
+
+type ListApiKeyService[T any] interface {
+	Handle(ctx context.Context, request T) ([]api_key_entity.ApiKey, error)
+}
+
+type RotateApiKeyService[T any] interface {
+	Handle(ctx context.Context, request T) (*api_key_entity.ApiKey, error)
+}
+
+type RevokeApiKeyService[T any] interface {
+	Handle(ctx context.Context, request T) error
+}
+
+type AuthenticateApiKeyService[T any] interface {
+	Handle(ctx context.Context, request T) (*api_key_entity.ApiKey, error)
+}
+
+type ListAuditService[T any] interface {
+	Handle(ctx context.Context, request T) ([]audit_entity.Entry, error)
+}
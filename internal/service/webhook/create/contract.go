@@ -0,0 +1,21 @@
+package create
+
+import (
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+type CreateWebhookInput struct {
+	Url        string   `json:"url" validate:"required,http_url"`
+	EventTypes []string `json:"event_types" validate:"dive,oneof=production.order.created production.order.state_changed production.order.cancelled"`
+
+	// Secret is generated when not informed
+	Secret string `json:"secret" validate:"omitempty,min=16"`
+}
+
+func (input *CreateWebhookInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	return nil
+}
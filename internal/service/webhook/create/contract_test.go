@@ -0,0 +1,64 @@
+package create
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Should return nil when valid", func(t *testing.T) {
+		// Arrange
+		input := CreateWebhookInput{
+			Url:        "https://partner.com/webhooks",
+			EventTypes: []string{"production.order.created"},
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return error when url is invalid", func(t *testing.T) {
+		// Arrange
+		input := CreateWebhookInput{
+			Url: "ftp://partner.com",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when event type is unknown", func(t *testing.T) {
+		// Arrange
+		input := CreateWebhookInput{
+			Url:        "https://partner.com/webhooks",
+			EventTypes: []string{"production.order.unknown"},
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when secret is too short", func(t *testing.T) {
+		// Arrange
+		input := CreateWebhookInput{
+			Url:    "https://partner.com/webhooks",
+			Secret: "short",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+package create
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+const secretSize = 32
+
+type Service struct {
+	repository   repository.WebhookRepository
+	transactor   repository.Transactor
+	timeProvider provider.TimeProvider
+	recorder     audit.Recorder
+}
+
+func NewService(
+	repository repository.WebhookRepository,
+	transactor repository.Transactor,
+	timeProvider provider.TimeProvider,
+	recorder audit.Recorder,
+) *Service {
+	return &Service{
+		repository:   repository,
+		transactor:   transactor,
+		timeProvider: timeProvider,
+		recorder:     recorder,
+	}
+}
+
+// Handle returns the subscription with its secret, it is the only moment the
+// secret is exposed
+func (s *Service) Handle(ctx context.Context, request CreateWebhookInput) (*webhook_entity.Subscription, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription := webhook_entity.NewSubscription(
+		uuid.NewString(),
+		request.Url,
+		request.EventTypes,
+		secret,
+		s.timeProvider.GetTime(),
+	)
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.CreateSubscription(ctx, &subscription); err != nil {
+			return err
+		}
+
+		return s.recorder.Record(ctx, audit_entity.WebhookCreatedAction, audit_entity.WebhookResource, subscription.Id, nil, subscription.Snapshot())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, secretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
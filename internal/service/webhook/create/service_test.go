@@ -0,0 +1,172 @@
+package create
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withinTransaction runs the function as the transactor does, the transaction
+// itself is tested with the repositories
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should create the webhook with a generated secret", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("CreateSubscription", ctx, mock.Anything).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.WebhookCreatedAction, audit_entity.WebhookResource, mock.Anything, nil, mock.MatchedBy(func(snapshot webhook_entity.Subscription) bool {
+			return snapshot.Secret == ""
+		})).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		req := CreateWebhookInput{
+			Url: "https://partner.com/webhooks",
+		}
+
+		// Act
+		subscription, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, subscription)
+		assert.Len(t, subscription.Secret, secretSize*2)
+		assert.True(t, subscription.Active)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should keep the informed secret", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("CreateSubscription", ctx, mock.Anything).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.WebhookCreatedAction, audit_entity.WebhookResource, mock.Anything, nil, mock.Anything).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		req := CreateWebhookInput{
+			Url:    "https://partner.com/webhooks",
+			Secret: "a-very-long-partner-secret",
+		}
+
+		// Act
+		subscription, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "a-very-long-partner-secret", subscription.Secret)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when request is invalid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		req := CreateWebhookInput{}
+
+		// Act
+		subscription, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, subscription)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to create the webhook", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("CreateSubscription", ctx, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(time.Now()).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		req := CreateWebhookInput{
+			Url: "https://partner.com/webhooks",
+		}
+
+		// Act
+		subscription, err := service.Handle(ctx, req)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, subscription)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,9 @@
+package list
+
+type ListWebhookInput struct {
+	Active *bool `query:"active" json:"active"`
+}
+
+func (input *ListWebhookInput) Validate() error {
+	return nil
+}
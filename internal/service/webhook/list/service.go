@@ -0,0 +1,42 @@
+package list
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Service struct {
+	repository repository.WebhookRepository
+}
+
+func NewService(repository repository.WebhookRepository) *Service {
+	return &Service{
+		repository: repository,
+	}
+}
+
+func (s *Service) Handle(ctx context.Context, request ListWebhookInput) ([]webhook_entity.Subscription, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.repository.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]webhook_entity.Subscription, 0, len(subscriptions))
+
+	for _, subscription := range subscriptions {
+		if request.Active != nil && subscription.Active != *request.Active {
+			continue
+		}
+
+		subscription.HideSecret()
+		filtered = append(filtered, subscription)
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,91 @@
+package list
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should list the webhooks without the secrets", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+
+		repository.On("ListSubscriptions", ctx).
+			Return([]webhook_entity.Subscription{
+				webhook_entity.NewSubscription(uuid.NewString(), "https://partner.com", nil, "secret", now),
+			}, nil).
+			Once()
+
+		service := NewService(repository)
+
+		// Act
+		subscriptions, err := service.Handle(ctx, ListWebhookInput{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, subscriptions, 1)
+		assert.Empty(t, subscriptions[0].Secret)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should filter the webhooks by active", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		disabled := webhook_entity.NewSubscription(uuid.NewString(), "https://partner.com", nil, "secret", now)
+		disabled.Disable(now)
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+
+		repository.On("ListSubscriptions", ctx).
+			Return([]webhook_entity.Subscription{
+				webhook_entity.NewSubscription(uuid.NewString(), "https://partner.com", nil, "secret", now),
+				disabled,
+			}, nil).
+			Once()
+
+		service := NewService(repository)
+
+		active := false
+
+		// Act
+		subscriptions, err := service.Handle(ctx, ListWebhookInput{Active: &active})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, subscriptions, 1)
+		assert.Equal(t, disabled.Id, subscriptions[0].Id)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to list the webhooks", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+
+		repository.On("ListSubscriptions", ctx).
+			Return(nil, assert.AnError).
+			Once()
+
+		service := NewService(repository)
+
+		// Act
+		subscriptions, err := service.Handle(ctx, ListWebhookInput{})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, subscriptions)
+		repository.AssertExpectations(t)
+	})
+}
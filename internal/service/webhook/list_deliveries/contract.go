@@ -0,0 +1,20 @@
+package list_deliveries
+
+import (
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+const DefaultLimit = 50
+
+type ListWebhookDeliveriesInput struct {
+	Id    string `param:"id" json:"id" validate:"required,uuid4"`
+	Limit int    `query:"limit" json:"limit" validate:"gte=0,lte=500"`
+}
+
+func (input *ListWebhookDeliveriesInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package list_deliveries
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Service struct {
+	repository repository.WebhookRepository
+}
+
+func NewService(repository repository.WebhookRepository) *Service {
+	return &Service{
+		repository: repository,
+	}
+}
+
+func (s *Service) Handle(ctx context.Context, request ListWebhookDeliveriesInput) ([]webhook_entity.Delivery, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repository.GetSubscriptionByID(ctx, request.Id); err != nil {
+		return nil, err
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	return s.repository.ListDeliveries(ctx, request.Id, limit)
+}
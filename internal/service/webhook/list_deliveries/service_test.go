@@ -0,0 +1,79 @@
+package list_deliveries
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle(t *testing.T) {
+	t.Run("Should list the deliveries with the default limit", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		id := uuid.NewString()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+
+		repository.On("GetSubscriptionByID", ctx, id).
+			Return(webhook_entity.Subscription{Id: id}, nil).
+			Once()
+
+		repository.On("ListDeliveries", ctx, id, DefaultLimit).
+			Return([]webhook_entity.Delivery{{SubscriptionId: id}}, nil).
+			Once()
+
+		service := NewService(repository)
+
+		// Act
+		deliveries, err := service.Handle(ctx, ListWebhookDeliveriesInput{Id: id})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when webhook is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+
+		repository.On("GetSubscriptionByID", ctx, mock.Anything).
+			Return(webhook_entity.Subscription{}, custom_error.ErrWebhookNotFound).
+			Once()
+
+		service := NewService(repository)
+
+		// Act
+		deliveries, err := service.Handle(ctx, ListWebhookDeliveriesInput{Id: uuid.NewString()})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrWebhookNotFound)
+		assert.Nil(t, deliveries)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when limit is too high", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+
+		service := NewService(repository)
+
+		// Act
+		deliveries, err := service.Handle(ctx, ListWebhookDeliveriesInput{Id: uuid.NewString(), Limit: 1000})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, deliveries)
+		repository.AssertExpectations(t)
+	})
+}
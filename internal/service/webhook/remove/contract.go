@@ -0,0 +1,17 @@
+package remove
+
+import (
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+type DeleteWebhookInput struct {
+	Id string `param:"id" json:"id" validate:"required,uuid4"`
+}
+
+func (input *DeleteWebhookInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package remove
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Service struct {
+	repository repository.WebhookRepository
+	transactor repository.Transactor
+	recorder   audit.Recorder
+}
+
+func NewService(
+	repository repository.WebhookRepository,
+	transactor repository.Transactor,
+	recorder audit.Recorder,
+) *Service {
+	return &Service{
+		repository: repository,
+		transactor: transactor,
+		recorder:   recorder,
+	}
+}
+
+func (s *Service) Handle(ctx context.Context, request DeleteWebhookInput) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	subscription, err := s.repository.GetSubscriptionByID(ctx, request.Id)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.DeleteSubscription(ctx, request.Id); err != nil {
+			return err
+		}
+
+		return s.recorder.Record(ctx, audit_entity.WebhookDeletedAction, audit_entity.WebhookResource, subscription.Id, subscription.Snapshot(), nil)
+	})
+}
@@ -0,0 +1,137 @@
+package remove
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withinTransaction runs the function as the transactor does, the transaction
+// itself is tested with the repositories
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should delete the webhook", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		subscription := webhook_entity.NewSubscription(uuid.NewString(), "https://partner.com", nil, "secret", time.Now())
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetSubscriptionByID", ctx, subscription.Id).
+			Return(subscription, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("DeleteSubscription", ctx, subscription.Id).
+			Return(nil).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.WebhookDeletedAction, audit_entity.WebhookResource, subscription.Id, mock.MatchedBy(func(before webhook_entity.Subscription) bool {
+			return before.Url == subscription.Url && before.Secret == ""
+		}), nil).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, recorder)
+
+		// Act
+		err := service.Handle(ctx, DeleteWebhookInput{Id: subscription.Id})
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when webhook is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		id := uuid.NewString()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetSubscriptionByID", ctx, id).
+			Return(webhook_entity.Subscription{}, custom_error.ErrWebhookNotFound).
+			Once()
+
+		service := NewService(repository, transactor, recorder)
+
+		// Act
+		err := service.Handle(ctx, DeleteWebhookInput{Id: id})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrWebhookNotFound)
+		repository.AssertExpectations(t)
+		repository.AssertNotCalled(t, "DeleteSubscription", mock.Anything, mock.Anything)
+		recorder.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to delete the webhook", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		subscription := webhook_entity.NewSubscription(uuid.NewString(), "https://partner.com", nil, "secret", time.Now())
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetSubscriptionByID", ctx, subscription.Id).
+			Return(subscription, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("DeleteSubscription", ctx, subscription.Id).
+			Return(assert.AnError).
+			Once()
+
+		service := NewService(repository, transactor, recorder)
+
+		// Act
+		err := service.Handle(ctx, DeleteWebhookInput{Id: subscription.Id})
+
+		// Assert
+		assert.Error(t, err)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when request is invalid", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		service := NewService(repository, transactor, recorder)
+
+		// Act
+		err := service.Handle(ctx, DeleteWebhookInput{Id: "123"})
+
+		// Assert
+		assert.Error(t, err)
+		repository.AssertExpectations(t)
+	})
+}
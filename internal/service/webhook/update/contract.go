@@ -0,0 +1,21 @@
+package update
+
+import (
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/validation"
+)
+
+type UpdateWebhookInput struct {
+	Id string `param:"id" json:"id" validate:"required,uuid4"`
+
+	Url        *string   `json:"url" validate:"omitempty,http_url"`
+	EventTypes *[]string `json:"event_types" validate:"omitempty,dive,oneof=production.order.created production.order.state_changed production.order.cancelled"`
+	Active     *bool     `json:"active"`
+}
+
+func (input *UpdateWebhookInput) Validate() error {
+	if err := validation.Struct(input); err != nil {
+		return err
+	}
+
+	return nil
+}
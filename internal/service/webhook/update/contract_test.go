@@ -0,0 +1,57 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Should return nil when valid", func(t *testing.T) {
+		// Arrange
+		url := "https://partner.com/webhooks"
+		eventTypes := []string{"production.order.cancelled"}
+
+		input := UpdateWebhookInput{
+			Id:         uuid.NewString(),
+			Url:        &url,
+			EventTypes: &eventTypes,
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return error when id is invalid", func(t *testing.T) {
+		// Arrange
+		input := UpdateWebhookInput{
+			Id: "123",
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return error when event type is unknown", func(t *testing.T) {
+		// Arrange
+		eventTypes := []string{"unknown"}
+
+		input := UpdateWebhookInput{
+			Id:         uuid.NewString(),
+			EventTypes: &eventTypes,
+		}
+
+		// Act
+		err := input.Validate()
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
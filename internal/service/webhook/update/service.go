@@ -0,0 +1,80 @@
+package update
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/repository"
+)
+
+type Service struct {
+	repository   repository.WebhookRepository
+	transactor   repository.Transactor
+	timeProvider provider.TimeProvider
+	recorder     audit.Recorder
+}
+
+func NewService(
+	repository repository.WebhookRepository,
+	transactor repository.Transactor,
+	timeProvider provider.TimeProvider,
+	recorder audit.Recorder,
+) *Service {
+	return &Service{
+		repository:   repository,
+		transactor:   transactor,
+		timeProvider: timeProvider,
+		recorder:     recorder,
+	}
+}
+
+func (s *Service) Handle(ctx context.Context, request UpdateWebhookInput) (*webhook_entity.Subscription, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.repository.GetSubscriptionByID(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := subscription.Snapshot()
+
+	now := s.timeProvider.GetTime()
+
+	if request.Url != nil {
+		subscription.Url = *request.Url
+	}
+
+	if request.EventTypes != nil {
+		subscription.EventTypes = *request.EventTypes
+	}
+
+	if request.Active != nil {
+		if *request.Active {
+			subscription.Enable(now)
+		} else {
+			subscription.Disable(now)
+		}
+	}
+
+	subscription.UpdatedAt = now
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.UpdateSubscription(ctx, &subscription); err != nil {
+			return err
+		}
+
+		subscription.HideSecret()
+
+		return s.recorder.Record(ctx, audit_entity.WebhookUpdatedAction, audit_entity.WebhookResource, subscription.Id, before, subscription)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
@@ -0,0 +1,152 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	audit_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/adapter/audit/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/audit_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/webhook_entity"
+	provider_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	repository_mocks "github.com/jfelipearaujo-org/ms-production-management/internal/repository/mocks"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withinTransaction runs the function as the transactor does, the transaction
+// itself is tested with the repositories
+func withinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestHandle(t *testing.T) {
+	t.Run("Should enable a disabled webhook", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		subscription := webhook_entity.NewSubscription(uuid.NewString(), "https://partner.com", nil, "secret", now)
+		subscription.RegisterFailure(1, now)
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetSubscriptionByID", ctx, subscription.Id).
+			Return(subscription, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("UpdateSubscription", ctx, mock.Anything).
+			Return(nil).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		recorder.On("Record", ctx, audit_entity.WebhookUpdatedAction, audit_entity.WebhookResource, subscription.Id, mock.MatchedBy(func(before webhook_entity.Subscription) bool {
+			return !before.Active && before.Secret == ""
+		}), mock.Anything).
+			Return(nil).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		active := true
+
+		// Act
+		res, err := service.Handle(ctx, UpdateWebhookInput{
+			Id:     subscription.Id,
+			Active: &active,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, res.Active)
+		assert.Equal(t, 0, res.ConsecutiveFailures)
+		assert.Empty(t, res.Secret)
+		repository.AssertExpectations(t)
+		recorder.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when webhook is not found", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetSubscriptionByID", ctx, mock.Anything).
+			Return(webhook_entity.Subscription{}, custom_error.ErrWebhookNotFound).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		// Act
+		res, err := service.Handle(ctx, UpdateWebhookInput{
+			Id: uuid.NewString(),
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrWebhookNotFound)
+		assert.Nil(t, res)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when try to update the webhook", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		now := time.Now()
+
+		subscription := webhook_entity.NewSubscription(uuid.NewString(), "https://partner.com", nil, "secret", now)
+
+		repository := repository_mocks.NewMockWebhookRepository(t)
+		transactor := repository_mocks.NewMockTransactor(t)
+		timeProvider := provider_mocks.NewMockTimeProvider(t)
+		recorder := audit_mocks.NewMockRecorder(t)
+
+		repository.On("GetSubscriptionByID", ctx, subscription.Id).
+			Return(subscription, nil).
+			Once()
+
+		transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+			Return(withinTransaction).
+			Once()
+
+		repository.On("UpdateSubscription", ctx, mock.Anything).
+			Return(assert.AnError).
+			Once()
+
+		timeProvider.On("GetTime").
+			Return(now).
+			Once()
+
+		service := NewService(repository, transactor, timeProvider, recorder)
+
+		url := "https://other.com"
+
+		// Act
+		res, err := service.Handle(ctx, UpdateWebhookInput{
+			Id:  subscription.Id,
+			Url: &url,
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		repository.AssertExpectations(t)
+		timeProvider.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,42 @@
+package authorization
+
+import "context"
+
+type rolesKey struct{}
+
+type storeKey struct{}
+
+// WithRoles stores the roles of the author of the changes in the context
+func WithRoles(ctx context.Context, roles ...Role) context.Context {
+	return context.WithValue(ctx, rolesKey{}, roles)
+}
+
+// RolesFromContext returns the roles stored by WithRoles, none when the
+// context has no author
+func RolesFromContext(ctx context.Context) []Role {
+	roles, _ := ctx.Value(rolesKey{}).([]Role)
+	return roles
+}
+
+// HasRole reports if the author of the changes has the role
+func HasRole(ctx context.Context, role Role) bool {
+	for _, r := range RolesFromContext(ctx) {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithStore stores the store the author of the changes has access to, empty
+// when the author is not scoped to a store
+func WithStore(ctx context.Context, storeId string) context.Context {
+	return context.WithValue(ctx, storeKey{}, storeId)
+}
+
+// StoreFromContext returns the store stored by WithStore
+func StoreFromContext(ctx context.Context) string {
+	storeId, _ := ctx.Value(storeKey{}).(string)
+	return storeId
+}
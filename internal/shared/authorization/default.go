@@ -0,0 +1,75 @@
+package authorization
+
+const grpcService = "/production.v1.ProductionService/"
+
+var (
+	readEndpoints = []string{
+		"GET /production",
+		"GET /production/:id",
+		"GET /production/:id/ticket",
+		"GET /production/states",
+		"GET /production/stream",
+		"GET /production/ws",
+		GrpcMethod + " " + grpcService + "GetOrder",
+		GrpcMethod + " " + grpcService + "ListOrders",
+		GrpcMethod + " " + grpcService + "WatchOrders",
+	}
+
+	updateEndpoints = []string{
+		"PATCH /production",
+		"PATCH /production/:id",
+		GrpcMethod + " " + grpcService + "UpdateOrderState",
+	}
+)
+
+// defaultPolicy lets the kitchen prepare the orders, the counter create, hand
+// over and cancel the orders not started yet, and the manager reach every
+// endpoint and do every transition but the hand over, only done at the counter
+var defaultPolicy = PolicyDocument{
+	Roles: map[Role]Rule{
+		KitchenRole: {
+			Transitions: []string{"Received -> Processing", "Processing -> Completed"},
+			Endpoints:   concat(readEndpoints, updateEndpoints),
+		},
+		ExpeditorRole: {
+			Transitions: []string{"Processing -> Completed"},
+			Endpoints:   concat(readEndpoints, updateEndpoints),
+		},
+		CounterRole: {
+			Transitions: []string{"None -> Received", "Completed -> Delivered", "Received -> Cancelled"},
+			Endpoints:   concat(readEndpoints, updateEndpoints, []string{"POST /production"}),
+		},
+		ManagerRole: {
+			Transitions: []string{
+				"None -> Received",
+				"Received -> Processing",
+				"Processing -> Completed",
+				"Received -> Cancelled",
+				"Processing -> Cancelled",
+			},
+			Endpoints: []string{"* *"},
+		},
+		ServiceRole: {
+			Transitions: []string{"None -> Received"},
+			Endpoints:   readEndpoints,
+		},
+	},
+}
+
+func DefaultPolicy() *Policy {
+	policy, err := NewPolicy(defaultPolicy)
+	if err != nil {
+		panic(err)
+	}
+
+	return policy
+}
+
+func concat(lists ...[]string) []string {
+	res := make([]string, 0)
+	for _, list := range lists {
+		res = append(res, list...)
+	}
+
+	return res
+}
@@ -0,0 +1,26 @@
+package authorization
+
+import (
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+)
+
+// Middleware allows the request when the roles of the token can use the
+// route, the prefix (e.g. /api/v1) is removed from the route before checking
+// the policy. It must run after the token middleware
+func Middleware(policy *Policy, prefix string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			roles := RolesFromContext(c.Request().Context())
+			path := strings.TrimPrefix(c.Path(), prefix)
+
+			if !policy.CanAccess(roles, c.Request().Method, path) {
+				return custom_error.NewHttpAppErrorFromBusinessError(custom_error.ErrAccessDenied)
+			}
+
+			return next(c)
+		}
+	}
+}
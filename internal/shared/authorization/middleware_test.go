@@ -0,0 +1,68 @@
+package authorization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	newServer := func(roles ...Role) *echo.Echo {
+		e := echo.New()
+
+		group := e.Group("/api/v1", func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				c.SetRequest(c.Request().WithContext(WithRoles(c.Request().Context(), roles...)))
+				return next(c)
+			}
+		}, Middleware(DefaultPolicy(), "/api/v1"))
+
+		group.GET("/production/:id", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+		group.GET("/admin/webhooks", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+		return e
+	}
+
+	t.Run("Should allow the roles of the route", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/production/123", nil)
+		res := httptest.NewRecorder()
+
+		// Act
+		newServer(KitchenRole).ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("Should forbid the roles that cannot use the route", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/webhooks", nil)
+		res := httptest.NewRecorder()
+
+		// Act
+		newServer(KitchenRole).ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+
+	t.Run("Should forbid the requests without roles", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/production/123", nil)
+		res := httptest.NewRecorder()
+
+		// Act
+		newServer().ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, res.Code)
+	})
+}
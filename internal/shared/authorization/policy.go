@@ -0,0 +1,226 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"gopkg.in/yaml.v3"
+)
+
+type Role string
+
+const (
+	KitchenRole   Role = "kitchen"
+	ExpeditorRole Role = "expeditor"
+	CounterRole   Role = "counter"
+	ManagerRole   Role = "manager"
+	// ServiceRole is the role of the changes made by the service itself, e.g.
+	// the orders received through the queue
+	ServiceRole Role = "service"
+)
+
+// GrpcMethod is the method of the gRPC endpoints of the policy, their path is
+// the full method name, e.g. GRPC /production.v1.ProductionService/GetOrder
+const GrpcMethod = "GRPC"
+
+const wildcard = "*"
+
+// Rule lists what a role is allowed to do. Transitions are written as
+// "From -> To", where None is the state of an order that does not exist yet
+// and * matches any state. Endpoints are written as "METHOD /path", with the
+// path of the route without the API version, * matches any method and a path
+// ending with * matches any path with the prefix
+type Rule struct {
+	Transitions []string `yaml:"transitions"`
+	Endpoints   []string `yaml:"endpoints"`
+}
+
+// PolicyDocument is the format of the policy file
+type PolicyDocument struct {
+	Roles map[Role]Rule `yaml:"roles"`
+}
+
+type transition struct {
+	from order_entity.OrderState
+	to   order_entity.OrderState
+}
+
+type endpoint struct {
+	method string
+	path   string
+	prefix bool
+}
+
+func (e endpoint) matches(method string, path string) bool {
+	if e.method != wildcard && !strings.EqualFold(e.method, method) {
+		return false
+	}
+
+	if e.prefix {
+		return strings.HasPrefix(path, e.path)
+	}
+
+	return e.path == path
+}
+
+// Policy maps the roles to the transitions and endpoints they are allowed to
+// use, a role that is not in the policy is not allowed to do anything
+type Policy struct {
+	transitions map[Role]map[transition]struct{}
+	endpoints   map[Role][]endpoint
+}
+
+func NewPolicy(document PolicyDocument) (*Policy, error) {
+	policy := &Policy{
+		transitions: make(map[Role]map[transition]struct{}, len(document.Roles)),
+		endpoints:   make(map[Role][]endpoint, len(document.Roles)),
+	}
+
+	for role, rule := range document.Roles {
+		policy.transitions[role] = make(map[transition]struct{})
+
+		for _, text := range rule.Transitions {
+			transitions, err := parseTransition(text)
+			if err != nil {
+				return nil, fmt.Errorf("role %s: %w", role, err)
+			}
+
+			for _, t := range transitions {
+				policy.transitions[role][t] = struct{}{}
+			}
+		}
+
+		for _, text := range rule.Endpoints {
+			endpoint, err := parseEndpoint(text)
+			if err != nil {
+				return nil, fmt.Errorf("role %s: %w", role, err)
+			}
+
+			policy.endpoints[role] = append(policy.endpoints[role], endpoint)
+		}
+	}
+
+	return policy, nil
+}
+
+// ParsePolicy reads the policy from a YAML document
+func ParsePolicy(data []byte) (*Policy, error) {
+	var document PolicyDocument
+
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("error parsing the policy: %w", err)
+	}
+
+	return NewPolicy(document)
+}
+
+// LoadPolicy reads the policy file, the default policy is used when the path
+// is empty
+func LoadPolicy(path string) (*Policy, error) {
+	if path == "" {
+		return DefaultPolicy(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the policy: %w", err)
+	}
+
+	return ParsePolicy(data)
+}
+
+// CanTransition reports if any of the roles is allowed to move an order from
+// a state to the other
+func (p *Policy) CanTransition(roles []Role, from order_entity.OrderState, to order_entity.OrderState) bool {
+	for _, role := range roles {
+		if _, ok := p.transitions[role][transition{from: from, to: to}]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanAccess reports if any of the roles is allowed to use the endpoint
+func (p *Policy) CanAccess(roles []Role, method string, path string) bool {
+	for _, role := range roles {
+		for _, endpoint := range p.endpoints[role] {
+			if endpoint.matches(method, path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// AuthorizeTransition checks the transition against the roles of the context,
+// it is called by the services so every entry point applies the same rules
+func (p *Policy) AuthorizeTransition(ctx context.Context, from order_entity.OrderState, to order_entity.OrderState) error {
+	if !p.CanTransition(RolesFromContext(ctx), from, to) {
+		return custom_error.ErrOrderTransitionForbidden
+	}
+
+	return nil
+}
+
+func parseTransition(text string) ([]transition, error) {
+	from, to, ok := strings.Cut(text, "->")
+	if !ok {
+		return nil, fmt.Errorf("invalid transition %q, expected \"From -> To\"", text)
+	}
+
+	fromStates, err := parseStates(strings.TrimSpace(from))
+	if err != nil {
+		return nil, err
+	}
+
+	toStates, err := parseStates(strings.TrimSpace(to))
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([]transition, 0, len(fromStates)*len(toStates))
+	for _, from := range fromStates {
+		for _, to := range toStates {
+			transitions = append(transitions, transition{from: from, to: to})
+		}
+	}
+
+	return transitions, nil
+}
+
+func parseStates(title string) ([]order_entity.OrderState, error) {
+	states := make([]order_entity.OrderState, 0)
+
+	for state := order_entity.None; state <= order_entity.Cancelled; state++ {
+		if title == wildcard || state.String() == title {
+			states = append(states, state)
+		}
+	}
+
+	if len(states) == 0 {
+		return nil, fmt.Errorf("invalid state %q", title)
+	}
+
+	return states, nil
+}
+
+func parseEndpoint(text string) (endpoint, error) {
+	fields := strings.Fields(text)
+	if len(fields) != 2 || !strings.HasPrefix(fields[1], "/") && fields[1] != wildcard {
+		return endpoint{}, fmt.Errorf("invalid endpoint %q, expected \"METHOD /path\"", text)
+	}
+
+	path, prefix := strings.CutSuffix(fields[1], wildcard)
+
+	return endpoint{
+		method: strings.ToUpper(fields[0]),
+		path:   path,
+		prefix: prefix,
+	}, nil
+}
@@ -0,0 +1,224 @@
+package authorization
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultPolicy(t *testing.T) {
+	policy := DefaultPolicy()
+
+	t.Run("Should only allow the counter to deliver the orders", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.True(t, policy.CanTransition([]Role{CounterRole}, order_entity.Completed, order_entity.Delivered))
+		assert.False(t, policy.CanTransition([]Role{ManagerRole}, order_entity.Completed, order_entity.Delivered))
+		assert.False(t, policy.CanTransition([]Role{KitchenRole}, order_entity.Completed, order_entity.Delivered))
+		assert.False(t, policy.CanTransition([]Role{ExpeditorRole}, order_entity.Completed, order_entity.Delivered))
+		assert.False(t, policy.CanTransition([]Role{ServiceRole}, order_entity.Completed, order_entity.Delivered))
+	})
+
+	t.Run("Should only allow the manager to cancel the orders being processed", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.True(t, policy.CanTransition([]Role{ManagerRole}, order_entity.Processing, order_entity.Cancelled))
+		assert.False(t, policy.CanTransition([]Role{CounterRole}, order_entity.Processing, order_entity.Cancelled))
+		assert.False(t, policy.CanTransition([]Role{KitchenRole}, order_entity.Processing, order_entity.Cancelled))
+	})
+
+	t.Run("Should allow the manager every other transition of the state machine", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.True(t, policy.CanTransition([]Role{ManagerRole}, order_entity.None, order_entity.Received))
+		assert.True(t, policy.CanTransition([]Role{ManagerRole}, order_entity.Received, order_entity.Processing))
+		assert.True(t, policy.CanTransition([]Role{ManagerRole}, order_entity.Processing, order_entity.Completed))
+		assert.True(t, policy.CanTransition([]Role{ManagerRole}, order_entity.Received, order_entity.Cancelled))
+	})
+
+	t.Run("Should allow the service to create the orders", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.True(t, policy.CanTransition([]Role{ServiceRole}, order_entity.None, order_entity.Received))
+		assert.False(t, policy.CanTransition([]Role{ServiceRole}, order_entity.Received, order_entity.Processing))
+	})
+
+	t.Run("Should allow any of the roles", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.True(t, policy.CanTransition([]Role{KitchenRole, CounterRole}, order_entity.Completed, order_entity.Delivered))
+	})
+
+	t.Run("Should not allow unknown roles or no roles", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.False(t, policy.CanTransition([]Role{"cashier"}, order_entity.Received, order_entity.Processing))
+		assert.False(t, policy.CanTransition(nil, order_entity.Received, order_entity.Processing))
+		assert.False(t, policy.CanAccess(nil, "GET", "/production"))
+	})
+
+	t.Run("Should only allow the manager to access the admin endpoints", func(t *testing.T) {
+		// Arrange
+		// Act
+		// Assert
+		assert.True(t, policy.CanAccess([]Role{ManagerRole}, "GET", "/admin/webhooks"))
+		assert.False(t, policy.CanAccess([]Role{KitchenRole}, "GET", "/admin/webhooks"))
+		assert.False(t, policy.CanAccess([]Role{CounterRole}, "GET", "/production/export"))
+		assert.True(t, policy.CanAccess([]Role{KitchenRole}, "PATCH", "/production/:id"))
+		assert.False(t, policy.CanAccess([]Role{KitchenRole}, "POST", "/production"))
+		assert.True(t, policy.CanAccess([]Role{CounterRole}, "POST", "/production"))
+		assert.True(t, policy.CanAccess([]Role{KitchenRole}, GrpcMethod, "/production.v1.ProductionService/GetOrder"))
+	})
+}
+
+func TestParsePolicy(t *testing.T) {
+	t.Run("Should parse the wildcards of the transitions and endpoints", func(t *testing.T) {
+		// Arrange
+		data := []byte(`
+roles:
+  supervisor:
+    transitions:
+      - "* -> Cancelled"
+    endpoints:
+      - "GET /production*"
+      - "* /admin/webhooks"
+`)
+
+		// Act
+		policy, err := ParsePolicy(data)
+
+		// Assert
+		assert.NoError(t, err)
+
+		roles := []Role{"supervisor"}
+		assert.True(t, policy.CanTransition(roles, order_entity.Received, order_entity.Cancelled))
+		assert.True(t, policy.CanTransition(roles, order_entity.Processing, order_entity.Cancelled))
+		assert.False(t, policy.CanTransition(roles, order_entity.Completed, order_entity.Delivered))
+
+		assert.True(t, policy.CanAccess(roles, "GET", "/production"))
+		assert.True(t, policy.CanAccess(roles, "get", "/production/:id/ticket"))
+		assert.False(t, policy.CanAccess(roles, "PATCH", "/production/:id"))
+		assert.True(t, policy.CanAccess(roles, "DELETE", "/admin/webhooks"))
+		assert.False(t, policy.CanAccess(roles, "DELETE", "/admin/webhooks/:id"))
+	})
+
+	t.Run("Should return error when the transition is invalid", func(t *testing.T) {
+		// Arrange
+		data := []byte(`
+roles:
+  kitchen:
+    transitions:
+      - "Received to Processing"
+`)
+
+		// Act
+		_, err := ParsePolicy(data)
+
+		// Assert
+		assert.ErrorContains(t, err, "role kitchen: invalid transition")
+	})
+
+	t.Run("Should return error when the state is invalid", func(t *testing.T) {
+		// Arrange
+		data := []byte(`
+roles:
+  kitchen:
+    transitions:
+      - "Received -> Cooking"
+`)
+
+		// Act
+		_, err := ParsePolicy(data)
+
+		// Assert
+		assert.ErrorContains(t, err, `invalid state "Cooking"`)
+	})
+
+	t.Run("Should return error when the endpoint is invalid", func(t *testing.T) {
+		// Arrange
+		data := []byte(`
+roles:
+  kitchen:
+    endpoints:
+      - "/production"
+`)
+
+		// Act
+		_, err := ParsePolicy(data)
+
+		// Assert
+		assert.ErrorContains(t, err, "role kitchen: invalid endpoint")
+	})
+
+	t.Run("Should return error when the document is not YAML", func(t *testing.T) {
+		// Arrange
+		// Act
+		_, err := ParsePolicy([]byte("roles: ["))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Run("Should return the default policy when the path is empty", func(t *testing.T) {
+		// Arrange
+		// Act
+		policy, err := LoadPolicy("")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, policy.CanTransition([]Role{CounterRole}, order_entity.Completed, order_entity.Delivered))
+	})
+
+	t.Run("Should load the policy file", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		err := os.WriteFile(path, []byte("roles:\n  kitchen:\n    transitions: [\"Completed -> Delivered\"]\n"), 0o600)
+		assert.NoError(t, err)
+
+		// Act
+		policy, err := LoadPolicy(path)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, policy.CanTransition([]Role{KitchenRole}, order_entity.Completed, order_entity.Delivered))
+		assert.False(t, policy.CanTransition([]Role{CounterRole}, order_entity.Completed, order_entity.Delivered))
+	})
+
+	t.Run("Should return error when the file does not exist", func(t *testing.T) {
+		// Arrange
+		// Act
+		_, err := LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthorizeTransition(t *testing.T) {
+	t.Run("Should use the roles of the context", func(t *testing.T) {
+		// Arrange
+		policy := DefaultPolicy()
+		ctx := WithRoles(context.Background(), CounterRole)
+
+		// Act
+		allowed := policy.AuthorizeTransition(ctx, order_entity.Completed, order_entity.Delivered)
+		forbidden := policy.AuthorizeTransition(ctx, order_entity.Received, order_entity.Processing)
+
+		// Assert
+		assert.NoError(t, allowed)
+		assert.ErrorIs(t, forbidden, custom_error.ErrOrderTransitionForbidden)
+	})
+}
@@ -0,0 +1,66 @@
+package correlation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// HeaderXCorrelationID carries the id shared by every request and message
+	// of the same flow, echo only defines the request id header
+	HeaderXCorrelationID = "X-Correlation-ID"
+
+	// RequestIdKey and CorrelationIdKey name the ids in the logs and in the
+	// attributes of the messages
+	RequestIdKey     = "request_id"
+	CorrelationIdKey = "correlation_id"
+)
+
+type requestIdKey struct{}
+
+type correlationIdKey struct{}
+
+// NewId returns a random id for the requests and flows without one
+func NewId() string {
+	return uuid.NewString()
+}
+
+// WithRequestId stores the id of the request or message being handled
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestIdFromContext returns the id stored by WithRequestId, empty when there
+// is none
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
+// WithCorrelationId stores the id of the flow the request or message is part
+// of, it is forwarded to the published events
+func WithCorrelationId(ctx context.Context, correlationId string) context.Context {
+	return context.WithValue(ctx, correlationIdKey{}, correlationId)
+}
+
+// CorrelationIdFromContext returns the id stored by WithCorrelationId, empty
+// when there is none
+func CorrelationIdFromContext(ctx context.Context) string {
+	correlationId, _ := ctx.Value(correlationIdKey{}).(string)
+	return correlationId
+}
+
+// WithIds stores both ids, the correlation id defaults to the request id as the
+// request starts a new flow
+func WithIds(ctx context.Context, requestId string, correlationId string) context.Context {
+	if requestId == "" {
+		requestId = NewId()
+	}
+
+	if correlationId == "" {
+		correlationId = requestId
+	}
+
+	return WithCorrelationId(WithRequestId(ctx, requestId), correlationId)
+}
@@ -0,0 +1,59 @@
+package correlation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithIds(t *testing.T) {
+	t.Run("Should store both ids", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		ctx = WithIds(ctx, "request-id", "correlation-id")
+
+		// Assert
+		assert.Equal(t, "request-id", RequestIdFromContext(ctx))
+		assert.Equal(t, "correlation-id", CorrelationIdFromContext(ctx))
+	})
+
+	t.Run("Should use the request id as correlation id when it is not informed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		ctx = WithIds(ctx, "request-id", "")
+
+		// Assert
+		assert.Equal(t, "request-id", RequestIdFromContext(ctx))
+		assert.Equal(t, "request-id", CorrelationIdFromContext(ctx))
+	})
+
+	t.Run("Should generate the request id when it is not informed", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		ctx = WithIds(ctx, "", "")
+
+		// Assert
+		assert.Len(t, RequestIdFromContext(ctx), 36)
+		assert.Equal(t, RequestIdFromContext(ctx), CorrelationIdFromContext(ctx))
+	})
+
+	t.Run("Should return empty ids when the context has none", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		requestId := RequestIdFromContext(ctx)
+		correlationId := CorrelationIdFromContext(ctx)
+
+		// Assert
+		assert.Empty(t, requestId)
+		assert.Empty(t, correlationId)
+	})
+}
@@ -0,0 +1,25 @@
+package correlation
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// Middleware stores the ids informed in the X-Request-ID and X-Correlation-ID
+// headers in the context of the request, generating the missing ones, and
+// returns both in the response
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			ctx := WithIds(req.Context(), req.Header.Get(echo.HeaderXRequestID), req.Header.Get(HeaderXCorrelationID))
+
+			c.SetRequest(req.WithContext(ctx))
+
+			c.Response().Header().Set(echo.HeaderXRequestID, RequestIdFromContext(ctx))
+			c.Response().Header().Set(HeaderXCorrelationID, CorrelationIdFromContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package correlation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Should honor the ids informed in the headers", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderXRequestID, "request-id")
+		req.Header.Set(HeaderXCorrelationID, "correlation-id")
+		res := httptest.NewRecorder()
+
+		var requestId, correlationId string
+
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/", func(c echo.Context) error {
+			requestId = RequestIdFromContext(c.Request().Context())
+			correlationId = CorrelationIdFromContext(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})
+
+		// Act
+		e.ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, "request-id", requestId)
+		assert.Equal(t, "correlation-id", correlationId)
+		assert.Equal(t, "request-id", res.Header().Get(echo.HeaderXRequestID))
+		assert.Equal(t, "correlation-id", res.Header().Get(HeaderXCorrelationID))
+	})
+
+	t.Run("Should generate the ids when they are not informed", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		res := httptest.NewRecorder()
+
+		var requestId string
+
+		e := echo.New()
+		e.Use(Middleware())
+		e.GET("/", func(c echo.Context) error {
+			requestId = RequestIdFromContext(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})
+
+		// Act
+		e.ServeHTTP(res, req)
+
+		// Assert
+		assert.NotEmpty(t, requestId)
+		assert.Equal(t, requestId, res.Header().Get(echo.HeaderXRequestID))
+		assert.Equal(t, requestId, res.Header().Get(HeaderXCorrelationID))
+	})
+
+	t.Run("Should return the ids in the errors", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		req.Header.Set(echo.HeaderXRequestID, "request-id")
+		res := httptest.NewRecorder()
+
+		e := echo.New()
+		e.Use(Middleware())
+
+		// Act
+		e.ServeHTTP(res, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, "request-id", res.Header().Get(echo.HeaderXRequestID))
+	})
+}
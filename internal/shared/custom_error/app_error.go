@@ -1,6 +1,10 @@
 package custom_error
 
-import "github.com/labstack/echo/v4"
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
 
 type AppError struct {
 	Code    int    `json:"code"`
@@ -15,10 +19,28 @@ func NewHttpAppError(code int, message string, err error) *echo.HTTPError {
 		Details: err.Error(),
 	}
 
-	return echo.NewHTTPError(code, appError)
+	return echo.NewHTTPError(code, appError).SetInternal(err)
 }
 
 func NewHttpAppErrorFromBusinessError(err error) *echo.HTTPError {
-	buErr := err.(BusinessError)
+	buErr, _ := AsBusinessErr(err)
 	return NewHttpAppError(buErr.Code(), buErr.Title(), err)
 }
+
+// NewAppError converts the error into the body returned to the client, errors
+// that are not business errors are reported as internal server errors
+func NewAppError(err error) AppError {
+	if buErr, ok := AsBusinessErr(err); ok {
+		return AppError{
+			Code:    buErr.Code(),
+			Message: buErr.Title(),
+			Details: buErr.Error(),
+		}
+	}
+
+	return AppError{
+		Code:    http.StatusInternalServerError,
+		Message: "internal server error",
+		Details: err.Error(),
+	}
+}
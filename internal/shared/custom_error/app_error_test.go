@@ -43,3 +43,35 @@ func TestNewHttpAppErrorFromBusinessError(t *testing.T) {
 		}, err.Message)
 	})
 }
+
+func TestNewAppError(t *testing.T) {
+	t.Run("Should return the app error from business error", func(t *testing.T) {
+		// Arrange
+		buErr := New(400, "title", "message")
+
+		// Act
+		res := NewAppError(buErr)
+
+		// Assert
+		assert.Equal(t, AppError{
+			Code:    400,
+			Message: "title",
+			Details: "message",
+		}, res)
+	})
+
+	t.Run("Should return an internal server error", func(t *testing.T) {
+		// Arrange
+		err := errors.New("my error")
+
+		// Act
+		res := NewAppError(err)
+
+		// Assert
+		assert.Equal(t, AppError{
+			Code:    500,
+			Message: "internal server error",
+			Details: "my error",
+		}, res)
+	})
+}
@@ -1,5 +1,7 @@
 package custom_error
 
+import "errors"
+
 type BusinessError struct {
 	code    int
 	title   string
@@ -31,6 +33,17 @@ func IsBusinessErr(err error) bool {
 		return false
 	}
 
-	_, ok := err.(BusinessError)
+	_, ok := AsBusinessErr(err)
 	return ok
 }
+
+// AsBusinessErr finds the business error in the chain of err, e.g. the
+// ErrRequestNotValid of a validation error
+func AsBusinessErr(err error) (BusinessError, bool) {
+	var buErr BusinessError
+	if errors.As(err, &buErr) {
+		return buErr, true
+	}
+
+	return BusinessError{}, false
+}
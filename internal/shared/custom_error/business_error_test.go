@@ -40,3 +40,28 @@ func TestIsBusinessErr(t *testing.T) {
 		assert.False(t, result)
 	})
 }
+
+func TestAsBusinessErr(t *testing.T) {
+	t.Run("Should find the business error wrapped by the error", func(t *testing.T) {
+		// Arrange
+		err := NewValidationError(Violation{Field: "state", Rule: "required", Message: "is required"})
+
+		// Act
+		buErr, ok := AsBusinessErr(err)
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, ErrRequestNotValid, buErr)
+	})
+
+	t.Run("Should return false when error is not a business error", func(t *testing.T) {
+		// Arrange
+		err := errors.New("error")
+
+		// Act
+		_, ok := AsBusinessErr(err)
+
+		// Assert
+		assert.False(t, ok)
+	})
+}
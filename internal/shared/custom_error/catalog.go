@@ -0,0 +1,62 @@
+package custom_error
+
+import (
+	"net/http"
+	"strings"
+)
+
+// catalog has the stable codes of the business errors, clients must rely on
+// them instead of the messages, which may change
+var catalog = map[BusinessError]string{
+	ErrRequestNotValid: "REQUEST_NOT_VALID",
+	ErrAccessDenied:    "ACCESS_DENIED",
+	ErrStoreForbidden:  "STORE_FORBIDDEN",
+
+	ErrOrderInvalidStateTransition: "ORDER_INVALID_STATE_TRANSITION",
+	ErrOrderAlreadyAtState:         "ORDER_ALREADY_AT_STATE",
+	ErrOrderNotFound:               "ORDER_NOT_FOUND",
+	ErrOrderAlreadyExists:          "ORDER_ALREADY_EXISTS",
+	ErrOrderItemAlreadyExists:      "ORDER_ITEM_ALREADY_EXISTS",
+	ErrOrderInProgress:             "ORDER_IN_PROGRESS",
+	ErrOrderAlreadyCompleted:       "ORDER_ALREADY_COMPLETED",
+	ErrOrderTransitionForbidden:    "ORDER_TRANSITION_FORBIDDEN",
+
+	ErrOrderEventNotFound: "ORDER_EVENT_NOT_FOUND",
+
+	ErrOrderHasNoItems:         "ORDER_HAS_NO_ITEMS",
+	ErrOrderHasOnGoingPayments: "ORDER_HAS_ON_GOING_PAYMENTS",
+
+	ErrTopicNotFound: "TOPIC_NOT_FOUND",
+
+	ErrQueueMessageNotValid: "QUEUE_MESSAGE_NOT_VALID",
+
+	ErrWebhookNotFound: "WEBHOOK_NOT_FOUND",
+
+	ErrApiKeyNotFound: "API_KEY_NOT_FOUND",
+	ErrApiKeyNotValid: "API_KEY_NOT_VALID",
+	ErrApiKeyRevoked:  "API_KEY_REVOKED",
+
+	ErrPaymentNotFound:               "PAYMENT_NOT_FOUND",
+	ErrPaymentInvalidStateTransition: "PAYMENT_INVALID_STATE_TRANSITION",
+}
+
+// ErrorCode returns the code of the business error, errors outside of the
+// catalog are identified by their HTTP status
+func ErrorCode(err BusinessError) string {
+	if code, ok := catalog[err]; ok {
+		return code
+	}
+
+	return StatusCode(err.Code())
+}
+
+// StatusCode returns the code of the errors without a business meaning, e.g.
+// 404 is NOT_FOUND and 500 is INTERNAL_SERVER_ERROR
+func StatusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "UNKNOWN_ERROR"
+	}
+
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
@@ -4,6 +4,8 @@ import "net/http"
 
 var (
 	ErrRequestNotValid BusinessError = New(http.StatusUnprocessableEntity, "validation error", "request not valid, please check the fields")
+	ErrAccessDenied    BusinessError = New(http.StatusForbidden, "access denied", "the roles of the user do not allow the operation")
+	ErrStoreForbidden  BusinessError = New(http.StatusForbidden, "access denied", "the user has no access to the store")
 
 	ErrOrderInvalidStateTransition BusinessError = New(http.StatusBadRequest, "unable to update order state", "invalid state transition")
 	ErrOrderAlreadyAtState         BusinessError = New(http.StatusBadRequest, "unable to update order state", "order is already at the state")
@@ -12,6 +14,9 @@ var (
 	ErrOrderItemAlreadyExists      BusinessError = New(http.StatusConflict, "unable to add an item", "order item already exists")
 	ErrOrderInProgress             BusinessError = New(http.StatusBadRequest, "unable to update/insert information to the order", "order is in progress")
 	ErrOrderAlreadyCompleted       BusinessError = New(http.StatusBadRequest, "unable to update/insert information to the order", "order is already completed or cancelled")
+	ErrOrderTransitionForbidden    BusinessError = New(http.StatusForbidden, "unable to update order state", "the roles of the user do not allow the transition")
+
+	ErrOrderEventNotFound BusinessError = New(http.StatusNotFound, "unable to find the order event", "order event not found")
 
 	ErrOrderHasNoItems         BusinessError = New(http.StatusBadRequest, "operation not allowed", "order has no items")
 	ErrOrderHasOnGoingPayments BusinessError = New(http.StatusBadRequest, "operation not allowed", "order has on going payments or is already paid")
@@ -20,6 +25,12 @@ var (
 
 	ErrQueueMessageNotValid BusinessError = New(http.StatusUnprocessableEntity, "unable to process the message", "message not valid")
 
+	ErrWebhookNotFound BusinessError = New(http.StatusNotFound, "unable to find the webhook", "webhook not found")
+
+	ErrApiKeyNotFound BusinessError = New(http.StatusNotFound, "unable to find the API key", "API key not found")
+	ErrApiKeyNotValid BusinessError = New(http.StatusUnauthorized, "unable to authenticate", "API key is invalid, expired or revoked")
+	ErrApiKeyRevoked  BusinessError = New(http.StatusBadRequest, "unable to rotate the API key", "API key is revoked or expired")
+
 	ErrPaymentNotFound               BusinessError = New(http.StatusNotFound, "unable to find the payment", "payment not found")
 	ErrPaymentInvalidStateTransition BusinessError = New(http.StatusBadRequest, "unable to update payment state", "invalid state transition")
 )
@@ -0,0 +1,53 @@
+package custom_error
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Violation describes why a field of the request is not valid
+type Violation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationError is the ErrRequestNotValid with the fields that failed the
+// validation, it unwraps to ErrRequestNotValid
+type ValidationError struct {
+	Violations []Violation
+}
+
+func NewValidationError(violations ...Violation) *ValidationError {
+	return &ValidationError{
+		Violations: violations,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Violations) == 0 {
+		return ErrRequestNotValid.Error()
+	}
+
+	fields := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		fields = append(fields, fmt.Sprintf("%s %s", violation.Field, violation.Message))
+	}
+
+	return fmt.Sprintf("%s: %s", ErrRequestNotValid.Error(), strings.Join(fields, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrRequestNotValid
+}
+
+// GetViolations returns the violations of the validation error wrapped by err
+func GetViolations(err error) []Violation {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Violations
+	}
+
+	return nil
+}
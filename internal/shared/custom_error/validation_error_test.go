@@ -0,0 +1,73 @@
+package custom_error
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	t.Run("Should describe the fields that failed", func(t *testing.T) {
+		// Arrange
+		err := NewValidationError(
+			Violation{Field: "order_id", Rule: "required", Message: "is required"},
+			Violation{Field: "items", Rule: "required", Message: "is required"},
+		)
+
+		// Act
+		res := err.Error()
+
+		// Assert
+		assert.Equal(t, "request not valid, please check the fields: order_id is required; items is required", res)
+		assert.ErrorIs(t, err, ErrRequestNotValid)
+		assert.True(t, IsBusinessErr(err))
+	})
+
+	t.Run("Should return the violations of the wrapped validation error", func(t *testing.T) {
+		// Arrange
+		violation := Violation{Field: "state", Rule: "required", Message: "is required"}
+		err := NewHttpAppErrorFromBusinessError(NewValidationError(violation))
+
+		// Act
+		res := GetViolations(err)
+
+		// Assert
+		assert.Equal(t, []Violation{violation}, res)
+		assert.Equal(t, 422, err.Code)
+	})
+
+	t.Run("Should return nil when there is no validation error", func(t *testing.T) {
+		// Arrange
+		err := errors.New("error")
+
+		// Act
+		res := GetViolations(err)
+
+		// Assert
+		assert.Nil(t, res)
+	})
+}
+
+func TestErrorCode(t *testing.T) {
+	t.Run("Should return the code of the catalog", func(t *testing.T) {
+		// Arrange
+
+		// Act
+		res := ErrorCode(ErrOrderNotFound)
+
+		// Assert
+		assert.Equal(t, "ORDER_NOT_FOUND", res)
+	})
+
+	t.Run("Should return the code of the status when not in the catalog", func(t *testing.T) {
+		// Arrange
+		err := New(409, "title", "message")
+
+		// Act
+		res := ErrorCode(err)
+
+		// Assert
+		assert.Equal(t, "CONFLICT", res)
+	})
+}
@@ -1,7 +1,24 @@
 package health
 
+import "context"
+
+const (
+	HealthyStatus   = "healthy"
+	UnhealthyStatus = "unhealthy"
+	// DegradedStatus is reported when only non critical components are
+	// unhealthy, the service keeps working without them
+	DegradedStatus = "degraded"
+)
+
 type HealthCheck interface {
-	Health() *HealthStatus
+	Health(ctx context.Context) *HealthStatus
+}
+
+// HealthCheckFunc turns a function into a HealthCheck
+type HealthCheckFunc func(ctx context.Context) *HealthStatus
+
+func (f HealthCheckFunc) Health(ctx context.Context) *HealthStatus {
+	return f(ctx)
 }
 
 type HealthStatus struct {
@@ -9,6 +26,21 @@ type HealthStatus struct {
 	Err    string `json:"err,omitempty"`
 }
 
+// NewHealthStatus returns the status of a check that failed with the error,
+// healthy when there is none
+func NewHealthStatus(err error) *HealthStatus {
+	if err != nil {
+		return &HealthStatus{
+			Status: UnhealthyStatus,
+			Err:    err.Error(),
+		}
+	}
+
+	return &HealthStatus{
+		Status: HealthyStatus,
+	}
+}
+
 func (h *HealthStatus) HasError() bool {
 	return h.Err != ""
 }
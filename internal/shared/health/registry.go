@@ -0,0 +1,197 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider"
+)
+
+type Probe string
+
+const (
+	// LivenessProbe fails when the process must be restarted, its checks must
+	// not depend on other services
+	LivenessProbe Probe = "liveness"
+	// ReadinessProbe fails when the service cannot handle requests and
+	// messages, e.g. a dependency is unreachable
+	ReadinessProbe Probe = "readiness"
+)
+
+// Check is a component registered in the registry
+type Check struct {
+	Name        string
+	HealthCheck HealthCheck
+	// Critical components fail the probe when unhealthy, the others only
+	// degrade it
+	Critical bool
+	// Timeout of the check, the one of the registry when zero
+	Timeout time.Duration
+	// Probes the check is part of, only the readiness when empty
+	Probes []Probe
+}
+
+func (c Check) isPartOf(probe Probe) bool {
+	if len(c.Probes) == 0 {
+		return probe == ReadinessProbe
+	}
+
+	return slices.Contains(c.Probes, probe)
+}
+
+type ComponentStatus struct {
+	Status    string    `json:"status"`
+	Err       string    `json:"err,omitempty"`
+	Critical  bool      `json:"critical"`
+	LatencyMs float64   `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components"`
+}
+
+func (r Report) IsHealthy() bool {
+	return r.Status != UnhealthyStatus
+}
+
+type Reporter interface {
+	Report(ctx context.Context, probe Probe) Report
+}
+
+type entry struct {
+	check Check
+
+	// mutex makes the concurrent probes wait for the running check instead
+	// of running it again
+	mutex     sync.Mutex
+	status    ComponentStatus
+	expiresAt time.Time
+}
+
+// Registry runs the checks of the registered components, the results are
+// reused for the cache TTL so frequent probes do not hammer the dependencies
+type Registry struct {
+	timeProvider provider.TimeProvider
+	timeout      time.Duration
+	cacheTtl     time.Duration
+
+	mutex   sync.RWMutex
+	entries []*entry
+}
+
+func NewRegistry(timeProvider provider.TimeProvider, timeout time.Duration, cacheTtl time.Duration) *Registry {
+	return &Registry{
+		timeProvider: timeProvider,
+		timeout:      timeout,
+		cacheTtl:     cacheTtl,
+	}
+}
+
+func (r *Registry) Register(check Check) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.entries = append(r.entries, &entry{check: check})
+}
+
+// Report runs the checks of the probe in parallel, the service is unhealthy
+// when a critical component is unhealthy
+func (r *Registry) Report(ctx context.Context, probe Probe) Report {
+	r.mutex.RLock()
+	entries := make([]*entry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		if entry.check.isPartOf(probe) {
+			entries = append(entries, entry)
+		}
+	}
+	r.mutex.RUnlock()
+
+	statuses := make([]ComponentStatus, len(entries))
+
+	var wg sync.WaitGroup
+	for i := range entries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statuses[i] = r.run(ctx, entries[i])
+		}(i)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:     HealthyStatus,
+		Components: make(map[string]ComponentStatus, len(entries)),
+	}
+
+	for i, entry := range entries {
+		status := statuses[i]
+		report.Components[entry.check.Name] = status
+
+		if status.Status == HealthyStatus {
+			continue
+		}
+
+		if status.Critical {
+			report.Status = UnhealthyStatus
+		} else if report.Status == HealthyStatus {
+			report.Status = DegradedStatus
+		}
+	}
+
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, entry *entry) ComponentStatus {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	now := r.timeProvider.GetTime()
+	if now.Before(entry.expiresAt) {
+		return entry.status
+	}
+
+	timeout := entry.check.Timeout
+	if timeout <= 0 {
+		timeout = r.timeout
+	}
+
+	// the result is cached, so it must not fail because the probe gave up
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	result := make(chan *HealthStatus, 1)
+
+	start := time.Now()
+	go func() {
+		result <- entry.check.HealthCheck.Health(ctx)
+	}()
+
+	var status *HealthStatus
+
+	select {
+	case status = <-result:
+	case <-ctx.Done():
+		status = NewHealthStatus(fmt.Errorf("check timed out after %s", timeout))
+	}
+
+	if status.Status != HealthyStatus {
+		slog.WarnContext(ctx, "component is unhealthy", "component", entry.check.Name, "critical", entry.check.Critical, "error", status.Err)
+	}
+
+	entry.status = ComponentStatus{
+		Status:    status.Status,
+		Err:       status.Err,
+		Critical:  entry.check.Critical,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		CheckedAt: now,
+	}
+	entry.expiresAt = now.Add(r.cacheTtl)
+
+	return entry.status
+}
@@ -0,0 +1,209 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/provider/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func countedCheck(counter *atomic.Int32, err error) HealthCheck {
+	return HealthCheckFunc(func(ctx context.Context) *HealthStatus {
+		counter.Add(1)
+		return NewHealthStatus(err)
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	now := time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should be healthy when every component is healthy", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		timeProvider := mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now)
+
+		var calls atomic.Int32
+
+		registry := NewRegistry(timeProvider, time.Second, time.Minute)
+		registry.Register(Check{Name: "database", HealthCheck: countedCheck(&calls, nil), Critical: true})
+		registry.Register(Check{Name: "queue", HealthCheck: countedCheck(&calls, nil), Critical: true})
+
+		// Act
+		report := registry.Report(ctx, ReadinessProbe)
+
+		// Assert
+		assert.True(t, report.IsHealthy())
+		assert.Equal(t, HealthyStatus, report.Status)
+		assert.Len(t, report.Components, 2)
+		assert.Equal(t, HealthyStatus, report.Components["database"].Status)
+		assert.True(t, report.Components["database"].Critical)
+		assert.Equal(t, now, report.Components["database"].CheckedAt)
+		assert.Equal(t, int32(2), calls.Load())
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should be unhealthy when a critical component is unhealthy", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		timeProvider := mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now)
+
+		var calls atomic.Int32
+
+		registry := NewRegistry(timeProvider, time.Second, time.Minute)
+		registry.Register(Check{Name: "database", HealthCheck: countedCheck(&calls, errors.New("connection refused")), Critical: true})
+		registry.Register(Check{Name: "secrets", HealthCheck: countedCheck(&calls, nil)})
+
+		// Act
+		report := registry.Report(ctx, ReadinessProbe)
+
+		// Assert
+		assert.False(t, report.IsHealthy())
+		assert.Equal(t, UnhealthyStatus, report.Status)
+		assert.Equal(t, "connection refused", report.Components["database"].Err)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should be degraded when only a non critical component is unhealthy", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		timeProvider := mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now)
+
+		var calls atomic.Int32
+
+		registry := NewRegistry(timeProvider, time.Second, time.Minute)
+		registry.Register(Check{Name: "database", HealthCheck: countedCheck(&calls, nil), Critical: true})
+		registry.Register(Check{Name: "secrets", HealthCheck: countedCheck(&calls, errors.New("access denied"))})
+
+		// Act
+		report := registry.Report(ctx, ReadinessProbe)
+
+		// Assert
+		assert.True(t, report.IsHealthy())
+		assert.Equal(t, DegradedStatus, report.Status)
+		assert.Equal(t, UnhealthyStatus, report.Components["secrets"].Status)
+		assert.False(t, report.Components["secrets"].Critical)
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should only run the checks of the probe", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		timeProvider := mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now)
+
+		var readinessCalls, livenessCalls atomic.Int32
+
+		registry := NewRegistry(timeProvider, time.Second, time.Minute)
+		registry.Register(Check{Name: "database", HealthCheck: countedCheck(&readinessCalls, nil), Critical: true})
+		registry.Register(Check{Name: "queue_consumer", HealthCheck: countedCheck(&livenessCalls, nil), Critical: true, Probes: []Probe{LivenessProbe}})
+
+		// Act
+		report := registry.Report(ctx, LivenessProbe)
+
+		// Assert
+		assert.Len(t, report.Components, 1)
+		assert.Contains(t, report.Components, "queue_consumer")
+		assert.Equal(t, int32(0), readinessCalls.Load())
+		assert.Equal(t, int32(1), livenessCalls.Load())
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should reuse the results until the cache expires", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		timeProvider := mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now).Twice()
+		timeProvider.On("GetTime").Return(now.Add(time.Minute)).Once()
+
+		var calls atomic.Int32
+
+		registry := NewRegistry(timeProvider, time.Second, time.Minute)
+		registry.Register(Check{Name: "database", HealthCheck: countedCheck(&calls, nil), Critical: true})
+
+		// Act
+		registry.Report(ctx, ReadinessProbe)
+		registry.Report(ctx, ReadinessProbe)
+		registry.Report(ctx, ReadinessProbe)
+
+		// Assert
+		assert.Equal(t, int32(2), calls.Load())
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should fail the check that does not finish in time", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		timeProvider := mocks.NewMockTimeProvider(t)
+		timeProvider.On("GetTime").Return(now)
+
+		release := make(chan struct{})
+		defer close(release)
+
+		registry := NewRegistry(timeProvider, time.Minute, time.Minute)
+		registry.Register(Check{
+			Name: "topic",
+			HealthCheck: HealthCheckFunc(func(ctx context.Context) *HealthStatus {
+				<-release
+				return NewHealthStatus(nil)
+			}),
+			Critical: true,
+			Timeout:  10 * time.Millisecond,
+		})
+
+		// Act
+		report := registry.Report(ctx, ReadinessProbe)
+
+		// Assert
+		assert.Equal(t, UnhealthyStatus, report.Status)
+		assert.Equal(t, "check timed out after 10ms", report.Components["topic"].Err)
+		assert.GreaterOrEqual(t, report.Components["topic"].LatencyMs, float64(10))
+		timeProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should be healthy when no component is registered", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		registry := NewRegistry(mocks.NewMockTimeProvider(t), time.Second, time.Minute)
+
+		// Act
+		report := registry.Report(ctx, LivenessProbe)
+
+		// Assert
+		assert.Equal(t, HealthyStatus, report.Status)
+		assert.Empty(t, report.Components)
+	})
+}
+
+func TestNewHealthStatus(t *testing.T) {
+	t.Run("Should return healthy when there is no error", func(t *testing.T) {
+		// Act
+		status := NewHealthStatus(nil)
+
+		// Assert
+		assert.Equal(t, &HealthStatus{Status: HealthyStatus}, status)
+		assert.False(t, status.HasError())
+	})
+
+	t.Run("Should return unhealthy with the error", func(t *testing.T) {
+		// Act
+		status := NewHealthStatus(errors.New("error"))
+
+		// Assert
+		assert.Equal(t, &HealthStatus{Status: UnhealthyStatus, Err: "error"}, status)
+		assert.True(t, status.HasError())
+	})
+}
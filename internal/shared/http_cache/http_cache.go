@@ -0,0 +1,101 @@
+package http_cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Respond writes the body as a public cacheable response, the ETag is the
+// hash of the body so unchanged responses are answered with 304
+func Respond(c echo.Context, maxAge time.Duration, contentType string, body []byte) error {
+	etag := NewETag(body)
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	header.Set("ETag", etag)
+
+	if Matches(c.Request().Header.Get("If-None-Match"), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+func NewETag(body []byte) string {
+	hash := sha256.Sum256(body)
+	return fmt.Sprintf("%q", hex.EncodeToString(hash[:16]))
+}
+
+// Matches reports if the If-None-Match header contains the etag, using the
+// weak comparison
+func Matches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, value := range strings.Split(ifNoneMatch, ",") {
+		value = strings.TrimSpace(value)
+		if value == "*" || strings.TrimPrefix(value, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validators identify the version of a private representation
+type Validators struct {
+	ETag         string
+	LastModified time.Time
+
+	// ModifiedSince evaluates If-Modified-Since, it must be false when the
+	// representation can change without a newer LastModified, e.g. a list that
+	// loses an item
+	ModifiedSince bool
+}
+
+// NewVersionETag is a weak ETag of the versions of the resources in the
+// representation, e.g. the id and update time of every order
+func NewVersionETag(versions ...string) string {
+	hash := sha256.New()
+	for _, version := range versions {
+		hash.Write([]byte(version))
+		hash.Write([]byte{0})
+	}
+
+	return fmt.Sprintf("W/%q", hex.EncodeToString(hash.Sum(nil)[:16]))
+}
+
+// NotModified writes the validators to the response and reports if the client
+// already has the representation, If-None-Match takes precedence over
+// If-Modified-Since. The clients must revalidate before reusing the response
+func NotModified(c echo.Context, validators Validators) bool {
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, "private, no-cache")
+	header.Set("ETag", validators.ETag)
+
+	if !validators.LastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, validators.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if ifNoneMatch := c.Request().Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return Matches(ifNoneMatch, validators.ETag)
+	}
+
+	if !validators.ModifiedSince || validators.LastModified.IsZero() {
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(c.Request().Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+
+	return !validators.LastModified.Truncate(time.Second).After(ifModifiedSince)
+}
@@ -0,0 +1,166 @@
+package http_cache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRespond(t *testing.T) {
+	t.Run("Should return the body with the cache headers", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		// Act
+		err := Respond(ctx, 5*time.Second, echo.MIMEApplicationJSON, []byte(`{}`))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "public, max-age=5", resp.Header().Get(echo.HeaderCacheControl))
+		assert.Equal(t, NewETag([]byte(`{}`)), resp.Header().Get("ETag"))
+		assert.Equal(t, `{}`, resp.Body.String())
+	})
+
+	t.Run("Should return not modified when the etag matches", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		req.Header.Set("If-None-Match", `"other", W/`+NewETag([]byte(`{}`)))
+		resp := httptest.NewRecorder()
+
+		e := echo.New()
+		ctx := e.NewContext(req, resp)
+
+		// Act
+		err := Respond(ctx, 5*time.Second, echo.MIMEApplicationJSON, []byte(`{}`))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotModified, resp.Code)
+		assert.Empty(t, resp.Body.String())
+	})
+}
+
+func TestMatches(t *testing.T) {
+	t.Run("Should match the etag", func(t *testing.T) {
+		// Arrange
+		etag := `"abc"`
+
+		// Act
+		// Assert
+		assert.True(t, Matches(`"abc"`, etag))
+		assert.True(t, Matches(`W/"abc"`, etag))
+		assert.True(t, Matches(`*`, etag))
+		assert.False(t, Matches(`"def"`, etag))
+		assert.False(t, Matches(``, etag))
+	})
+}
+
+func TestNewVersionETag(t *testing.T) {
+	t.Run("Should change when a version changes", func(t *testing.T) {
+		// Arrange
+		first := NewVersionETag("a@1", "b@1")
+
+		// Act
+		res := NewVersionETag("a@1", "b@2")
+
+		// Assert
+		assert.NotEqual(t, first, res)
+		assert.Equal(t, first, NewVersionETag("a@1", "b@1"))
+		assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, res)
+	})
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC)
+
+	newContext := func(headers map[string]string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(echo.GET, "/", nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		resp := httptest.NewRecorder()
+
+		return echo.New().NewContext(req, resp), resp
+	}
+
+	t.Run("Should write the validators", func(t *testing.T) {
+		// Arrange
+		ctx, resp := newContext(nil)
+
+		// Act
+		res := NotModified(ctx, Validators{ETag: `W/"1"`, LastModified: lastModified})
+
+		// Assert
+		assert.False(t, res)
+		assert.Equal(t, `W/"1"`, resp.Header().Get("ETag"))
+		assert.Equal(t, "Wed, 01 May 2024 12:00:00 GMT", resp.Header().Get(echo.HeaderLastModified))
+		assert.Equal(t, "private, no-cache", resp.Header().Get(echo.HeaderCacheControl))
+	})
+
+	t.Run("Should return true when the etag matches", func(t *testing.T) {
+		// Arrange
+		ctx, _ := newContext(map[string]string{"If-None-Match": `W/"1"`})
+
+		// Act
+		res := NotModified(ctx, Validators{ETag: `W/"1"`, LastModified: lastModified})
+
+		// Assert
+		assert.True(t, res)
+	})
+
+	t.Run("Should ignore If-Modified-Since when If-None-Match is informed", func(t *testing.T) {
+		// Arrange
+		ctx, _ := newContext(map[string]string{
+			"If-None-Match":     `W/"0"`,
+			"If-Modified-Since": "Wed, 01 May 2024 12:00:00 GMT",
+		})
+
+		// Act
+		res := NotModified(ctx, Validators{ETag: `W/"1"`, LastModified: lastModified, ModifiedSince: true})
+
+		// Assert
+		assert.False(t, res)
+	})
+
+	t.Run("Should return true when not modified since", func(t *testing.T) {
+		// Arrange
+		ctx, _ := newContext(map[string]string{"If-Modified-Since": "Wed, 01 May 2024 12:00:00 GMT"})
+
+		// Act
+		res := NotModified(ctx, Validators{ETag: `W/"1"`, LastModified: lastModified, ModifiedSince: true})
+
+		// Assert
+		assert.True(t, res)
+	})
+
+	t.Run("Should return false when modified since", func(t *testing.T) {
+		// Arrange
+		ctx, _ := newContext(map[string]string{"If-Modified-Since": "Wed, 01 May 2024 11:59:59 GMT"})
+
+		// Act
+		res := NotModified(ctx, Validators{ETag: `W/"1"`, LastModified: lastModified, ModifiedSince: true})
+
+		// Assert
+		assert.False(t, res)
+	})
+
+	t.Run("Should not evaluate If-Modified-Since when disabled", func(t *testing.T) {
+		// Arrange
+		ctx, _ := newContext(map[string]string{"If-Modified-Since": "Wed, 01 May 2024 12:00:00 GMT"})
+
+		// Act
+		res := NotModified(ctx, Validators{ETag: `W/"1"`, LastModified: lastModified})
+
+		// Assert
+		assert.False(t, res)
+	})
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ContextHandler adds the request and correlation ids, along with the trace
+// and span ids, of the context to every record, so the logs of the same order
+// can be followed across the requests and messages
+type ContextHandler struct {
+	slog.Handler
+}
+
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{
+		Handler: handler,
+	}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestId := correlation.RequestIdFromContext(ctx); requestId != "" {
+		record.AddAttrs(slog.String(correlation.RequestIdKey, requestId))
+	}
+
+	if correlationId := correlation.CorrelationIdFromContext(ctx); correlationId != "" {
+		record.AddAttrs(slog.String(correlation.CorrelationIdKey, correlationId))
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewContextHandler(h.Handler.WithAttrs(attrs))
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return NewContextHandler(h.Handler.WithGroup(name))
+}
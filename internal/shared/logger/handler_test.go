@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextHandler(t *testing.T) {
+	decode := func(t *testing.T, buffer *bytes.Buffer) map[string]interface{} {
+		var record map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+
+		return record
+	}
+
+	t.Run("Should add the ids of the context to the record", func(t *testing.T) {
+		// Arrange
+		buffer := &bytes.Buffer{}
+		log := slog.New(NewContextHandler(slog.NewJSONHandler(buffer, nil)))
+
+		traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanId, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+		ctx := correlation.WithIds(context.Background(), "request-id", "correlation-id")
+		ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceId,
+			SpanID:  spanId,
+		}))
+
+		// Act
+		log.InfoContext(ctx, "message")
+
+		// Assert
+		record := decode(t, buffer)
+		assert.Equal(t, "request-id", record["request_id"])
+		assert.Equal(t, "correlation-id", record["correlation_id"])
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["trace_id"])
+		assert.Equal(t, "00f067aa0ba902b7", record["span_id"])
+	})
+
+	t.Run("Should not add the ids when the context has none", func(t *testing.T) {
+		// Arrange
+		buffer := &bytes.Buffer{}
+		log := slog.New(NewContextHandler(slog.NewJSONHandler(buffer, nil)))
+
+		// Act
+		log.InfoContext(context.Background(), "message")
+
+		// Assert
+		record := decode(t, buffer)
+		assert.NotContains(t, record, "request_id")
+		assert.NotContains(t, record, "correlation_id")
+		assert.NotContains(t, record, "trace_id")
+	})
+
+	t.Run("Should keep adding the ids to the derived loggers", func(t *testing.T) {
+		// Arrange
+		buffer := &bytes.Buffer{}
+		log := slog.New(NewContextHandler(slog.NewJSONHandler(buffer, nil))).With("component", "queue")
+
+		ctx := correlation.WithIds(context.Background(), "request-id", "")
+
+		// Act
+		log.InfoContext(ctx, "message")
+
+		// Assert
+		record := decode(t, buffer)
+		assert.Equal(t, "queue", record["component"])
+		assert.Equal(t, "request-id", record["request_id"])
+	})
+}
@@ -2,36 +2,110 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
 )
 
+const (
+	JsonFormat = "json"
+	TextFormat = "text"
+)
+
+// level is shared by every handler created by SetupLog, so changing it at
+// runtime affects the loggers already created
+var level = new(slog.LevelVar)
+
+// Level returns the level of the logs, it can be changed at runtime
+func Level() *slog.LevelVar {
+	return level
+}
+
+// ParseLevel parses one of debug, info, warn or error, in any case
+func ParseLevel(text string) (slog.Level, error) {
+	var parsed slog.Level
+
+	if err := parsed.UnmarshalText([]byte(text)); err != nil {
+		return parsed, fmt.Errorf("unknown log level %q, use debug, info, warn or error", text)
+	}
+
+	return parsed, nil
+}
+
+// FormatLevel returns the level as ParseLevel reads it
+func FormatLevel(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
+
 func SetupLog(config *environment.Config) {
-	var level slog.Level
-	var handler slog.Handler
+	logConfig := config.LogConfig
 
-	logLevel := "info"
+	logLevel := logConfig.Level
+	if logLevel == "" {
+		logLevel = "info"
 
-	if config.ApiConfig.IsDevelopment() {
-		logLevel = "debug"
+		if config.ApiConfig.IsDevelopment() {
+			logLevel = "debug"
+		}
 	}
 
-	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+	parsed, err := ParseLevel(logLevel)
+	if err != nil {
 		panic(fmt.Errorf("unable to load log level: %v", err))
 	}
 
+	level.Set(parsed)
+
+	output, err := openOutput(logConfig.Output)
+	if err != nil {
+		panic(fmt.Errorf("unable to open log output: %v", err))
+	}
+
 	opts := &slog.HandlerOptions{
-		Level: level,
+		Level:       level,
+		ReplaceAttr: NewRedactor(logConfig.RedactKeys).ReplaceAttr,
 	}
 
-	handler = slog.NewJSONHandler(os.Stdout, opts)
+	format := logConfig.Format
+	if format == "" {
+		format = JsonFormat
+
+		if config.ApiConfig.IsDevelopment() {
+			format = TextFormat
+		}
+	}
+
+	var handler slog.Handler
 
-	if config.ApiConfig.IsDevelopment() {
-		handler = slog.NewTextHandler(os.Stdout, opts)
+	switch format {
+	case JsonFormat:
+		handler = slog.NewJSONHandler(output, opts)
+	case TextFormat:
+		handler = slog.NewTextHandler(output, opts)
+	default:
+		panic(fmt.Errorf("unknown log format %q, use json or text", format))
 	}
 
-	log := slog.New(handler)
-	slog.SetDefault(log)
+	handler = NewContextHandler(handler)
+
+	if logConfig.IsSamplingEnabled() {
+		handler = NewSamplingHandler(handler, logConfig.SampleInitial, logConfig.SampleThereafter, logConfig.SampleTick)
+	}
+
+	slog.SetDefault(slog.New(handler))
+}
+
+// openOutput returns stdout, stderr or the file the logs are appended to
+func openOutput(output string) (io.Writer, error) {
+	switch output {
+	case "", "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	default:
+		return os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	}
 }
@@ -2,26 +2,39 @@ package logger
 
 import (
 	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestSetupLog(t *testing.T) {
+	previous, previousLevel := slog.Default(), Level().Level()
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		Level().Set(previousLevel)
+	})
+
 	t.Run("Should setup log when is development", func(t *testing.T) {
 		// Arrange
 		config := &environment.Config{
 			ApiConfig: &environment.ApiConfig{
 				EnvName: "development",
 			},
+			LogConfig: &environment.LogConfig{},
 		}
 
 		// Act
 		SetupLog(config)
 
 		// Assert
-		assert.IsType(t, &slog.TextHandler{}, slog.Default().Handler())
+		handler, ok := slog.Default().Handler().(*ContextHandler)
+		assert.True(t, ok)
+		assert.IsType(t, &slog.TextHandler{}, handler.Handler)
+		assert.Equal(t, slog.LevelDebug, Level().Level())
 	})
 
 	t.Run("Should setup log when is not development", func(t *testing.T) {
@@ -30,12 +43,143 @@ func TestSetupLog(t *testing.T) {
 			ApiConfig: &environment.ApiConfig{
 				EnvName: "production",
 			},
+			LogConfig: &environment.LogConfig{},
+		}
+
+		// Act
+		SetupLog(config)
+
+		// Assert
+		handler, ok := slog.Default().Handler().(*ContextHandler)
+		assert.True(t, ok)
+		assert.IsType(t, &slog.JSONHandler{}, handler.Handler)
+		assert.Equal(t, slog.LevelInfo, Level().Level())
+	})
+
+	t.Run("Should use the configured level and format", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				EnvName: "production",
+			},
+			LogConfig: &environment.LogConfig{
+				Level:  "WARN",
+				Format: "text",
+				Output: "stderr",
+			},
+		}
+
+		// Act
+		SetupLog(config)
+
+		// Assert
+		handler, ok := slog.Default().Handler().(*ContextHandler)
+		assert.True(t, ok)
+		assert.IsType(t, &slog.TextHandler{}, handler.Handler)
+		assert.Equal(t, slog.LevelWarn, Level().Level())
+	})
+
+	t.Run("Should sample the logs when enabled", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				EnvName: "production",
+			},
+			LogConfig: &environment.LogConfig{
+				SampleInitial:    10,
+				SampleThereafter: 100,
+				SampleTick:       time.Second,
+			},
 		}
 
 		// Act
 		SetupLog(config)
 
 		// Assert
-		assert.IsType(t, &slog.JSONHandler{}, slog.Default().Handler())
+		handler, ok := slog.Default().Handler().(*SamplingHandler)
+		assert.True(t, ok)
+		assert.IsType(t, &ContextHandler{}, handler.Handler)
+	})
+
+	t.Run("Should append the logs to the file", func(t *testing.T) {
+		// Arrange
+		output := filepath.Join(t.TempDir(), "service.log")
+
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				EnvName: "production",
+			},
+			LogConfig: &environment.LogConfig{
+				Output:     output,
+				RedactKeys: []string{"token"},
+			},
+		}
+
+		SetupLog(config)
+
+		// Act
+		slog.Info("message", "token", "my-token")
+
+		// Assert
+		content, err := os.ReadFile(output)
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), `"msg":"message"`)
+		assert.Contains(t, string(content), `"token":"[REDACTED]"`)
+	})
+
+	t.Run("Should panic when the level is unknown", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				EnvName: "production",
+			},
+			LogConfig: &environment.LogConfig{
+				Level: "verbose",
+			},
+		}
+
+		// Act
+		act := func() { SetupLog(config) }
+
+		// Assert
+		assert.Panics(t, act)
+	})
+
+	t.Run("Should panic when the format is unknown", func(t *testing.T) {
+		// Arrange
+		config := &environment.Config{
+			ApiConfig: &environment.ApiConfig{
+				EnvName: "production",
+			},
+			LogConfig: &environment.LogConfig{
+				Format: "xml",
+			},
+		}
+
+		// Act
+		act := func() { SetupLog(config) }
+
+		// Assert
+		assert.Panics(t, act)
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Run("Should parse the level in any case", func(t *testing.T) {
+		// Act
+		level, err := ParseLevel("Debug")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, slog.LevelDebug, level)
+		assert.Equal(t, "debug", FormatLevel(level))
+	})
+
+	t.Run("Should return error when the level is unknown", func(t *testing.T) {
+		// Act
+		_, err := ParseLevel("verbose")
+
+		// Assert
+		assert.EqualError(t, err, `unknown log level "verbose", use debug, info, warn or error`)
 	})
 }
@@ -1,16 +1,30 @@
 package logger
 
 import (
-	"context"
 	"log/slog"
 	"os"
 	"runtime/debug"
 
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/problem"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func Middleware() echo.MiddlewareFunc {
+// skippedPaths are polled by the infrastructure, only their failures are
+// logged
+var skippedPaths = map[string]bool{
+	"/health":       true,
+	"/health/live":  true,
+	"/health/ready": true,
+	"/metrics":      true,
+}
+
+// Middleware logs the failed requests and, when the access log is enabled,
+// every other request. The user is read after the request was handled, so it
+// is known for the routes that require authentication
+func Middleware(config *environment.LogConfig) echo.MiddlewareFunc {
 	buildInfo, _ := debug.ReadBuildInfo()
 
 	child := slog.With(
@@ -19,23 +33,48 @@ func Middleware() echo.MiddlewareFunc {
 			slog.String("go_version", buildInfo.GoVersion),
 		),
 	)
+
+	redactor := NewRedactor(config.RedactKeys)
+
 	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
-		LogStatus:   true,
-		LogURI:      true,
-		LogError:    true,
-		HandleError: true,
+		LogMethod:        true,
+		LogRoutePath:     true,
+		LogURI:           true,
+		LogStatus:        true,
+		LogLatency:       true,
+		LogContentLength: true,
+		LogResponseSize:  true,
+		LogError:         true,
+		HandleError:      true,
 		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			if v.Error == nil && (!config.AccessLog || skippedPaths[v.RoutePath]) {
+				return nil
+			}
+
+			ctx := c.Request().Context()
+
+			attrs := []slog.Attr{
+				slog.String("method", v.Method),
+				slog.String("route", v.RoutePath),
+				slog.String("uri", redactor.RedactUri(v.URI)),
+				slog.Int("status", v.Status),
+				slog.Float64("latency_ms", float64(v.Latency.Microseconds())/1000),
+				slog.String("user_id", token.UserIdFromContext(ctx)),
+				slog.String("bytes_in", v.ContentLength),
+				slog.Int64("bytes_out", v.ResponseSize),
+			}
+
 			if v.Error != nil {
-				child.LogAttrs(
-					context.Background(),
-					slog.LevelError,
-					"request error",
-					slog.String("uri", v.URI),
-					slog.Int("status", v.Status),
+				child.LogAttrs(ctx, slog.LevelError, "request error", append(attrs,
 					slog.String("err", v.Error.Error()),
-				)
+					slog.String("trace_id", problem.TraceId(c)),
+				)...)
+
+				return nil
 			}
 
+			child.LogAttrs(ctx, slog.LevelInfo, "request completed", attrs...)
+
 			return nil
 		},
 	})
@@ -1,46 +1,164 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/jfelipearaujo-org/ms-production-management/internal/environment"
+	token "github.com/jfelipearaujo-org/ms-production-management/internal/server/middlewares"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
+// captureLogs makes the default logger write to the buffer until the end of
+// the test, the middleware must be created after it
+func captureLogs(t *testing.T) *bytes.Buffer {
+	buffer := &bytes.Buffer{}
+
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buffer, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	return buffer
+}
+
+func decodeLines(t *testing.T, buffer *bytes.Buffer) []map[string]interface{} {
+	records := make([]map[string]interface{}, 0)
+
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var record map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
 func TestMiddleware(t *testing.T) {
+	config := &environment.LogConfig{
+		AccessLog:  true,
+		RedactKeys: []string{"token"},
+	}
+
 	t.Run("Should return middleware", func(t *testing.T) {
 		// Arrange
 
 		// Act
-		middleware := Middleware()
+		middleware := Middleware(config)
 
 		// Assert
 		assert.NotNil(t, middleware)
 	})
 
-	t.Run("Should return middleware with request logger", func(t *testing.T) {
+	t.Run("Should log the completed request", func(t *testing.T) {
+		// Arrange
+		buffer := captureLogs(t)
+
+		e := echo.New()
+		e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				ctx := token.WithPrincipal(c.Request().Context(), token.Principal{Subject: "user-id"})
+				c.SetRequest(c.Request().WithContext(ctx))
+				return next(c)
+			}
+		})
+		e.Use(Middleware(config))
+		e.GET("/production/:id", func(c echo.Context) error {
+			return c.String(http.StatusOK, "done")
+		})
+
+		req := httptest.NewRequest(echo.GET, "/production/123?token=my-token&state=Received", nil)
+		resp := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		records := decodeLines(t, buffer)
+		assert.Len(t, records, 1)
+		assert.Equal(t, "INFO", records[0]["level"])
+		assert.Equal(t, "request completed", records[0]["msg"])
+		assert.Equal(t, "GET", records[0]["method"])
+		assert.Equal(t, "/production/:id", records[0]["route"])
+		assert.Equal(t, "/production/123?state=Received&token=%5BREDACTED%5D", records[0]["uri"])
+		assert.Equal(t, float64(http.StatusOK), records[0]["status"])
+		assert.Equal(t, "user-id", records[0]["user_id"])
+		assert.Equal(t, float64(4), records[0]["bytes_out"])
+		assert.Contains(t, records[0], "latency_ms")
+	})
+
+	t.Run("Should log the failed request as an error", func(t *testing.T) {
 		// Arrange
-		middleware := Middleware()
+		buffer := captureLogs(t)
 
-		trigger := func(c echo.Context) error {
+		e := echo.New()
+		e.Use(Middleware(config))
+		e.GET("/production/:id", func(c echo.Context) error {
 			return echo.NewHTTPError(http.StatusBadRequest, errors.New("this is a test"))
-		}
+		})
 
-		req := httptest.NewRequest(echo.GET, "/", nil)
+		req := httptest.NewRequest(echo.GET, "/production/123", nil)
 		resp := httptest.NewRecorder()
 
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		records := decodeLines(t, buffer)
+		assert.Len(t, records, 1)
+		assert.Equal(t, "ERROR", records[0]["level"])
+		assert.Equal(t, "request error", records[0]["msg"])
+		assert.Equal(t, float64(http.StatusBadRequest), records[0]["status"])
+		assert.Contains(t, records[0], "err")
+	})
+
+	t.Run("Should only log the failed requests when the access log is disabled", func(t *testing.T) {
+		// Arrange
+		buffer := captureLogs(t)
+
 		e := echo.New()
-		e.Use(middleware)
+		e.Use(Middleware(&environment.LogConfig{AccessLog: false}))
+		e.GET("/production/:id", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(echo.GET, "/production/123", nil)
+		resp := httptest.NewRecorder()
 
-		ctx := e.NewContext(req, resp)
+		// Act
+		e.ServeHTTP(resp, req)
+
+		// Assert
+		assert.Empty(t, buffer.String())
+	})
+
+	t.Run("Should not log the successful probes", func(t *testing.T) {
+		// Arrange
+		buffer := captureLogs(t)
+
+		e := echo.New()
+		e.Use(Middleware(config))
+		e.GET("/health/ready", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(echo.GET, "/health/ready", nil)
+		resp := httptest.NewRecorder()
 
 		// Act
-		err := trigger(ctx)
+		e.ServeHTTP(resp, req)
 
 		// Assert
-		assert.Error(t, err)
+		assert.Empty(t, buffer.String())
 	})
 }
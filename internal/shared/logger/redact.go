@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// RedactedValue replaces the sensitive values in the logs
+const RedactedValue = "[REDACTED]"
+
+// credentialSchemes are the prefixes of the values of the Authorization
+// header, redacted whatever the key of the attribute
+var credentialSchemes = []string{"bearer ", "basic "}
+
+// Redactor keeps the credentials out of the logs, the attributes and query
+// parameters with a sensitive key have their values replaced
+type Redactor struct {
+	keys map[string]bool
+}
+
+func NewRedactor(keys []string) *Redactor {
+	redactor := &Redactor{
+		keys: make(map[string]bool, len(keys)),
+	}
+
+	for _, key := range keys {
+		redactor.keys[normalizeKey(key)] = true
+	}
+
+	return redactor
+}
+
+// ReplaceAttr is used as the slog.HandlerOptions.ReplaceAttr of the handler
+func (r *Redactor) ReplaceAttr(groups []string, attr slog.Attr) slog.Attr {
+	if r.IsSensitive(attr.Key) {
+		return slog.String(attr.Key, RedactedValue)
+	}
+
+	if attr.Value.Kind() == slog.KindString && hasCredentialScheme(attr.Value.String()) {
+		return slog.String(attr.Key, RedactedValue)
+	}
+
+	return attr
+}
+
+func (r *Redactor) IsSensitive(key string) bool {
+	return r.keys[normalizeKey(key)]
+}
+
+// RedactUri replaces the values of the sensitive query parameters, e.g. the
+// token of the streams, the URI is returned as is when it cannot be parsed
+func (r *Redactor) RedactUri(uri string) string {
+	path, rawQuery, ok := strings.Cut(uri, "?")
+	if !ok {
+		return uri
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return uri
+	}
+
+	redacted := false
+	for key, values := range query {
+		if !r.IsSensitive(key) {
+			continue
+		}
+
+		for i := range values {
+			values[i] = RedactedValue
+		}
+		redacted = true
+	}
+
+	if !redacted {
+		return uri
+	}
+
+	return path + "?" + query.Encode()
+}
+
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+}
+
+func hasCredentialScheme(value string) bool {
+	for _, scheme := range credentialSchemes {
+		if len(value) > len(scheme) && strings.EqualFold(value[:len(scheme)], scheme) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactor(t *testing.T) {
+	t.Run("Should redact the sensitive attributes in any case", func(t *testing.T) {
+		// Arrange
+		buffer := &bytes.Buffer{}
+		redactor := NewRedactor([]string{"authorization", "api_key"})
+		log := slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{ReplaceAttr: redactor.ReplaceAttr}))
+
+		// Act
+		log.Info("message",
+			"Authorization", "my-token",
+			slog.Group("request", slog.String("api-key", "my-key")),
+			"order_id", "123",
+		)
+
+		// Assert
+		var record map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+		assert.Equal(t, RedactedValue, record["Authorization"])
+		assert.Equal(t, map[string]interface{}{"api-key": RedactedValue}, record["request"])
+		assert.Equal(t, "123", record["order_id"])
+	})
+
+	t.Run("Should redact the credentials whatever the key", func(t *testing.T) {
+		// Arrange
+		redactor := NewRedactor(nil)
+
+		// Act
+		bearer := redactor.ReplaceAttr(nil, slog.String("header", "Bearer eyJhbGciOiJIUzI1NiJ9"))
+		basic := redactor.ReplaceAttr(nil, slog.String("header", "basic dXNlcjpwYXNz"))
+		other := redactor.ReplaceAttr(nil, slog.String("header", "bearer"))
+
+		// Assert
+		assert.Equal(t, RedactedValue, bearer.Value.String())
+		assert.Equal(t, RedactedValue, basic.Value.String())
+		assert.Equal(t, "bearer", other.Value.String())
+	})
+
+	t.Run("Should redact the sensitive query parameters", func(t *testing.T) {
+		// Arrange
+		redactor := NewRedactor([]string{"access_token"})
+
+		// Act
+		redacted := redactor.RedactUri("/api/v1/production/stream?Access-Token=my-token&state=Received")
+
+		// Assert
+		assert.Equal(t, "/api/v1/production/stream?Access-Token=%5BREDACTED%5D&state=Received", redacted)
+	})
+
+	t.Run("Should keep the URI without sensitive query parameters", func(t *testing.T) {
+		// Arrange
+		redactor := NewRedactor([]string{"token"})
+
+		// Act
+		withQuery := redactor.RedactUri("/production?state=Received,Processing")
+		withoutQuery := redactor.RedactUri("/production/123")
+
+		// Assert
+		assert.Equal(t, "/production?state=Received,Processing", withQuery)
+		assert.Equal(t, "/production/123", withoutQuery)
+	})
+}
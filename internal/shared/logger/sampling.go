@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// SamplingHandler limits the records logged with the same message, the first
+// ones of each tick are logged and then only one of every thereafter, so a
+// burst of messages does not flood the logs. Warnings and errors are never
+// sampled
+type SamplingHandler struct {
+	slog.Handler
+
+	sampler *sampler
+}
+
+func NewSamplingHandler(handler slog.Handler, initial int, thereafter int, tick time.Duration) *SamplingHandler {
+	return &SamplingHandler{
+		Handler: handler,
+		sampler: &sampler{
+			initial:    initial,
+			thereafter: max(thereafter, 1),
+			tick:       tick,
+			counters:   make(map[string]*counter),
+		},
+	}
+}
+
+func (h *SamplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level < slog.LevelWarn && !h.sampler.allow(record) {
+		return nil
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs and WithGroup share the sampler, the records are counted by
+// message whatever the attributes of the logger
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{Handler: h.Handler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{Handler: h.Handler.WithGroup(name), sampler: h.sampler}
+}
+
+type counter struct {
+	resetAt time.Time
+	count   int
+}
+
+type sampler struct {
+	initial    int
+	thereafter int
+	tick       time.Duration
+
+	// the messages are constants in the code, so the counters do not grow
+	// unbounded
+	mutex    sync.Mutex
+	counters map[string]*counter
+}
+
+// allow counts the record in the tick of its time, the records without time
+// are always logged
+func (s *sampler) allow(record slog.Record) bool {
+	if record.Time.IsZero() {
+		return true
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	c, ok := s.counters[record.Message]
+	if !ok || !record.Time.Before(c.resetAt) {
+		c = &counter{resetAt: record.Time.Add(s.tick)}
+		s.counters[record.Message] = c
+	}
+
+	c.count++
+
+	if c.count <= s.initial {
+		return true
+	}
+
+	return (c.count-s.initial)%s.thereafter == 0
+}
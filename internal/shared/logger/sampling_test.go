@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplingHandler(t *testing.T) {
+	now := time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC)
+
+	handle := func(handler slog.Handler, level slog.Level, message string, at time.Time) {
+		_ = handler.Handle(context.Background(), slog.NewRecord(at, level, message, 0))
+	}
+
+	count := func(buffer *bytes.Buffer, message string) int {
+		return strings.Count(buffer.String(), "msg="+message)
+	}
+
+	t.Run("Should log the initial records and then one of every thereafter", func(t *testing.T) {
+		// Arrange
+		buffer := &bytes.Buffer{}
+		handler := NewSamplingHandler(slog.NewTextHandler(buffer, nil), 2, 3, time.Second)
+
+		// Act
+		for i := 0; i < 10; i++ {
+			handle(handler, slog.LevelInfo, "received", now)
+		}
+
+		// Assert
+		assert.Equal(t, 4, count(buffer, "received"))
+	})
+
+	t.Run("Should count the messages separately", func(t *testing.T) {
+		// Arrange
+		buffer := &bytes.Buffer{}
+		handler := NewSamplingHandler(slog.NewTextHandler(buffer, nil), 1, 100, time.Second)
+
+		// Act
+		handle(handler, slog.LevelInfo, "received", now)
+		handle(handler, slog.LevelInfo, "received", now)
+		handle(handler, slog.LevelInfo, "processed", now)
+
+		// Assert
+		assert.Equal(t, 1, count(buffer, "received"))
+		assert.Equal(t, 1, count(buffer, "processed"))
+	})
+
+	t.Run("Should restart the count on the next tick", func(t *testing.T) {
+		// Arrange
+		buffer := &bytes.Buffer{}
+		handler := NewSamplingHandler(slog.NewTextHandler(buffer, nil), 1, 100, time.Second)
+
+		// Act
+		handle(handler, slog.LevelInfo, "received", now)
+		handle(handler, slog.LevelInfo, "received", now.Add(500*time.Millisecond))
+		handle(handler, slog.LevelInfo, "received", now.Add(time.Second))
+
+		// Assert
+		assert.Equal(t, 2, count(buffer, "received"))
+	})
+
+	t.Run("Should never sample the warnings and errors", func(t *testing.T) {
+		// Arrange
+		buffer := &bytes.Buffer{}
+		handler := NewSamplingHandler(slog.NewTextHandler(buffer, nil), 1, 100, time.Second)
+
+		// Act
+		for i := 0; i < 5; i++ {
+			handle(handler, slog.LevelError, "failed", now)
+		}
+
+		// Assert
+		assert.Equal(t, 5, count(buffer, "failed"))
+	})
+
+	t.Run("Should share the counters with the derived handlers", func(t *testing.T) {
+		// Arrange
+		buffer := &bytes.Buffer{}
+		handler := NewSamplingHandler(slog.NewTextHandler(buffer, nil), 1, 100, time.Second)
+		derived := handler.WithAttrs([]slog.Attr{slog.String("queue", "orders")})
+
+		// Act
+		handle(handler, slog.LevelInfo, "received", now)
+		handle(derived, slog.LevelInfo, "received", now)
+
+		// Assert
+		assert.Equal(t, 1, count(buffer, "received"))
+	})
+}
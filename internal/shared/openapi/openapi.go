@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	//go:embed openapi.yaml
+	spec []byte
+
+	parseOnce sync.Once
+	document  map[string]interface{}
+	specJSON  []byte
+	parseErr  error
+)
+
+// Operation identifies an endpoint documented in the specification
+type Operation struct {
+	Method string
+	Path   string
+}
+
+func (o Operation) String() string {
+	return fmt.Sprintf("%s %s", o.Method, o.Path)
+}
+
+// YAML returns the specification as it was written
+func YAML() []byte {
+	return spec
+}
+
+// JSON returns the specification converted to JSON
+func JSON() ([]byte, error) {
+	if err := parse(); err != nil {
+		return nil, err
+	}
+
+	return specJSON, nil
+}
+
+// Operations lists every method and path of the specification, sorted by path
+func Operations() ([]Operation, error) {
+	if err := parse(); err != nil {
+		return nil, err
+	}
+
+	paths, ok := document["paths"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the specification has no paths")
+	}
+
+	operations := make([]Operation, 0)
+
+	for path, item := range paths {
+		methods, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the path %s is not an object", path)
+		}
+
+		for method := range methods {
+			method = strings.ToUpper(method)
+			if !isHttpMethod(method) {
+				continue
+			}
+
+			operations = append(operations, Operation{Method: method, Path: path})
+		}
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].Path == operations[j].Path {
+			return operations[i].Method < operations[j].Method
+		}
+		return operations[i].Path < operations[j].Path
+	})
+
+	return operations, nil
+}
+
+func parse() error {
+	parseOnce.Do(func() {
+		if parseErr = yaml.Unmarshal(spec, &document); parseErr != nil {
+			return
+		}
+
+		specJSON, parseErr = json.Marshal(document)
+	})
+
+	return parseErr
+}
+
+func isHttpMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON(t *testing.T) {
+	t.Run("Should convert the specification to JSON", func(t *testing.T) {
+		// Arrange
+		var document map[string]interface{}
+
+		// Act
+		content, err := JSON()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(content, &document))
+		assert.Equal(t, "3.0.3", document["openapi"])
+	})
+}
+
+func TestOperations(t *testing.T) {
+	t.Run("Should list the operations of the specification", func(t *testing.T) {
+		// Arrange
+		// Act
+		operations, err := Operations()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, operations, Operation{Method: "GET", Path: "/health"})
+		assert.Contains(t, operations, Operation{Method: "PATCH", Path: "/api/v1/production/{id}"})
+		assert.NotContains(t, operations, Operation{Method: "PARAMETERS", Path: "/api/v1/production/{id}"})
+	})
+}
+
+func TestReferences(t *testing.T) {
+	t.Run("Should resolve every component reference", func(t *testing.T) {
+		// Arrange
+		content, err := JSON()
+		assert.NoError(t, err)
+
+		var document map[string]interface{}
+		assert.NoError(t, json.Unmarshal(content, &document))
+
+		components := document["components"].(map[string]interface{})
+
+		// Act
+		refs := collectRefs(document)
+
+		// Assert
+		assert.NotEmpty(t, refs)
+		for _, ref := range refs {
+			parts := strings.Split(strings.TrimPrefix(ref, "#/components/"), "/")
+			if assert.Len(t, parts, 2, ref) {
+				group, ok := components[parts[0]].(map[string]interface{})
+				if assert.True(t, ok, ref) {
+					assert.Contains(t, group, parts[1], ref)
+				}
+			}
+		}
+	})
+}
+
+func collectRefs(value interface{}) []string {
+	refs := make([]string, 0)
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				refs = append(refs, ref)
+				continue
+			}
+			refs = append(refs, collectRefs(item)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			refs = append(refs, collectRefs(item)...)
+		}
+	}
+
+	return refs
+}
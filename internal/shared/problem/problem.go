@@ -0,0 +1,197 @@
+package problem
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	MIMEApplicationProblemJSON = "application/problem+json"
+
+	// TraceIdKey is the key of the trace id in the echo context
+	TraceIdKey = "traceId"
+
+	internalErrorDetail = "an unexpected error occurred, inform the trace id to the support"
+)
+
+// Problem is the RFC 7807 body of every error returned by the API
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Code is stable and identifies the error, see custom_error.ErrorCode
+	Code       string                   `json:"code"`
+	TraceId    string                   `json:"trace_id,omitempty"`
+	RequestId  string                   `json:"request_id,omitempty"`
+	Violations []custom_error.Violation `json:"violations,omitempty"`
+}
+
+// New converts the error returned by a handler to a problem, the detail of
+// internal errors is only exposed in development
+func New(err error, development bool) Problem {
+	var violations []custom_error.Violation
+
+	var httpErr *echo.HTTPError
+	var bindingErr *echo.BindingError
+	if errors.As(err, &bindingErr) {
+		httpErr = bindingErr.HTTPError
+		violations = []custom_error.Violation{{
+			Field:   bindingErr.Field,
+			Rule:    "type",
+			Message: "has a value that cannot be converted to the expected type",
+		}}
+	} else if !errors.As(err, &httpErr) {
+		httpErr = &echo.HTTPError{
+			Code:     http.StatusInternalServerError,
+			Message:  err.Error(),
+			Internal: err,
+		}
+
+		if buErr, ok := custom_error.AsBusinessErr(err); ok {
+			httpErr.Code = buErr.Code()
+			httpErr.Message = custom_error.NewAppError(err)
+		}
+	}
+
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(httpErr.Code),
+		Status: httpErr.Code,
+		Code:   custom_error.StatusCode(httpErr.Code),
+
+		Violations: violations,
+	}
+
+	switch message := httpErr.Message.(type) {
+	case custom_error.AppError:
+		problem.Title = message.Message
+		problem.Detail = message.Details
+	case string:
+		problem.Detail = message
+	case error:
+		problem.Detail = message.Error()
+	default:
+		problem.Detail = fmt.Sprintf("%v", message)
+	}
+
+	cause := httpErr.Internal
+	if cause == nil {
+		cause = err
+	}
+
+	switch buErr, ok := custom_error.AsBusinessErr(cause); {
+	case ok:
+		problem.Code = custom_error.ErrorCode(buErr)
+		problem.Violations = custom_error.GetViolations(cause)
+	case errors.Is(err, echo.ErrNotFound):
+		problem.Code = "ROUTE_NOT_FOUND"
+		problem.Detail = "no route matches the method and path of the request"
+	case problem.Status == http.StatusBadRequest && (httpErr.Internal != nil || bindingErr != nil):
+		// echo reports the bodies, queries and params it cannot bind as 400
+		problem.Code = "MALFORMED_REQUEST"
+	}
+
+	if problem.Status >= http.StatusInternalServerError {
+		problem.Title = http.StatusText(problem.Status)
+
+		if !development {
+			problem.Detail = internalErrorDetail
+		}
+	}
+
+	return problem
+}
+
+// ErrorHandler writes every error, including the unknown routes, the bind
+// errors and the recovered panics, as application/problem+json
+func ErrorHandler(development bool) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		problem := New(err, development)
+		problem.Instance = c.Request().URL.Path
+		problem.TraceId = TraceId(c)
+		problem.RequestId = correlation.RequestIdFromContext(c.Request().Context())
+
+		c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(problem.Status)
+		} else {
+			err = c.JSON(problem.Status, problem)
+		}
+
+		if err != nil {
+			slog.ErrorContext(c.Request().Context(), "error writing the problem", "error", err)
+		}
+	}
+}
+
+// TraceId returns the trace id of the request, taken from the span of the
+// request, the W3C traceparent or the X-Request-Id headers, a new one is
+// generated when none is informed
+func TraceId(c echo.Context) string {
+	if traceId, ok := c.Get(TraceIdKey).(string); ok && traceId != "" {
+		return traceId
+	}
+
+	var traceId string
+
+	if spanContext := trace.SpanContextFromContext(c.Request().Context()); spanContext.HasTraceID() {
+		traceId = spanContext.TraceID().String()
+	}
+
+	if traceId == "" {
+		traceId = parseTraceParent(c.Request().Header.Get("traceparent"))
+	}
+
+	if traceId == "" {
+		traceId = c.Request().Header.Get(echo.HeaderXRequestID)
+	}
+
+	if traceId == "" {
+		traceId = newTraceId()
+	}
+
+	c.Set(TraceIdKey, traceId)
+
+	return traceId
+}
+
+// parseTraceParent returns the trace id of version-traceid-parentid-flags
+func parseTraceParent(header string) string {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 {
+		return ""
+	}
+
+	if _, err := hex.DecodeString(parts[1]); err != nil || parts[1] == strings.Repeat("0", 32) {
+		return ""
+	}
+
+	return parts[1]
+}
+
+func newTraceId() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(id)
+}
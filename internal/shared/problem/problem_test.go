@@ -0,0 +1,259 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/correlation"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Should convert a business error", func(t *testing.T) {
+		// Arrange
+		err := custom_error.NewHttpAppErrorFromBusinessError(custom_error.ErrOrderNotFound)
+
+		// Act
+		res := New(err, false)
+
+		// Assert
+		assert.Equal(t, Problem{
+			Type:   "about:blank",
+			Title:  "unable to find the order",
+			Status: http.StatusNotFound,
+			Detail: "order not found",
+			Code:   "ORDER_NOT_FOUND",
+		}, res)
+	})
+
+	t.Run("Should convert a validation error with the violations", func(t *testing.T) {
+		// Arrange
+		violation := custom_error.Violation{Field: "state", Rule: "required", Message: "is required"}
+		err := custom_error.NewHttpAppErrorFromBusinessError(custom_error.NewValidationError(violation))
+
+		// Act
+		res := New(err, false)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+		assert.Equal(t, "REQUEST_NOT_VALID", res.Code)
+		assert.Equal(t, []custom_error.Violation{violation}, res.Violations)
+	})
+
+	t.Run("Should convert a business error returned without the HTTP error", func(t *testing.T) {
+		// Arrange
+		err := custom_error.ErrOrderAlreadyExists
+
+		// Act
+		res := New(err, false)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, "ORDER_ALREADY_EXISTS", res.Code)
+		assert.Equal(t, "unable to create the order", res.Title)
+	})
+
+	t.Run("Should hide the internal error outside development", func(t *testing.T) {
+		// Arrange
+		err := custom_error.NewHttpAppError(http.StatusInternalServerError, "internal server error", errors.New("pq: connection refused"))
+
+		// Act
+		res := New(err, false)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, res.Status)
+		assert.Equal(t, "INTERNAL_SERVER_ERROR", res.Code)
+		assert.Equal(t, "Internal Server Error", res.Title)
+		assert.NotContains(t, res.Detail, "pq")
+	})
+
+	t.Run("Should expose the internal error in development", func(t *testing.T) {
+		// Arrange
+		err := errors.New("pq: connection refused")
+
+		// Act
+		res := New(err, true)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, res.Status)
+		assert.Equal(t, "pq: connection refused", res.Detail)
+	})
+
+	t.Run("Should convert an HTTP error without business meaning", func(t *testing.T) {
+		// Arrange
+		err := echo.NewHTTPError(http.StatusUnauthorized, "Token is required")
+
+		// Act
+		res := New(err, false)
+
+		// Assert
+		assert.Equal(t, Problem{
+			Type:   "about:blank",
+			Title:  "Unauthorized",
+			Status: http.StatusUnauthorized,
+			Detail: "Token is required",
+			Code:   "UNAUTHORIZED",
+		}, res)
+	})
+
+	t.Run("Should convert a binding error with the field", func(t *testing.T) {
+		// Arrange
+		err := echo.NewBindingError("limit", []string{"abc"}, "strconv.ParseInt: parsing \"abc\": invalid syntax", errors.New("invalid syntax"))
+
+		// Act
+		res := New(err, false)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, "MALFORMED_REQUEST", res.Code)
+		assert.Len(t, res.Violations, 1)
+		assert.Equal(t, "limit", res.Violations[0].Field)
+	})
+}
+
+func TestErrorHandler(t *testing.T) {
+	newEcho := func() *echo.Echo {
+		e := echo.New()
+		e.HTTPErrorHandler = ErrorHandler(false)
+		e.Use(middleware.Recover())
+
+		e.POST("/orders", func(c echo.Context) error {
+			var body struct {
+				Id string `json:"id"`
+			}
+			return c.Bind(&body)
+		})
+		e.GET("/panic", func(c echo.Context) error {
+			panic("something went wrong")
+		})
+
+		return e
+	}
+
+	serve := func(e *echo.Echo, req *http.Request) (*httptest.ResponseRecorder, Problem) {
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+
+		var problem Problem
+		_ = json.Unmarshal(resp.Body.Bytes(), &problem)
+
+		return resp, problem
+	}
+
+	t.Run("Should write unknown routes as problem", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+
+		// Act
+		resp, problem := serve(newEcho(), req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, resp.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "ROUTE_NOT_FOUND", problem.Code)
+		assert.Equal(t, "/unknown", problem.Instance)
+		assert.Len(t, problem.TraceId, 32)
+	})
+
+	t.Run("Should write bind errors as problem", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("{"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		// Act
+		resp, problem := serve(newEcho(), req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, resp.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "MALFORMED_REQUEST", problem.Code)
+	})
+
+	t.Run("Should write panics as problem without the panic message", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+
+		// Act
+		resp, problem := serve(newEcho(), req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.Equal(t, "INTERNAL_SERVER_ERROR", problem.Code)
+		assert.NotContains(t, resp.Body.String(), "something went wrong")
+	})
+
+	t.Run("Should use the trace id of the span of the request", func(t *testing.T) {
+		// Arrange
+		traceId, _ := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+		spanId, _ := trace.SpanIDFromHex("b7ad6b7169203331")
+
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		req = req.WithContext(trace.ContextWithSpanContext(req.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceId,
+			SpanID:  spanId,
+		})))
+
+		// Act
+		_, problem := serve(newEcho(), req)
+
+		// Assert
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", problem.TraceId)
+	})
+
+	t.Run("Should use the trace id of the traceparent header", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		// Act
+		_, problem := serve(newEcho(), req)
+
+		// Assert
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", problem.TraceId)
+	})
+
+	t.Run("Should use the request id when there is no traceparent", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		req.Header.Set(echo.HeaderXRequestID, "my-request")
+
+		// Act
+		_, problem := serve(newEcho(), req)
+
+		// Assert
+		assert.Equal(t, "my-request", problem.TraceId)
+	})
+
+	t.Run("Should write the request id of the context", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		req = req.WithContext(correlation.WithIds(req.Context(), "request-id", ""))
+
+		// Act
+		_, problem := serve(newEcho(), req)
+
+		// Assert
+		assert.Equal(t, "request-id", problem.RequestId)
+	})
+
+	t.Run("Should not write a body to HEAD requests", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodHead, "/unknown", nil)
+
+		// Act
+		resp, _ := serve(newEcho(), req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Empty(t, resp.Body.String())
+	})
+}
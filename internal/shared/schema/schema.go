@@ -0,0 +1,173 @@
+package schema
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+)
+
+const (
+	OrderProductionSchema = "order-production.v1"
+	UpdateOrderSchema     = "update-order.v1"
+	OrderEventSchema      = "order-event.v2"
+)
+
+var (
+	ErrSchemaNotFound = errors.New("schema not found")
+
+	//go:embed schemas/*.json
+	files embed.FS
+
+	sources = map[string]string{
+		OrderProductionSchema: "schemas/order_production.v1.json",
+		UpdateOrderSchema:     "schemas/update_order.v1.json",
+		OrderEventSchema:      "schemas/order_event.v2.json",
+	}
+
+	compileOnce sync.Once
+	compiled    map[string]*jsonschema.Schema
+	compileErr  error
+)
+
+// ValidationError lists every reason why a message does not match its schema
+type ValidationError struct {
+	Schema  string
+	Reasons []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("message does not match schema %s: %s", e.Schema, strings.Join(e.Reasons, "; "))
+}
+
+// Names returns the name of every available schema
+func Names() []string {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Get returns the raw content of the schema
+func Get(name string) ([]byte, error) {
+	source, ok := sources[name]
+	if !ok {
+		return nil, ErrSchemaNotFound
+	}
+
+	return files.ReadFile(source)
+}
+
+// Validate checks the message against the schema, returning a ValidationError
+// when the message does not match it
+func Validate(name string, message []byte) error {
+	schemas, err := compile()
+	if err != nil {
+		return err
+	}
+
+	sch, ok := schemas[name]
+	if !ok {
+		return ErrSchemaNotFound
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(message))
+	if err != nil {
+		return &ValidationError{
+			Schema:  name,
+			Reasons: []string{fmt.Sprintf("invalid json: %v", err)},
+		}
+	}
+
+	err = sch.Validate(instance)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	return &ValidationError{
+		Schema:  name,
+		Reasons: reasons(validationErr.BasicOutput()),
+	}
+}
+
+func reasons(output *jsonschema.OutputUnit) []string {
+	reasons := make([]string, 0, len(output.Errors))
+
+	for _, unit := range output.Errors {
+		// groups only wrap the nested errors that are also in the list
+		if unit.Error == nil || isGroup(unit.Error.Kind) {
+			continue
+		}
+
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+
+		reasons = append(reasons, fmt.Sprintf("%s: %s", location, unit.Error.String()))
+	}
+
+	return reasons
+}
+
+func isGroup(k jsonschema.ErrorKind) bool {
+	switch k.(type) {
+	case *kind.Group, *kind.Schema:
+		return true
+	default:
+		return false
+	}
+}
+
+func compile() (map[string]*jsonschema.Schema, error) {
+	compileOnce.Do(func() {
+		compiler := jsonschema.NewCompiler()
+		compiler.AssertFormat()
+
+		compiled = make(map[string]*jsonschema.Schema, len(sources))
+
+		for name, source := range sources {
+			content, err := files.ReadFile(source)
+			if err != nil {
+				compileErr = err
+				return
+			}
+
+			doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(content))
+			if err != nil {
+				compileErr = fmt.Errorf("error reading schema %s: %w", name, err)
+				return
+			}
+
+			if err := compiler.AddResource(source, doc); err != nil {
+				compileErr = fmt.Errorf("error adding schema %s: %w", name, err)
+				return
+			}
+
+			sch, err := compiler.Compile(source)
+			if err != nil {
+				compileErr = fmt.Errorf("error compiling schema %s: %w", name, err)
+				return
+			}
+
+			compiled[name] = sch
+		}
+	})
+
+	return compiled, compileErr
+}
@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNames(t *testing.T) {
+	t.Run("Should return every schema name", func(t *testing.T) {
+		// Arrange
+		// Act
+		names := Names()
+
+		// Assert
+		assert.Equal(t, []string{OrderEventSchema, OrderProductionSchema, UpdateOrderSchema}, names)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("Should return the schema content", func(t *testing.T) {
+		// Arrange
+		// Act
+		content, err := Get(UpdateOrderSchema)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "urn:fastfood:production:update-order:v1")
+	})
+
+	t.Run("Should return error when schema does not exist", func(t *testing.T) {
+		// Arrange
+		// Act
+		content, err := Get("unknown")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSchemaNotFound)
+		assert.Nil(t, content)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Should validate a message", func(t *testing.T) {
+		// Arrange
+		message := []byte(`{"order_id":"c3fdab1b-3c06-4db2-9edc-4760a2429462","items":[{"id":"cfdab175-1f86-4fb0-9bcb-15f2c58df30c","name":"Hamburger","quantity":1}]}`)
+
+		// Act
+		err := Validate(OrderProductionSchema, message)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return every rejection reason", func(t *testing.T) {
+		// Arrange
+		message := []byte(`{"order_id":"invalid","items":[{"id":"cfdab175-1f86-4fb0-9bcb-15f2c58df30c","quantity":0}]}`)
+
+		// Act
+		err := Validate(OrderProductionSchema, message)
+
+		// Assert
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, OrderProductionSchema, validationErr.Schema)
+		assert.Len(t, validationErr.Reasons, 3)
+		assert.Contains(t, err.Error(), "/order_id")
+		assert.Contains(t, err.Error(), "/items/0/quantity")
+		assert.Contains(t, err.Error(), "/items/0")
+	})
+
+	t.Run("Should return error when message is not a json", func(t *testing.T) {
+		// Arrange
+		message := []byte(`invalid`)
+
+		// Act
+		err := Validate(OrderProductionSchema, message)
+
+		// Assert
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "invalid json")
+	})
+
+	t.Run("Should return error when schema does not exist", func(t *testing.T) {
+		// Arrange
+		// Act
+		err := Validate("unknown", []byte(`{}`))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSchemaNotFound)
+	})
+}
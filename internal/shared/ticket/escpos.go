@@ -0,0 +1,82 @@
+package ticket
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ESC/POS commands, as documented by Epson and supported by most thermal
+// printers
+var (
+	escPosInitialize   = []byte{0x1b, '@'}
+	escPosCodePage1252 = []byte{0x1b, 't', 16}
+	escPosAlignLeft    = []byte{0x1b, 'a', 0}
+	escPosAlignCenter  = []byte{0x1b, 'a', 1}
+	escPosBoldOn       = []byte{0x1b, 'E', 1}
+	escPosBoldOff      = []byte{0x1b, 'E', 0}
+	escPosDoubleSize   = []byte{0x1d, '!', 0x11}
+	escPosNormalSize   = []byte{0x1d, '!', 0x00}
+	escPosFeed         = []byte{0x1b, 'd', 4}
+	escPosCut          = []byte{0x1d, 'V', 66, 0}
+)
+
+// EscPos renders the ticket for thermal printers with lines of width
+// characters, the paper is cut at the end
+func EscPos(t Ticket, width int) []byte {
+	var buffer bytes.Buffer
+
+	buffer.Write(escPosInitialize)
+	buffer.Write(escPosCodePage1252)
+
+	for _, line := range t.layout(width) {
+		if line.center {
+			buffer.Write(escPosAlignCenter)
+		} else {
+			buffer.Write(escPosAlignLeft)
+		}
+
+		if line.bold {
+			buffer.Write(escPosBoldOn)
+		}
+
+		if line.large {
+			buffer.Write(escPosDoubleSize)
+		}
+
+		buffer.Write(encode(line.text))
+		buffer.WriteByte('\n')
+
+		if line.large {
+			buffer.Write(escPosNormalSize)
+		}
+
+		if line.bold {
+			buffer.Write(escPosBoldOff)
+		}
+	}
+
+	buffer.Write(escPosAlignLeft)
+	buffer.Write(escPosFeed)
+	buffer.Write(escPosCut)
+
+	return buffer.Bytes()
+}
+
+// encode converts the text to the Windows-1252 code page, it matches Latin-1
+// for the accented letters. The characters out of it are replaced by '?'
+func encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+
+	for _, r := range strings.ToValidUTF8(text, "?") {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			encoded = append(encoded, ' ')
+		case r < 0x80 || (r >= 0xa0 && r <= 0xff):
+			encoded = append(encoded, byte(r))
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+
+	return encoded
+}
@@ -0,0 +1,36 @@
+package ticket
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscPos(t *testing.T) {
+	t.Run("Should render the ticket as ESC/POS", func(t *testing.T) {
+		// Arrange
+		ticket := newTicket()
+
+		// Act
+		res := EscPos(ticket, DefaultWidth)
+
+		// Assert
+		assert.True(t, bytes.HasPrefix(res, []byte{0x1b, '@', 0x1b, 't', 16}))
+		assert.True(t, bytes.HasSuffix(res, []byte{0x1d, 'V', 66, 0}))
+		assert.Contains(t, string(res), "\x1d!\x11C3FDAB\n\x1d!\x00")
+		assert.Contains(t, string(res), "\x1bE\x012x Hamburger\n\x1bE\x00")
+		assert.True(t, bytes.Contains(res, []byte("1x P\xe3o de queijo\n")))
+	})
+
+	t.Run("Should replace the characters out of the code page", func(t *testing.T) {
+		// Arrange
+		text := "Café ☕\tok"
+
+		// Act
+		res := encode(text)
+
+		// Assert
+		assert.Equal(t, []byte("Caf\xe9 ? ok"), res)
+	})
+}
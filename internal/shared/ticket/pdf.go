@@ -0,0 +1,108 @@
+package ticket
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	pdfPageWidth  = 227.0 // 80mm
+	pdfMargin     = 12.0
+	pdfFontSize   = 8.0
+	pdfLineHeight = 11.0
+
+	// courierAdvance is the width of a character of the Courier fonts, in
+	// units of the font size
+	courierAdvance = 0.6
+)
+
+// PDF renders the ticket as a single page document with the width of the
+// thermal paper, for the kitchens without ESC/POS printers. The monospaced
+// Courier is used so the lines look the same as on the paper
+func PDF(t Ticket) []byte {
+	lines := t.layout(DefaultWidth)
+
+	height := 2 * pdfMargin
+	for _, line := range lines {
+		height += lineHeight(line)
+	}
+
+	var content bytes.Buffer
+
+	y := height - pdfMargin
+
+	for _, line := range lines {
+		y -= lineHeight(line)
+
+		font := "F1"
+		if line.bold {
+			font = "F2"
+		}
+
+		size := pdfFontSize
+		if line.large {
+			size *= 2
+		}
+
+		x := pdfMargin
+		if line.center {
+			x = (pdfPageWidth - float64(len(encode(line.text)))*size*courierAdvance) / 2
+		}
+
+		fmt.Fprintf(&content, "BT /%s %.0f Tf 1 0 0 1 %.2f %.2f Tm (%s) Tj ET\n", font, size, x, y, escapePDF(encode(line.text)))
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", pdfPageWidth, height),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var document bytes.Buffer
+
+	document.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, 0, len(objects))
+
+	for i, object := range objects {
+		offsets = append(offsets, document.Len())
+		fmt.Fprintf(&document, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := document.Len()
+
+	fmt.Fprintf(&document, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&document, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&document, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return document.Bytes()
+}
+
+func lineHeight(l line) float64 {
+	if l.large {
+		return 2 * pdfLineHeight
+	}
+
+	return pdfLineHeight
+}
+
+// escapePDF escapes the delimiters of the literal strings
+func escapePDF(text []byte) []byte {
+	escaped := make([]byte, 0, len(text))
+
+	for _, b := range text {
+		if b == '\\' || b == '(' || b == ')' {
+			escaped = append(escaped, '\\')
+		}
+
+		escaped = append(escaped, b)
+	}
+
+	return escaped
+}
@@ -0,0 +1,46 @@
+package ticket
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDF(t *testing.T) {
+	t.Run("Should render the ticket as PDF", func(t *testing.T) {
+		// Arrange
+		ticket := newTicket()
+
+		// Act
+		res := PDF(ticket)
+
+		// Assert
+		assert.True(t, bytes.HasPrefix(res, []byte("%PDF-1.4\n")))
+		assert.True(t, bytes.HasSuffix(res, []byte("%%EOF\n")))
+		assert.Contains(t, string(res), "/F2 16 Tf")
+		assert.Contains(t, string(res), "(C3FDAB) Tj")
+		assert.True(t, bytes.Contains(res, []byte("(1x P\xe3o de queijo) Tj")))
+
+		match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(res)
+		assert.NotNil(t, match)
+
+		offset, err := strconv.Atoi(string(match[1]))
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(res[offset:], []byte("xref\n")))
+	})
+
+	t.Run("Should escape the string delimiters", func(t *testing.T) {
+		// Arrange
+		ticket := newTicket()
+		ticket.Items[0].Modifiers = []string{`sauce (spicy) \ hot`}
+
+		// Act
+		res := PDF(ticket)
+
+		// Assert
+		assert.Contains(t, string(res), `(   - sauce \(spicy\) \\ hot) Tj`)
+	})
+}
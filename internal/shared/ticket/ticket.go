@@ -0,0 +1,143 @@
+package ticket
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+)
+
+// DefaultWidth is the number of characters of a line in a 80mm paper
+const DefaultWidth = 42
+
+const receivedAtLayout = "02/01/2006 15:04"
+
+// Ticket is the paper the kitchen prepares the order from
+type Ticket struct {
+	StoreId    string
+	Code       string
+	OrderId    string
+	Origin     order_entity.OrderOrigin
+	ReceivedAt time.Time
+	Items      []order_entity.Item
+}
+
+func New(order order_entity.Order, storeId string) Ticket {
+	return Ticket{
+		StoreId:    storeId,
+		Code:       order.DisplayCode(),
+		OrderId:    order.Id,
+		Origin:     order.Origin,
+		ReceivedAt: order.CreatedAt,
+		Items:      order.Items,
+	}
+}
+
+// line is a line of the ticket, large lines use twice the width of a character
+type line struct {
+	text   string
+	bold   bool
+	large  bool
+	center bool
+}
+
+// layout breaks the ticket in lines of at most width characters, shared by
+// every format so the paper and the PDF look the same
+func (t Ticket) layout(width int) []line {
+	lines := make([]line, 0, len(t.Items)*2+8)
+
+	if t.StoreId != "" {
+		lines = append(lines, line{text: t.StoreId, center: true})
+	}
+
+	lines = append(lines,
+		line{text: t.Code, bold: true, large: true, center: true},
+		line{text: "Order " + t.OrderId, center: true},
+		line{text: "Received " + t.ReceivedAt.In(order_entity.Location()).Format(receivedAtLayout), center: true},
+	)
+
+	if t.Origin == order_entity.ManualOrigin {
+		lines = append(lines, line{text: "Manual order", center: true})
+	}
+
+	separator := line{text: strings.Repeat("-", width)}
+
+	lines = append(lines, separator)
+
+	quantity := 0
+
+	for _, item := range t.Items {
+		quantity += item.Quantity
+
+		for _, text := range wrap(fmt.Sprintf("%dx %s", item.Quantity, item.Name), width, "   ") {
+			lines = append(lines, line{text: text, bold: true})
+		}
+
+		if item.Station != "" {
+			for _, text := range wrap("   ["+item.Station+"]", width, "    ") {
+				lines = append(lines, line{text: text})
+			}
+		}
+
+		for _, modifier := range item.Modifiers {
+			for _, text := range wrap("   - "+modifier, width, "     ") {
+				lines = append(lines, line{text: text})
+			}
+		}
+	}
+
+	lines = append(lines,
+		separator,
+		line{text: fmt.Sprintf("%d item(s)", quantity)},
+	)
+
+	for i := range lines {
+		lines[i].text = truncate(lines[i].text, lines[i].columns(width))
+	}
+
+	return lines
+}
+
+// columns is how many characters fit in the line
+func (l line) columns(width int) int {
+	if l.large {
+		return width / 2
+	}
+
+	return width
+}
+
+// wrap breaks the text at the spaces, the next lines start with the indent
+func wrap(text string, width int, indent string) []string {
+	lines := make([]string, 0, 1)
+
+	words := strings.Fields(text)
+	current := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	empty := true
+
+	for _, word := range words {
+		switch {
+		case empty:
+			current += word
+		case len([]rune(current))+1+len([]rune(word)) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = indent + word
+		}
+
+		empty = false
+	}
+
+	return append(lines, current)
+}
+
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+
+	return string(runes[:width])
+}
@@ -0,0 +1,83 @@
+package ticket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/entity/order_entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTicket() Ticket {
+	now := time.Date(2024, 5, 1, 15, 30, 0, 0, time.UTC)
+
+	order := order_entity.NewOrder("c3fdab1b-3c06-4db2-9edc-4760a2429462", now)
+	order.Items = []order_entity.Item{
+		{Id: "item-1", Name: "Hamburger", Quantity: 2, Station: "grill", Modifiers: []string{"no onions"}},
+		{Id: "item-2", Name: "Pão de queijo", Quantity: 1},
+	}
+
+	return New(order, "store-01")
+}
+
+func TestLayout(t *testing.T) {
+	t.Run("Should lay out the ticket", func(t *testing.T) {
+		// Arrange
+		ticket := newTicket()
+
+		// Act
+		lines := ticket.layout(DefaultWidth)
+
+		// Assert
+		texts := make([]string, 0, len(lines))
+		for _, line := range lines {
+			texts = append(texts, line.text)
+		}
+
+		assert.Equal(t, []string{
+			"store-01",
+			"C3FDAB",
+			"Order c3fdab1b-3c06-4db2-9edc-4760a2429462",
+			"Received 01/05/2024 12:30",
+			"------------------------------------------",
+			"2x Hamburger",
+			"   [grill]",
+			"   - no onions",
+			"1x Pão de queijo",
+			"------------------------------------------",
+			"3 item(s)",
+		}, texts)
+		assert.True(t, lines[1].large)
+		assert.True(t, lines[5].bold)
+	})
+
+	t.Run("Should wrap the long lines", func(t *testing.T) {
+		// Arrange
+		ticket := newTicket()
+		ticket.Items[0].Modifiers = []string{"sauce on the side and the bread toasted"}
+
+		// Act
+		lines := ticket.layout(24)
+
+		// Assert
+		assert.Equal(t, "   - sauce on the side", lines[7].text)
+		assert.Equal(t, "     and the bread", lines[8].text)
+		assert.Equal(t, "     toasted", lines[9].text)
+
+		for _, line := range lines {
+			assert.LessOrEqual(t, len([]rune(line.text)), line.columns(24))
+		}
+	})
+
+	t.Run("Should flag the manual orders", func(t *testing.T) {
+		// Arrange
+		ticket := newTicket()
+		ticket.Origin = order_entity.ManualOrigin
+
+		// Act
+		lines := ticket.layout(DefaultWidth)
+
+		// Assert
+		assert.Equal(t, "Manual order", lines[4].text)
+	})
+}
@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+)
+
+var (
+	validateOnce sync.Once
+	validate     *validator.Validate
+)
+
+// Struct validates the input with its validate tags, the failures are returned
+// as a custom_error.ValidationError with the fields named as in the request
+func Struct(input interface{}) error {
+	validateOnce.Do(func() {
+		validate = validator.New()
+		validate.RegisterTagNameFunc(fieldName)
+	})
+
+	err := validate.Struct(input)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return custom_error.ErrRequestNotValid
+	}
+
+	violations := make([]custom_error.Violation, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		violations = append(violations, custom_error.Violation{
+			Field:   fieldPath(fieldErr.Namespace()),
+			Rule:    fieldErr.Tag(),
+			Message: message(fieldErr),
+		})
+	}
+
+	return custom_error.NewValidationError(violations...)
+}
+
+// fieldName uses the name of the field in the body, query or path, the fields
+// that are not part of the request keep the Go name
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query", "param"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// fieldPath removes the name of the struct from the namespace, e.g.
+// CreateOrderProductionInput.items[0].quantity is items[0].quantity
+func fieldPath(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+
+	return path
+}
+
+func message(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "required_if":
+		return fmt.Sprintf("is required when %s", strings.Replace(fieldErr.Param(), " ", " is ", 1))
+	case "required_with":
+		return fmt.Sprintf("is required when %s is informed", fieldErr.Param())
+	case "required_without":
+		return fmt.Sprintf("is required when %s is not informed", fieldErr.Param())
+	case "excluded_with":
+		return fmt.Sprintf("must not be informed with %s", fieldErr.Param())
+	case "uuid4":
+		return "must be a valid UUID v4"
+	case "http_url":
+		return "must be a valid HTTP URL"
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fieldErr.Param(), " ", ", "))
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fieldErr.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", fieldErr.Param())
+	case "min":
+		return fmt.Sprintf("must be at least %s%s", fieldErr.Param(), unit(fieldErr.Kind()))
+	case "max":
+		return fmt.Sprintf("must be at most %s%s", fieldErr.Param(), unit(fieldErr.Kind()))
+	}
+
+	return fmt.Sprintf("does not satisfy the %s rule", fieldErr.Tag())
+}
+
+// unit describes what min and max count for strings and collections
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	}
+
+	return ""
+}
@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo-org/ms-production-management/internal/shared/custom_error"
+	"github.com/stretchr/testify/assert"
+)
+
+type itemInput struct {
+	Quantity int `json:"quantity" validate:"gte=1"`
+}
+
+type orderInput struct {
+	Id     string      `param:"id" json:"-" validate:"required,uuid4"`
+	State  string      `query:"state" validate:"oneof=Received Processing"`
+	Items  []itemInput `json:"items" validate:"required,dive"`
+	Origin string      `json:"-"`
+	User   string      `json:"-" validate:"required_if=Origin manual"`
+}
+
+func TestStruct(t *testing.T) {
+	t.Run("Should return nil when valid", func(t *testing.T) {
+		// Arrange
+		input := orderInput{
+			Id:    "c3fdab1b-3c06-4db2-9edc-4760a2429462",
+			State: "Received",
+			Items: []itemInput{{Quantity: 1}},
+		}
+
+		// Act
+		err := Struct(&input)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should return the violations named as in the request", func(t *testing.T) {
+		// Arrange
+		input := orderInput{
+			Id:     "123",
+			State:  "Unknown",
+			Items:  []itemInput{{Quantity: 1}, {Quantity: 0}},
+			Origin: "manual",
+		}
+
+		// Act
+		err := Struct(&input)
+
+		// Assert
+		assert.ErrorIs(t, err, custom_error.ErrRequestNotValid)
+		assert.Equal(t, []custom_error.Violation{
+			{Field: "id", Rule: "uuid4", Message: "must be a valid UUID v4"},
+			{Field: "state", Rule: "oneof", Message: "must be one of: Received, Processing"},
+			{Field: "items[1].quantity", Rule: "gte", Message: "must be greater than or equal to 1"},
+			{Field: "User", Rule: "required_if", Message: "is required when Origin is manual"},
+		}, custom_error.GetViolations(err))
+	})
+}
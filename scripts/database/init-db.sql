@@ -1,3 +1,8 @@
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TABLE IF EXISTS order_events;
 DROP TABLE IF EXISTS orders;
 DROP TABLE IF EXISTS order_items;
 
@@ -5,6 +10,9 @@ CREATE TABLE IF NOT EXISTS orders (
     order_id varchar(255) NOT NULL UNIQUE,
     state INT,
     state_updated_at TIMESTAMP WITH TIME ZONE,
+    origin varchar(16) NOT NULL DEFAULT 'queue',
+    created_by varchar(255),
+    reconciled_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE,
     updated_at TIMESTAMP WITH TIME ZONE,
     PRIMARY KEY (order_id)
@@ -15,6 +23,105 @@ CREATE TABLE IF NOT EXISTS order_items (
     order_id varchar(255),
     name varchar(255),
     quantity int,
+    station varchar(255) NOT NULL DEFAULT '',
+    modifiers TEXT[] NOT NULL DEFAULT '{}',
     PRIMARY KEY (id),
     FOREIGN KEY (order_id) REFERENCES orders(order_id)
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS order_events (
+    id BIGSERIAL NOT NULL,
+    order_id varchar(255) NOT NULL,
+    type varchar(32) NOT NULL,
+    state INT,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS order_events_created_at_idx ON order_events (created_at);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id varchar(255) NOT NULL UNIQUE,
+    url varchar(2048) NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    secret varchar(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id varchar(255) NOT NULL UNIQUE,
+    subscription_id varchar(255) NOT NULL,
+    event_id varchar(255) NOT NULL,
+    event_type varchar(255) NOT NULL,
+    attempt INT NOT NULL,
+    success BOOLEAN NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (id),
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id)
+);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id varchar(255) NOT NULL UNIQUE,
+    name varchar(255) NOT NULL,
+    prefix varchar(32) NOT NULL UNIQUE,
+    hash varchar(64) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    replaced_by varchar(255),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL NOT NULL,
+    action varchar(64) NOT NULL,
+    resource_type varchar(32) NOT NULL,
+    resource_id varchar(255) NOT NULL,
+    actor_type varchar(16) NOT NULL,
+    actor_id varchar(255) NOT NULL DEFAULT '',
+    source_type varchar(16) NOT NULL DEFAULT '',
+    source_message_id varchar(255) NOT NULL DEFAULT '',
+    request_id varchar(255) NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_resource_idx ON audit_log (resource_type, resource_id);
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log (actor_id);
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+
+-- the audit log is append only, the entries can not be changed or removed
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+-- the row triggers are not fired by TRUNCATE
+DROP TRIGGER IF EXISTS audit_log_append_only_truncate ON audit_log;
+CREATE TRIGGER audit_log_append_only_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+-- the script runs as the role of the application, which only reads and
+-- appends the entries
+REVOKE UPDATE, DELETE, TRUNCATE ON audit_log FROM PUBLIC, CURRENT_USER;
\ No newline at end of file
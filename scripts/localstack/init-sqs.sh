@@ -3,4 +3,7 @@
 echo "Initializing SQS queues..."
 
 awslocal sqs create-queue \
-    --queue-name OrderProductionQueue
\ No newline at end of file
+    --queue-name OrderProductionQueue
+
+awslocal sqs create-queue \
+    --queue-name OrderProductionDeadLetterQueue
\ No newline at end of file
@@ -231,8 +231,9 @@ func InitializeScenario(ctx *godog.ScenarioContext) {
 
 func generateToken(userId string, expire time.Duration) (string, error) {
 	claims := jwt.MapClaims{
-		"sub": userId,
-		"exp": time.Now().Add(expire).Unix(),
+		"sub":   userId,
+		"exp":   time.Now().Add(expire).Unix(),
+		"roles": []string{"manager"},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -408,6 +409,7 @@ func createApiContainer(ctx context.Context, network *testcontainers.DockerNetwo
 				"AWS_BASE_ENDPOINT":               "http://test:4566",
 				"AWS_ORDER_PRODUCTION_QUEUE_NAME": "OrderProductionQueue",
 				"AWS_UPDATE_ORDER_TOPIC_NAME":     "UpdateOrderTopic",
+				"AUTH_SECRET":                     "my-secret",
 			},
 			Networks: []string{
 				network.Name,
@@ -436,7 +438,7 @@ func createApiContainer(ctx context.Context, network *testcontainers.DockerNetwo
 
 	port := ports["8080/tcp"][0].HostPort
 
-	res, err := http.Get(fmt.Sprintf("http://localhost:%s/health", port))
+	res, err := http.Get(fmt.Sprintf("http://localhost:%s/health/ready", port))
 	if err != nil {
 		return nil, ctx, err
 	}